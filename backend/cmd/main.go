@@ -13,6 +13,7 @@ import (
 	"website-analyzer-backend/config"
 	"website-analyzer-backend/database"
 	"website-analyzer-backend/routes"
+	"website-analyzer-backend/services"
 )
 
 func main() {
@@ -24,8 +25,129 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 	
+	// Create the first user on an empty database
+	if err := services.NewUserService().EnsureBootstrapUser(services.BootstrapSettings{
+		Name:     cfg.Auth.AdminName,
+		Email:    cfg.Auth.AdminEmail,
+		APIToken: cfg.Auth.APIToken,
+		Password: cfg.Auth.AdminPassword,
+	}); err != nil {
+		log.Fatalf("Failed to create bootstrap user: %v", err)
+	}
+	
+	// Configure password login, access tokens and the OIDC provider
+	if err := services.ConfigureAuth(services.AuthSettings{
+		SigningKeyPath:  cfg.Auth.SigningKeyPath,
+		Issuer:          cfg.Auth.Issuer,
+		AccessTokenTTL:  cfg.Auth.AccessTokenTTL,
+		RefreshTokenTTL: cfg.Auth.RefreshTokenTTL,
+		OIDC: services.OIDCSettings{
+			Issuer:           cfg.OIDC.Issuer,
+			Audience:         cfg.OIDC.Audience,
+			JWKS:             cfg.OIDC.JWKS,
+			JWKSRefresh:      cfg.OIDC.JWKSRefresh,
+			EmailClaim:       cfg.OIDC.EmailClaim,
+			NameClaim:        cfg.OIDC.NameClaim,
+			RoleClaim:        cfg.OIDC.RoleClaim,
+			RoleMapping:      cfg.OIDC.RoleMapping,
+			DefaultRole:      cfg.OIDC.DefaultRole,
+			AutoProvision:    cfg.OIDC.AutoProvision,
+			DefaultWorkspace: cfg.OIDC.DefaultWorkspace,
+		},
+	}); err != nil {
+		log.Fatalf("Failed to configure authentication: %v", err)
+	}
+	
+	// Create the default workspace and move data that predates workspaces into it
+	defaultWorkspace, err := services.NewWorkspaceService().EnsureDefaultWorkspace()
+	if err != nil {
+		log.Fatalf("Failed to create default workspace: %v", err)
+	}
+	
+	// Load the JavaScript library vulnerability feed (falls back to the bundled feed)
+	if err := services.InitJSLibraryDatabase(cfg.Analyzer.VulnerabilityFeedPath); err != nil {
+		log.Printf("Using bundled vulnerability feed: %v", err)
+	}
+	
+	// Configure the analyzer check pipeline
+	services.ConfigureChecks(services.CheckSettings{
+		Disabled: cfg.Analyzer.DisabledChecks,
+		Timeout:  cfg.Analyzer.CheckTimeout,
+		Timeouts: cfg.Analyzer.CheckTimeouts,
+	})
+	
+	// Import audit rules from the configured rules file into the default workspace
+	if err := services.NewAuditRuleService().ForWorkspace(defaultWorkspace.ID).ImportRulesFile(cfg.Analyzer.AuditRulesPath); err != nil {
+		log.Printf("Failed to load audit rules: %v", err)
+	}
+	
+	// Configure email notifications (critical alerts and digests)
+	services.ConfigureNotifications(services.NotificationSettings{
+		Mail: services.MailSettings{
+			Host:       cfg.SMTP.Host,
+			Port:       cfg.SMTP.Port,
+			Username:   cfg.SMTP.Username,
+			Password:   cfg.SMTP.Password,
+			From:       cfg.SMTP.From,
+			Encryption: cfg.SMTP.Encryption,
+		},
+		DigestHour:    cfg.Notifications.DigestHour,
+		DigestWeekday: cfg.Notifications.DigestWeekday,
+	})
+	
+	// Configure the default monthly analysis quota of each workspace
+	services.ConfigureQuotas(services.QuotaSettings{
+		MonthlyAnalyses: cfg.Quotas.MonthlyAnalyses,
+	})
+	
+	// Start the recurring analysis scheduler
+	var scheduler *services.Scheduler
+	if cfg.Scheduler.Enabled {
+		scheduler = services.NewScheduler(cfg.Scheduler.Tick)
+		scheduler.Start()
+	}
+	
+	// Start delivering queued webhook events
+	var webhookDispatcher *services.WebhookDispatcher
+	if cfg.Webhooks.Enabled {
+		webhookDispatcher = services.NewWebhookDispatcher(services.WebhookSettings{
+			Tick:         cfg.Webhooks.Tick,
+			Timeout:      cfg.Webhooks.Timeout,
+			MaxAttempts:  cfg.Webhooks.MaxAttempts,
+			RetryBackoff: cfg.Webhooks.RetryBackoff,
+		})
+		webhookDispatcher.Start()
+	}
+	
+	// Start probing URLs for uptime
+	services.ConfigureUptime(services.UptimeSettings{
+		Interval:          cfg.Uptime.Interval,
+		Timeout:           cfg.Uptime.Timeout,
+		Concurrency:       cfg.Uptime.Concurrency,
+		IncidentThreshold: cfg.Uptime.IncidentThreshold,
+		Retention:         cfg.Uptime.Retention,
+	})
+	var uptimeMonitor *services.UptimeMonitor
+	if cfg.Uptime.Enabled {
+		uptimeMonitor = services.NewUptimeMonitor()
+		uptimeMonitor.Start()
+	}
+	
+	// Start purging URLs that have been in the trash longer than the retention period
+	var trashPurger *services.TrashPurger
+	if cfg.Trash.Retention > 0 {
+		trashPurger = services.NewTrashPurger(services.TrashSettings{
+			Retention: cfg.Trash.Retention,
+			Interval:  cfg.Trash.PurgeInterval,
+		})
+		trashPurger.Start()
+	}
+	
 	// Setup router
-	router := routes.SetupRouter(cfg)
+	router, err := routes.SetupRouter(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up router: %v", err)
+	}
 	
 	// Create HTTP server
 	server := &http.Server{
@@ -64,6 +186,26 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Stop scheduling new runs and let in-flight analyses finish
+	if scheduler != nil {
+		scheduler.Stop(ctx)
+	}
+
+	// Stop delivering webhooks; pending deliveries are sent after the next start
+	if webhookDispatcher != nil {
+		webhookDispatcher.Stop(ctx)
+	}
+
+	// Stop probing for uptime
+	if uptimeMonitor != nil {
+		uptimeMonitor.Stop(ctx)
+	}
+
+	// Stop purging the trash
+	if trashPurger != nil {
+		trashPurger.Stop(ctx)
+	}
+
 	// Close database connection
 	if err := database.CloseDatabase(); err != nil {
 		log.Printf("Error closing database: %v", err)
@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +15,16 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	Auth     AuthConfig
+	OIDC     OIDCConfig
+	Analyzer AnalyzerConfig
+	Scheduler SchedulerConfig
+	Webhooks  WebhookConfig
+	SMTP          SMTPConfig
+	Notifications NotificationConfig
+	Uptime        UptimeConfig
+	RateLimit     RateLimitConfig
+	Quotas        QuotaConfig
+	Trash         TrashConfig
 }
 
 // ServerConfig holds server configuration
@@ -19,6 +32,8 @@ type ServerConfig struct {
 	Port string
 	Host string
 	Mode string // gin mode: debug, release, test
+
+	TrustedProxies []string // IPs or CIDRs whose X-Forwarded-For is believed; none by default
 }
 
 // DatabaseConfig holds database configuration
@@ -33,7 +48,103 @@ type DatabaseConfig struct {
 
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
-	APIToken string
+	APIToken      string // imported as the first user's API key on an empty database
+	AdminName     string // name of the first user
+	AdminEmail    string // email of the first user
+	AdminPassword string // password of the first user, for POST /api/v1/auth/login
+
+	SigningKeyPath  string        // PEM RSA key that signs access tokens; generated when missing
+	Issuer          string        // iss claim of access tokens issued by the login endpoint
+	AccessTokenTTL  time.Duration // lifetime of access tokens
+	RefreshTokenTTL time.Duration // lifetime of refresh tokens
+}
+
+// OIDCConfig holds external OpenID Connect provider configuration; tokens from
+// the provider are only accepted when an issuer and JWKS are configured
+type OIDCConfig struct {
+	Issuer           string            // expected iss claim
+	Audience         string            // expected aud claim, the client ID; required with an issuer and JWKS
+	JWKS             string            // path or http(s) URL of the provider's JSON Web Key Set
+	JWKSRefresh      time.Duration     // how often a JWKS URL is fetched again
+	EmailClaim       string            // claim holding the user's email address
+	NameClaim        string            // claim holding the user's display name
+	RoleClaim        string            // claim holding role names or groups; roles are not synced when empty
+	RoleMapping      map[string]string // claim value to role; values are used as role names when empty
+	DefaultRole      string            // role of provisioned users whose claims map to no role
+	AutoProvision    bool              // create users on their first login
+	DefaultWorkspace uint              // workspace provisioned users join; 0 leaves them in none
+}
+
+// AnalyzerConfig holds website analyzer configuration
+type AnalyzerConfig struct {
+	VulnerabilityFeedPath string // JSON feed of JavaScript libraries and known vulnerabilities
+	AuditRulesPath        string // YAML or JSON file of audit rules imported at startup
+	DisabledChecks        []string
+	CheckTimeout          time.Duration            // default per-check timeout
+	CheckTimeouts         map[string]time.Duration // per-check timeout overrides
+}
+
+// SchedulerConfig holds recurring analysis scheduler configuration
+type SchedulerConfig struct {
+	Enabled bool
+	Tick    time.Duration // how often due schedules are checked
+}
+
+// WebhookConfig holds outgoing webhook delivery configuration
+type WebhookConfig struct {
+	Enabled      bool
+	Tick         time.Duration // how often due deliveries are sent
+	Timeout      time.Duration // per-request timeout
+	MaxAttempts  int           // attempts before a delivery is marked failed
+	RetryBackoff time.Duration // delay before the first retry, doubled after every failure
+}
+
+// SMTPConfig holds outgoing mail server configuration; mail is disabled without a host
+type SMTPConfig struct {
+	Host       string
+	Port       string
+	Username   string
+	Password   string
+	From       string
+	Encryption string // none, starttls or tls
+}
+
+// NotificationConfig holds email notification configuration
+type NotificationConfig struct {
+	DigestHour    int          // local hour at which daily and weekly digests are sent
+	DigestWeekday time.Weekday // day on which weekly digests are sent
+}
+
+// UptimeConfig holds lightweight uptime monitoring configuration
+type UptimeConfig struct {
+	Enabled           bool
+	Interval          time.Duration // how often every URL is probed
+	Timeout           time.Duration // per-probe timeout
+	Concurrency       int           // probes running at once
+	IncidentThreshold int           // consecutive failed probes that make an incident
+	Retention         time.Duration // how long probe results are kept
+}
+
+// RateLimitConfig holds per-client request rate limits. Expensive operations
+// (analyze, bulk analyze, bulk import) are also limited separately.
+type RateLimitConfig struct {
+	Enabled            bool
+	PerMinute          int // sustained requests per minute
+	Burst              int // requests allowed at once
+	ExpensivePerMinute int
+	ExpensiveBurst     int
+}
+
+// QuotaConfig holds per-workspace usage quotas
+type QuotaConfig struct {
+	MonthlyAnalyses int // default analyses per workspace per calendar month; 0 is unlimited
+}
+
+// TrashConfig holds configuration for deleted URLs, which stay restorable in
+// the trash until they are purged
+type TrashConfig struct {
+	Retention     time.Duration // how long deleted URLs are kept; 0 keeps them until purged by hand
+	PurgeInterval time.Duration // how often expired URLs are purged
 }
 
 // LoadConfig loads configuration from environment variables
@@ -48,6 +159,8 @@ func LoadConfig() *Config {
 			Port: getEnv("SERVER_PORT", "8080"),
 			Host: getEnv("SERVER_HOST", "localhost"),
 			Mode: getEnv("GIN_MODE", "debug"),
+
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -58,7 +171,80 @@ func LoadConfig() *Config {
 			Charset:  getEnv("DB_CHARSET", "utf8mb4"),
 		},
 		Auth: AuthConfig{
-			APIToken: getEnv("API_TOKEN", "your-secret-api-token"),
+			APIToken:      getEnv("API_TOKEN", ""),
+			AdminName:     getEnv("ADMIN_NAME", "Administrator"),
+			AdminEmail:    getEnv("ADMIN_EMAIL", "admin@localhost"),
+			AdminPassword: getEnv("ADMIN_PASSWORD", ""),
+
+			SigningKeyPath:  getEnv("AUTH_SIGNING_KEY_PATH", ""),
+			Issuer:          getEnv("AUTH_ISSUER", "website-analyzer"),
+			AccessTokenTTL:  getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		},
+		OIDC: OIDCConfig{
+			Issuer:           getEnv("OIDC_ISSUER", ""),
+			Audience:         getEnv("OIDC_AUDIENCE", ""),
+			JWKS:             getEnv("OIDC_JWKS", ""),
+			JWKSRefresh:      getEnvDuration("OIDC_JWKS_REFRESH", time.Hour),
+			EmailClaim:       getEnv("OIDC_EMAIL_CLAIM", "email"),
+			NameClaim:        getEnv("OIDC_NAME_CLAIM", "name"),
+			RoleClaim:        getEnv("OIDC_ROLE_CLAIM", ""),
+			RoleMapping:      getEnvMap("OIDC_ROLE_MAPPING"),
+			DefaultRole:      getEnv("OIDC_DEFAULT_ROLE", "viewer"),
+			AutoProvision:    getEnv("OIDC_AUTO_PROVISION", "true") == "true",
+			DefaultWorkspace: uint(getEnvInt("OIDC_DEFAULT_WORKSPACE", 0)),
+		},
+		Analyzer: AnalyzerConfig{
+			VulnerabilityFeedPath: getEnv("VULNERABILITY_FEED_PATH", ""),
+			AuditRulesPath:        getEnv("AUDIT_RULES_PATH", ""),
+			DisabledChecks:        getEnvList("DISABLED_CHECKS"),
+			CheckTimeout:          getEnvDuration("CHECK_TIMEOUT", 30*time.Second),
+			CheckTimeouts:         getEnvDurations("CHECK_TIMEOUTS"),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled: getEnv("SCHEDULER_ENABLED", "true") == "true",
+			Tick:    getEnvDuration("SCHEDULER_TICK", 30*time.Second),
+		},
+		Webhooks: WebhookConfig{
+			Enabled:      getEnv("WEBHOOKS_ENABLED", "true") == "true",
+			Tick:         getEnvDuration("WEBHOOK_TICK", 5*time.Second),
+			Timeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 6),
+			RetryBackoff: getEnvDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+		},
+		SMTP: SMTPConfig{
+			Host:       getEnv("SMTP_HOST", ""),
+			Port:       getEnv("SMTP_PORT", "25"),
+			Username:   getEnv("SMTP_USERNAME", ""),
+			Password:   getEnv("SMTP_PASSWORD", ""),
+			From:       getEnv("SMTP_FROM", "Website Analyzer <noreply@localhost>"),
+			Encryption: strings.ToLower(getEnv("SMTP_ENCRYPTION", "none")),
+		},
+		Notifications: NotificationConfig{
+			DigestHour:    getEnvInt("DIGEST_HOUR", 8),
+			DigestWeekday: getEnvWeekday("DIGEST_WEEKDAY", time.Monday),
+		},
+		Uptime: UptimeConfig{
+			Enabled:           getEnv("UPTIME_ENABLED", "true") == "true",
+			Interval:          getEnvDuration("UPTIME_INTERVAL", 5*time.Minute),
+			Timeout:           getEnvDuration("UPTIME_TIMEOUT", 10*time.Second),
+			Concurrency:       getEnvInt("UPTIME_CONCURRENCY", 10),
+			IncidentThreshold: getEnvInt("UPTIME_INCIDENT_THRESHOLD", 2),
+			Retention:         getEnvDuration("UPTIME_RETENTION", 90*24*time.Hour),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:            getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+			PerMinute:          getEnvInt("RATE_LIMIT_PER_MINUTE", 300),
+			Burst:              getEnvInt("RATE_LIMIT_BURST", 60),
+			ExpensivePerMinute: getEnvInt("RATE_LIMIT_EXPENSIVE_PER_MINUTE", 10),
+			ExpensiveBurst:     getEnvInt("RATE_LIMIT_EXPENSIVE_BURST", 5),
+		},
+		Quotas: QuotaConfig{
+			MonthlyAnalyses: getEnvInt("WORKSPACE_MONTHLY_ANALYSIS_QUOTA", 0),
+		},
+		Trash: TrashConfig{
+			Retention:     getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+			PurgeInterval: getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
 		},
 	}
 
@@ -73,6 +259,83 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvInt gets an integer from an environment variable with a fallback value
+func getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		number, err := strconv.Atoi(value)
+		if err == nil {
+			return number
+		}
+		log.Printf("Invalid integer for %s: %q, using %d", key, value, fallback)
+	}
+	return fallback
+}
+
+// getEnvWeekday gets a day of the week (e.g. "monday") from an environment variable with a fallback value
+func getEnvWeekday(key string, fallback time.Weekday) time.Weekday {
+	if value := os.Getenv(key); value != "" {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(value, day.String()) {
+				return day
+			}
+		}
+		log.Printf("Invalid weekday for %s: %q, using %s", key, value, fallback)
+	}
+	return fallback
+}
+
+// getEnvList gets a comma-separated list from an environment variable
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvDuration gets a duration (e.g. "30s") from an environment variable with a fallback value
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
+			return duration
+		}
+		log.Printf("Invalid duration for %s: %q, using %s", key, value, fallback)
+	}
+	return fallback
+}
+
+// getEnvMap gets "key=value" pairs from a comma-separated environment variable
+func getEnvMap(key string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range getEnvList(key) {
+		name, value, found := strings.Cut(pair, "=")
+		if !found {
+			log.Printf("Invalid entry in %s: %q", key, pair)
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+// getEnvDurations gets "name=duration" pairs from a comma-separated environment variable
+func getEnvDurations(key string) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, pair := range getEnvList(key) {
+		name, value, found := strings.Cut(pair, "=")
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if !found || err != nil {
+			log.Printf("Invalid entry in %s: %q", key, pair)
+			continue
+		}
+		durations[strings.TrimSpace(name)] = duration
+	}
+	return durations
+}
+
 // GetDSN returns the database connection string
 func (c *Config) GetDSN() string {
 	return c.Database.User + ":" + c.Database.Password + "@tcp(" + 
@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertController handles HTTP requests for alert rules and alerts
+type AlertController struct {
+	alertService *services.AlertService
+}
+
+// NewAlertController creates a new alert controller instance
+func NewAlertController() *AlertController {
+	return &AlertController{
+		alertService: services.NewAlertService(),
+	}
+}
+
+// service returns the alert service scoped to the workspace the request acts on
+func (ctrl *AlertController) service(c *gin.Context) *services.AlertService {
+	return ctrl.alertService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllRules handles GET /api/v1/alert-rules
+func (ctrl *AlertController) GetAllRules(c *gin.Context) {
+	rules, err := ctrl.service(c).GetAllRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get alert rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ruleResponses := make([]models.AlertRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		ruleResponses = append(ruleResponses, rule.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       ruleResponses,
+		"conditions": services.AlertConditions(),
+	})
+}
+
+// GetRule handles GET /api/v1/alert-rules/:id
+func (ctrl *AlertController) GetRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid alert rule ID",
+		})
+		return
+	}
+
+	rule, err := ctrl.service(c).GetRuleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get alert rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rule.ToResponse(),
+	})
+}
+
+// CreateRule handles POST /api/v1/alert-rules
+func (ctrl *AlertController) CreateRule(c *gin.Context) {
+	var req models.AlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := ctrl.service(c).CreateRule(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create alert rule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetAlertRule, []uint{rule.ID}, nil, rule.ToResponse())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Alert rule created successfully",
+		"data":    rule.ToResponse(),
+	})
+}
+
+// UpdateRule handles PUT /api/v1/alert-rules/:id
+func (ctrl *AlertController) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid alert rule ID",
+		})
+		return
+	}
+
+	var req models.AlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetRuleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update alert rule")
+		return
+	}
+
+	rule, err := ctrl.service(c).UpdateRule(uint(id), req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update alert rule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetAlertRule, []uint{rule.ID}, before.ToResponse(), rule.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert rule updated successfully",
+		"data":    rule.ToResponse(),
+	})
+}
+
+// DeleteRule handles DELETE /api/v1/alert-rules/:id
+func (ctrl *AlertController) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid alert rule ID",
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetRuleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete alert rule")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteRule(uint(id)); err != nil {
+		ctrl.handleError(c, err, "Failed to delete alert rule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetAlertRule, []uint{before.ID}, before.ToResponse(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert rule deleted successfully",
+	})
+}
+
+// GetAlerts handles GET /api/v1/alerts
+func (ctrl *AlertController) GetAlerts(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	// Parse filter parameters
+	filters := services.AlertFilters{
+		State:    c.Query("state"),
+		Severity: c.Query("severity"),
+	}
+	if urlID, err := strconv.ParseUint(c.Query("url_id"), 10, 32); err == nil {
+		filters.URLID = uint(urlID)
+	}
+	if ruleID, err := strconv.ParseUint(c.Query("rule_id"), 10, 32); err == nil {
+		filters.RuleID = uint(ruleID)
+	}
+
+	alerts, total, err := ctrl.service(c).GetAlerts(page, limit, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get alerts",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": alerts,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetAlert handles GET /api/v1/alerts/:id
+func (ctrl *AlertController) GetAlert(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid alert ID",
+		})
+		return
+	}
+
+	alert, err := ctrl.service(c).GetAlertByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get alert")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": alert,
+	})
+}
+
+// AcknowledgeAlert handles POST /api/v1/alerts/:id/acknowledge
+func (ctrl *AlertController) AcknowledgeAlert(c *gin.Context) {
+	ctrl.changeState(c, ctrl.service(c).AcknowledgeAlert, models.AuditActionAcknowledge, "Alert acknowledged successfully")
+}
+
+// ResolveAlert handles POST /api/v1/alerts/:id/resolve
+func (ctrl *AlertController) ResolveAlert(c *gin.Context) {
+	ctrl.changeState(c, ctrl.service(c).ResolveAlert, models.AuditActionResolve, "Alert resolved successfully")
+}
+
+// changeState applies an alert state transition with an optional note
+func (ctrl *AlertController) changeState(c *gin.Context, transition func(uint, string) (*models.Alert, error), action, message string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid alert ID",
+		})
+		return
+	}
+
+	// The note is optional, so an empty body is accepted
+	var req models.AlertStateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid request payload",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	before, err := ctrl.service(c).GetAlertByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to "+action+" alert")
+		return
+	}
+
+	alert, err := transition(uint(id), req.Note)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to "+action+" alert")
+		return
+	}
+
+	recordAudit(c, action, models.AuditTargetAlert, []uint{alert.ID}, before, alert)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    alert,
+	})
+}
+
+// handleError maps alert service errors to HTTP responses
+func (ctrl *AlertController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "alert rule not found" || err.Error() == "alert not found" || err.Error() == "URL not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidAlertState):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": message,
+			"details": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidAlertRule):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
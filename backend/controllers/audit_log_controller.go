@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController handles HTTP requests for the audit log
+type AuditLogController struct {
+	auditLogService *services.AuditLogService
+}
+
+// NewAuditLogController creates a new audit log controller instance
+func NewAuditLogController() *AuditLogController {
+	return &AuditLogController{
+		auditLogService: services.NewAuditLogService(),
+	}
+}
+
+// GetAuditLog handles GET /api/v1/audit-log
+func (ctrl *AuditLogController) GetAuditLog(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	// Parse filter parameters
+	filters := services.AuditLogFilters{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		RequestID:  c.Query("request_id"),
+		From:       c.Query("from"),
+		To:         c.Query("to"),
+	}
+	if workspaceID, err := strconv.ParseUint(c.Query("workspace_id"), 10, 32); err == nil {
+		id := uint(workspaceID)
+		filters.WorkspaceID = &id
+	}
+	if userID, err := strconv.ParseUint(c.Query("user_id"), 10, 32); err == nil {
+		filters.UserID = uint(userID)
+	}
+	if targetID, err := strconv.ParseUint(c.Query("target_id"), 10, 32); err == nil {
+		filters.TargetID = uint(targetID)
+	}
+
+	entries, total, err := ctrl.auditLogService.GetEntries(page, limit, filters)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAuditLogQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid audit log query",
+				"details": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get audit log",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	entryResponses := make([]models.AuditLogResponse, 0, len(entries))
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, entry.ToResponse())
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entryResponses,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// recordAudit appends an entry for a successful change to the audit log,
+// attributing it to the caller of the request. A failure to record is logged
+// rather than failing a change that has already been made.
+func recordAudit(c *gin.Context, action, targetType string, targetIDs []uint, before, after interface{}) {
+	entry := &models.AuditLogEntry{
+		WorkspaceID: middlewares.CurrentWorkspaceID(c),
+		Action:      action,
+		TargetType:  targetType,
+		RequestID:   middlewares.RequestID(c),
+		SourceIP:    c.ClientIP(),
+		Method:      c.Request.Method,
+		Path:        c.Request.URL.Path,
+	}
+	if user := middlewares.CurrentUser(c); user != nil {
+		entry.UserID = &user.ID
+		entry.UserEmail = user.Email
+	}
+	if apiKey := middlewares.CurrentAPIKey(c); apiKey != nil {
+		entry.APIKeyID = &apiKey.ID
+	}
+
+	if err := services.NewAuditLogService().Record(entry, targetIDs, before, after); err != nil {
+		log.Printf("Failed to record %s %s in audit log (request %s): %v", action, targetType, entry.RequestID, err)
+	}
+}
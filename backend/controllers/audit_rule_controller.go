@@ -0,0 +1,308 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditRuleController handles HTTP requests for user-defined audit rules
+type AuditRuleController struct {
+	ruleService *services.AuditRuleService
+}
+
+// NewAuditRuleController creates a new audit rule controller instance
+func NewAuditRuleController() *AuditRuleController {
+	return &AuditRuleController{
+		ruleService: services.NewAuditRuleService(),
+	}
+}
+
+// service returns the audit rule service scoped to the workspace the request acts on
+func (ctrl *AuditRuleController) service(c *gin.Context) *services.AuditRuleService {
+	return ctrl.ruleService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllRules handles GET /api/v1/rules
+func (ctrl *AuditRuleController) GetAllRules(c *gin.Context) {
+	rules, err := ctrl.service(c).GetAllRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get audit rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rules,
+	})
+}
+
+// GetRule handles GET /api/v1/rules/:id
+func (ctrl *AuditRuleController) GetRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	rule, err := ctrl.service(c).GetRuleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get audit rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rule,
+	})
+}
+
+// CreateRule handles POST /api/v1/rules
+func (ctrl *AuditRuleController) CreateRule(c *gin.Context) {
+	var req models.AuditRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := ctrl.service(c).CreateRule(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create audit rule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetAuditRule, []uint{rule.ID}, nil, rule)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Audit rule created successfully",
+		"data":    rule,
+	})
+}
+
+// UpdateRule handles PUT /api/v1/rules/:id
+func (ctrl *AuditRuleController) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	var req models.AuditRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetRuleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update audit rule")
+		return
+	}
+
+	rule, err := ctrl.service(c).UpdateRule(uint(id), req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update audit rule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetAuditRule, []uint{rule.ID}, before, rule)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Audit rule updated successfully",
+		"data":    rule,
+	})
+}
+
+// DeleteRule handles DELETE /api/v1/rules/:id
+func (ctrl *AuditRuleController) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetRuleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete audit rule")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteRule(uint(id)); err != nil {
+		ctrl.handleError(c, err, "Failed to delete audit rule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetAuditRule, []uint{before.ID}, before, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Audit rule deleted successfully",
+	})
+}
+
+// ImportRules handles POST /api/v1/rules/import
+// Accepts either a multipart "file" upload or a raw YAML/JSON request body.
+func (ctrl *AuditRuleController) ImportRules(c *gin.Context) {
+	var data []byte
+
+	if file, err := c.FormFile("file"); err == nil {
+		// Validate file size (max 1MB)
+		if file.Size > int64(1<<20) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "File size exceeds 1MB limit",
+			})
+			return
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Failed to open uploaded file",
+				"details": err.Error(),
+			})
+			return
+		}
+		defer src.Close()
+
+		if data, err = io.ReadAll(src); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Failed to read uploaded file",
+				"details": err.Error(),
+			})
+			return
+		}
+	} else {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+		if err != nil || len(body) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "A rules file or YAML/JSON body is required",
+			})
+			return
+		}
+		data = body
+	}
+
+	created, updated, err := ctrl.service(c).ImportRules(data)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to import audit rules")
+		return
+	}
+
+	recordAudit(c, models.AuditActionImport, models.AuditTargetAuditRule, nil, nil, gin.H{
+		"created": created,
+		"updated": updated,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Audit rules imported successfully",
+		"data": gin.H{
+			"created": created,
+			"updated": updated,
+		},
+	})
+}
+
+// GetViolations handles GET /api/v1/rule-violations
+func (ctrl *AuditRuleController) GetViolations(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	// Parse filter parameters
+	filters := services.RuleViolationFilters{
+		Severity: c.Query("severity"),
+		History:  c.Query("history") == "true",
+	}
+	if urlID, err := strconv.ParseUint(c.Query("url_id"), 10, 32); err == nil {
+		filters.URLID = uint(urlID)
+	}
+	if ruleID, err := strconv.ParseUint(c.Query("rule_id"), 10, 32); err == nil {
+		filters.RuleID = uint(ruleID)
+	}
+
+	violations, total, err := ctrl.service(c).GetViolations(page, limit, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get rule violations",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": violations,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// handleError maps audit rule service errors to HTTP responses
+func (ctrl *AuditRuleController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "audit rule not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "audit rule already exists":
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidAuditRule):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
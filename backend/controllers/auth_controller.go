@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthController handles HTTP requests for password login and token refresh
+type AuthController struct {
+	authService *services.AuthService
+}
+
+// NewAuthController creates a new auth controller instance
+func NewAuthController() *AuthController {
+	return &AuthController{
+		authService: services.NewAuthService(),
+	}
+}
+
+// Login handles POST /api/v1/auth/login
+func (ctrl *AuthController) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tokens, err := ctrl.authService.Login(req.Email, req.Password)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to log in")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tokens,
+	})
+}
+
+// Refresh handles POST /api/v1/auth/refresh
+func (ctrl *AuthController) Refresh(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tokens, err := ctrl.authService.Refresh(req.RefreshToken)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to refresh token")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tokens,
+	})
+}
+
+// Logout handles POST /api/v1/auth/logout
+func (ctrl *AuthController) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := ctrl.authService.Logout(req.RefreshToken); err != nil {
+		ctrl.handleError(c, err, "Failed to log out")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+	})
+}
+
+// GetKeySet handles GET /api/v1/auth/jwks.json
+func (ctrl *AuthController) GetKeySet(c *gin.Context) {
+	c.JSON(http.StatusOK, ctrl.authService.GetKeySet())
+}
+
+// handleError maps auth service errors to HTTP responses
+func (ctrl *AuthController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Unauthorized",
+			"message": message,
+			"details": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidPassword):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
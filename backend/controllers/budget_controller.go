@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BudgetController handles HTTP requests for performance budgets
+type BudgetController struct {
+	budgetService *services.BudgetService
+}
+
+// NewBudgetController creates a new budget controller instance
+func NewBudgetController() *BudgetController {
+	return &BudgetController{
+		budgetService: services.NewBudgetService(),
+	}
+}
+
+// service returns the budget service scoped to the workspace the request acts on
+func (ctrl *BudgetController) service(c *gin.Context) *services.BudgetService {
+	return ctrl.budgetService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllBudgets handles GET /api/v1/budgets
+func (ctrl *BudgetController) GetAllBudgets(c *gin.Context) {
+	budgets, err := ctrl.service(c).GetAllBudgets()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get budgets",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budgetResponses := make([]models.BudgetResponse, 0, len(budgets))
+	for _, budget := range budgets {
+		budgetResponses = append(budgetResponses, budget.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    budgetResponses,
+		"metrics": services.BudgetMetricNames(),
+	})
+}
+
+// GetBudget handles GET /api/v1/budgets/:id
+func (ctrl *BudgetController) GetBudget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid budget ID",
+		})
+		return
+	}
+
+	budget, err := ctrl.service(c).GetBudgetByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get budget")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": budget.ToResponse(),
+	})
+}
+
+// CreateBudget handles POST /api/v1/budgets
+func (ctrl *BudgetController) CreateBudget(c *gin.Context) {
+	var req models.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budget, err := ctrl.service(c).CreateBudget(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create budget")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetBudget, []uint{budget.ID}, nil, budget.ToResponse())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Budget created successfully",
+		"data":    budget.ToResponse(),
+	})
+}
+
+// UpdateBudget handles PUT /api/v1/budgets/:id
+func (ctrl *BudgetController) UpdateBudget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid budget ID",
+		})
+		return
+	}
+
+	var req models.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetBudgetByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update budget")
+		return
+	}
+
+	budget, err := ctrl.service(c).UpdateBudget(uint(id), req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update budget")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetBudget, []uint{budget.ID}, before.ToResponse(), budget.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget updated successfully",
+		"data":    budget.ToResponse(),
+	})
+}
+
+// DeleteBudget handles DELETE /api/v1/budgets/:id
+func (ctrl *BudgetController) DeleteBudget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid budget ID",
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetBudgetByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete budget")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteBudget(uint(id)); err != nil {
+		ctrl.handleError(c, err, "Failed to delete budget")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetBudget, []uint{before.ID}, before.ToResponse(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget deleted successfully",
+	})
+}
+
+// handleError maps budget service errors to HTTP responses
+func (ctrl *BudgetController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "budget not found" || err.Error() == "URL not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidBudget):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FolderController handles HTTP requests for the folder hierarchy
+type FolderController struct {
+	folderService *services.FolderService
+}
+
+// NewFolderController creates a new folder controller instance
+func NewFolderController() *FolderController {
+	return &FolderController{
+		folderService: services.NewFolderService(),
+	}
+}
+
+// service returns the folder service scoped to the workspace the request acts on
+func (ctrl *FolderController) service(c *gin.Context) *services.FolderService {
+	return ctrl.folderService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllFolders handles GET /api/v1/folders
+func (ctrl *FolderController) GetAllFolders(c *gin.Context) {
+	folders, err := ctrl.service(c).GetAllFolders()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get folders",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": folders,
+	})
+}
+
+// GetFolder handles GET /api/v1/folders/:id
+func (ctrl *FolderController) GetFolder(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid folder ID",
+		})
+		return
+	}
+
+	folder, err := ctrl.service(c).GetFolderByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get folder")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": folder,
+	})
+}
+
+// CreateFolder handles POST /api/v1/folders
+func (ctrl *FolderController) CreateFolder(c *gin.Context) {
+	var req models.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	folder, err := ctrl.service(c).CreateFolder(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create folder")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetFolder, []uint{folder.ID}, nil, folder)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Folder created successfully",
+		"data":    folder,
+	})
+}
+
+// UpdateFolder handles PUT /api/v1/folders/:id
+func (ctrl *FolderController) UpdateFolder(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid folder ID",
+		})
+		return
+	}
+
+	var req models.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetFolderByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update folder")
+		return
+	}
+
+	folder, err := ctrl.service(c).UpdateFolder(uint(id), req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update folder")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetFolder, []uint{folder.ID}, before, folder)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Folder updated successfully",
+		"data":    folder,
+	})
+}
+
+// DeleteFolder handles DELETE /api/v1/folders/:id
+func (ctrl *FolderController) DeleteFolder(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid folder ID",
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetFolderByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete folder")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteFolder(uint(id)); err != nil {
+		ctrl.handleError(c, err, "Failed to delete folder")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetFolder, []uint{before.ID}, before, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Folder deleted successfully",
+	})
+}
+
+// handleError maps folder service errors to HTTP responses
+func (ctrl *FolderController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "folder not found" || err.Error() == "parent folder not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "folder already exists":
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": message,
+			"details": "A folder with this name already exists in the parent folder",
+		})
+	case errors.Is(err, services.ErrInvalidFolder):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
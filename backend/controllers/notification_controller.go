@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController handles HTTP requests for users' email notification preferences
+type NotificationController struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationController creates a new notification controller instance
+func NewNotificationController() *NotificationController {
+	return &NotificationController{
+		notificationService: services.NewNotificationService(),
+	}
+}
+
+// service returns the notification service scoped to the workspace the request acts on
+func (ctrl *NotificationController) service(c *gin.Context) *services.NotificationService {
+	return ctrl.notificationService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllPreferences handles GET /api/v1/notification-preferences
+func (ctrl *NotificationController) GetAllPreferences(c *gin.Context) {
+	preferences, err := ctrl.service(c).GetAllPreferences()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get notification preferences",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": preferences,
+	})
+}
+
+// GetPreference handles GET /api/v1/notification-preferences/:id
+func (ctrl *NotificationController) GetPreference(c *gin.Context) {
+	id, ok := parsePreferenceID(c)
+	if !ok {
+		return
+	}
+
+	preference, err := ctrl.service(c).GetPreferenceByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get notification preference")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": preference,
+	})
+}
+
+// CreatePreference handles POST /api/v1/notification-preferences
+func (ctrl *NotificationController) CreatePreference(c *gin.Context) {
+	var req models.NotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	preference, err := ctrl.service(c).CreatePreference(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create notification preference")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetNotificationPreference, []uint{preference.ID}, nil, preference)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Notification preference created successfully",
+		"data":    preference,
+	})
+}
+
+// UpdatePreference handles PUT /api/v1/notification-preferences/:id
+func (ctrl *NotificationController) UpdatePreference(c *gin.Context) {
+	id, ok := parsePreferenceID(c)
+	if !ok {
+		return
+	}
+
+	var req models.NotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetPreferenceByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update notification preference")
+		return
+	}
+
+	preference, err := ctrl.service(c).UpdatePreference(id, req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update notification preference")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetNotificationPreference, []uint{preference.ID}, before, preference)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification preference updated successfully",
+		"data":    preference,
+	})
+}
+
+// GetOwnPreference handles GET /api/v1/me/notification-preferences
+func (ctrl *NotificationController) GetOwnPreference(c *gin.Context) {
+	preference, err := ctrl.service(c).GetUserPreference(middlewares.CurrentUser(c).ID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": preference,
+	})
+}
+
+// UpdateOwnPreference handles PUT /api/v1/me/notification-preferences
+func (ctrl *NotificationController) UpdateOwnPreference(c *gin.Context) {
+	var req models.NotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := middlewares.CurrentUser(c).ID
+	before, err := ctrl.service(c).GetUserPreference(userID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update notification preferences")
+		return
+	}
+
+	preference, err := ctrl.service(c).SaveUserPreference(userID, req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update notification preferences")
+		return
+	}
+
+	if before.ID == 0 {
+		recordAudit(c, models.AuditActionCreate, models.AuditTargetNotificationPreference, []uint{preference.ID}, nil, preference)
+	} else {
+		recordAudit(c, models.AuditActionUpdate, models.AuditTargetNotificationPreference, []uint{preference.ID}, before, preference)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification preferences updated successfully",
+		"data":    preference,
+	})
+}
+
+// DeletePreference handles DELETE /api/v1/notification-preferences/:id
+func (ctrl *NotificationController) DeletePreference(c *gin.Context) {
+	id, ok := parsePreferenceID(c)
+	if !ok {
+		return
+	}
+
+	before, err := ctrl.service(c).GetPreferenceByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete notification preference")
+		return
+	}
+
+	if err := ctrl.service(c).DeletePreference(id); err != nil {
+		ctrl.handleError(c, err, "Failed to delete notification preference")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetNotificationPreference, []uint{before.ID}, before, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification preference deleted successfully",
+	})
+}
+
+// SendTestEmail handles POST /api/v1/notification-preferences/:id/test
+func (ctrl *NotificationController) SendTestEmail(c *gin.Context) {
+	id, ok := parsePreferenceID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service(c).SendTestEmail(id); err != nil {
+		ctrl.handleError(c, err, "Failed to send test email")
+		return
+	}
+
+	recordAudit(c, models.AuditActionSendTest, models.AuditTargetNotificationPreference, []uint{id}, nil, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Test email sent successfully",
+	})
+}
+
+// SendDigest handles POST /api/v1/notification-preferences/:id/digest
+func (ctrl *NotificationController) SendDigest(c *gin.Context) {
+	id, ok := parsePreferenceID(c)
+	if !ok {
+		return
+	}
+
+	digest, sent, err := ctrl.service(c).SendDigestNow(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to send digest")
+		return
+	}
+
+	recordAudit(c, models.AuditActionSendDigest, models.AuditTargetNotificationPreference, []uint{id}, nil, gin.H{
+		"sent": sent,
+	})
+
+	message := "Digest sent successfully"
+	if !sent {
+		message = "Digest is empty and was not sent"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"sent":    sent,
+		"data":    digest,
+	})
+}
+
+// parsePreferenceID parses the notification preference ID path parameter, responding with 400 if it is invalid
+func parsePreferenceID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid notification preference ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError maps notification service errors to HTTP responses
+func (ctrl *NotificationController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "notification preference not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "notification preference already exists":
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidNotificationPreference):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	case errors.Is(err, services.ErrSMTPNotConfigured):
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "Service Unavailable",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SavedViewController handles HTTP requests for saved URL list views
+type SavedViewController struct {
+	savedViewService *services.SavedViewService
+}
+
+// NewSavedViewController creates a new saved view controller instance
+func NewSavedViewController() *SavedViewController {
+	return &SavedViewController{
+		savedViewService: services.NewSavedViewService(),
+	}
+}
+
+// service returns the saved view service scoped to the caller's views in the
+// workspace the request acts on
+func (ctrl *SavedViewController) service(c *gin.Context) *services.SavedViewService {
+	return ctrl.savedViewService.ForWorkspace(middlewares.CurrentWorkspaceID(c)).ForUser(middlewares.CurrentUser(c).ID)
+}
+
+// GetAllViews handles GET /api/v1/views
+func (ctrl *SavedViewController) GetAllViews(c *gin.Context) {
+	views, err := ctrl.service(c).GetAllViews()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get saved views",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	viewResponses := make([]models.SavedViewResponse, 0, len(views))
+	for _, view := range views {
+		viewResponses = append(viewResponses, view.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": viewResponses,
+	})
+}
+
+// GetView handles GET /api/v1/views/:id
+func (ctrl *SavedViewController) GetView(c *gin.Context) {
+	id, ok := parseSavedViewID(c)
+	if !ok {
+		return
+	}
+
+	view, err := ctrl.service(c).GetViewByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get saved view")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": view.ToResponse(),
+	})
+}
+
+// CreateView handles POST /api/v1/views
+func (ctrl *SavedViewController) CreateView(c *gin.Context) {
+	var req models.SavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	view, err := ctrl.service(c).CreateView(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create saved view")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetSavedView, []uint{view.ID}, nil, view.ToResponse())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Saved view created successfully",
+		"data":    view.ToResponse(),
+	})
+}
+
+// UpdateView handles PUT /api/v1/views/:id
+func (ctrl *SavedViewController) UpdateView(c *gin.Context) {
+	id, ok := parseSavedViewID(c)
+	if !ok {
+		return
+	}
+
+	var req models.SavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetViewByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update saved view")
+		return
+	}
+	beforeResponse := before.ToResponse()
+
+	view, err := ctrl.service(c).UpdateView(id, req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update saved view")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetSavedView, []uint{view.ID}, beforeResponse, view.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Saved view updated successfully",
+		"data":    view.ToResponse(),
+	})
+}
+
+// DeleteView handles DELETE /api/v1/views/:id
+func (ctrl *SavedViewController) DeleteView(c *gin.Context) {
+	id, ok := parseSavedViewID(c)
+	if !ok {
+		return
+	}
+
+	before, err := ctrl.service(c).GetViewByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete saved view")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteView(id); err != nil {
+		ctrl.handleError(c, err, "Failed to delete saved view")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetSavedView, []uint{before.ID}, before.ToResponse(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Saved view deleted successfully",
+	})
+}
+
+// parseSavedViewID reads the :id parameter, responding with 400 if it is invalid
+func parseSavedViewID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid saved view ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError maps saved view service errors to HTTP responses
+func (ctrl *SavedViewController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "saved view not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "saved view already exists":
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": message,
+			"details": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidSavedView) || errors.Is(err, services.ErrInvalidFilter):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
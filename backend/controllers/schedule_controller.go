@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleController handles HTTP requests for recurring analysis schedules
+type ScheduleController struct {
+	scheduleService *services.ScheduleService
+}
+
+// NewScheduleController creates a new schedule controller instance
+func NewScheduleController() *ScheduleController {
+	return &ScheduleController{
+		scheduleService: services.NewScheduleService(),
+	}
+}
+
+// service returns the schedule service scoped to the workspace the request acts on
+func (ctrl *ScheduleController) service(c *gin.Context) *services.ScheduleService {
+	return ctrl.scheduleService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllSchedules handles GET /api/v1/schedules
+func (ctrl *ScheduleController) GetAllSchedules(c *gin.Context) {
+	schedules, err := ctrl.service(c).GetAllSchedules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get schedules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	scheduleResponses := make([]models.ScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		scheduleResponses = append(scheduleResponses, schedule.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": scheduleResponses,
+	})
+}
+
+// GetSchedule handles GET /api/v1/schedules/:id
+func (ctrl *ScheduleController) GetSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid schedule ID",
+		})
+		return
+	}
+
+	schedule, err := ctrl.service(c).GetScheduleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": schedule.ToResponse(),
+	})
+}
+
+// CreateSchedule handles POST /api/v1/schedules
+func (ctrl *ScheduleController) CreateSchedule(c *gin.Context) {
+	var req models.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	schedule, err := ctrl.service(c).CreateSchedule(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create schedule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetSchedule, []uint{schedule.ID}, nil, schedule.ToResponse())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Schedule created successfully",
+		"data":    schedule.ToResponse(),
+	})
+}
+
+// UpdateSchedule handles PUT /api/v1/schedules/:id
+func (ctrl *ScheduleController) UpdateSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid schedule ID",
+		})
+		return
+	}
+
+	var req models.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetScheduleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update schedule")
+		return
+	}
+
+	schedule, err := ctrl.service(c).UpdateSchedule(uint(id), req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update schedule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetSchedule, []uint{schedule.ID}, before.ToResponse(), schedule.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Schedule updated successfully",
+		"data":    schedule.ToResponse(),
+	})
+}
+
+// DeleteSchedule handles DELETE /api/v1/schedules/:id
+func (ctrl *ScheduleController) DeleteSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid schedule ID",
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetScheduleByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete schedule")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteSchedule(uint(id)); err != nil {
+		ctrl.handleError(c, err, "Failed to delete schedule")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetSchedule, []uint{before.ID}, before.ToResponse(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Schedule deleted successfully",
+	})
+}
+
+// handleError maps schedule service errors to HTTP responses
+func (ctrl *ScheduleController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "schedule not found" || err.Error() == "URL not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidSchedule):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagController handles HTTP requests for tags
+type TagController struct {
+	tagService *services.TagService
+}
+
+// NewTagController creates a new tag controller instance
+func NewTagController() *TagController {
+	return &TagController{
+		tagService: services.NewTagService(),
+	}
+}
+
+// service returns the tag service scoped to the workspace the request acts on
+func (ctrl *TagController) service(c *gin.Context) *services.TagService {
+	return ctrl.tagService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllTags handles GET /api/v1/tags
+func (ctrl *TagController) GetAllTags(c *gin.Context) {
+	tags, counts, err := ctrl.service(c).GetAllTags()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get tags",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tagResponses := make([]models.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		tagResponses = append(tagResponses, tag.ToResponse(counts[tag.ID]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tagResponses,
+	})
+}
+
+// GetTag handles GET /api/v1/tags/:id
+func (ctrl *TagController) GetTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid tag ID",
+		})
+		return
+	}
+
+	tag, urlCount, err := ctrl.service(c).GetTagByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tag.ToResponse(urlCount),
+	})
+}
+
+// CreateTag handles POST /api/v1/tags
+func (ctrl *TagController) CreateTag(c *gin.Context) {
+	var req models.TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tag, err := ctrl.service(c).CreateTag(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create tag")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetTag, []uint{tag.ID}, nil, tag.ToResponse(0))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tag created successfully",
+		"data":    tag.ToResponse(0),
+	})
+}
+
+// UpdateTag handles PUT /api/v1/tags/:id
+func (ctrl *TagController) UpdateTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid tag ID",
+		})
+		return
+	}
+
+	var req models.TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, urlCount, err := ctrl.service(c).GetTagByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update tag")
+		return
+	}
+	beforeResponse := before.ToResponse(urlCount)
+
+	tag, err := ctrl.service(c).UpdateTag(uint(id), req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update tag")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetTag, []uint{tag.ID}, beforeResponse, tag.ToResponse(urlCount))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag updated successfully",
+		"data":    tag.ToResponse(urlCount),
+	})
+}
+
+// DeleteTag handles DELETE /api/v1/tags/:id
+func (ctrl *TagController) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid tag ID",
+		})
+		return
+	}
+
+	before, urlCount, err := ctrl.service(c).GetTagByID(uint(id))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete tag")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteTag(uint(id)); err != nil {
+		ctrl.handleError(c, err, "Failed to delete tag")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetTag, []uint{before.ID}, before.ToResponse(urlCount), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+	})
+}
+
+// handleError maps tag service errors to HTTP responses
+func (ctrl *TagController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "tag not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "tag already exists" || errors.Is(err, services.ErrTagInUse):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": message,
+			"details": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidTag):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrendController handles HTTP requests for historical metric trends
+type TrendController struct {
+	trendService *services.TrendService
+}
+
+// NewTrendController creates a new trend controller instance
+func NewTrendController() *TrendController {
+	return &TrendController{
+		trendService: services.NewTrendService(),
+	}
+}
+
+// service returns the trend service scoped to the workspace the request acts on
+func (ctrl *TrendController) service(c *gin.Context) *services.TrendService {
+	return ctrl.trendService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetURLTrends handles GET /api/v1/urls/:id/trends
+func (ctrl *TrendController) GetURLTrends(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	report, err := ctrl.service(c).GetURLTrends(uint(id), trendOptions(c))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get trends")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    report,
+		"metrics": services.TrendMetricNames(),
+	})
+}
+
+// GetPortfolioTrends handles GET /api/v1/trends
+func (ctrl *TrendController) GetPortfolioTrends(c *gin.Context) {
+	report, err := ctrl.service(c).GetPortfolioTrends(c.Query("tag"), trendOptions(c))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get trends")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    report,
+		"metrics": services.TrendMetricNames(),
+	})
+}
+
+// trendOptions reads the trend query parameters
+func trendOptions(c *gin.Context) services.TrendOptions {
+	return services.TrendOptions{
+		Metrics: c.Query("metrics"),
+		From:    c.Query("from"),
+		To:      c.Query("to"),
+		Bucket:  c.Query("bucket"),
+	}
+}
+
+// handleError maps trend service errors to HTTP responses
+func (ctrl *TrendController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "URL not found" || err.Error() == "tag not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidTrendQuery):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
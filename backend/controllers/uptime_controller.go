@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UptimeController handles HTTP requests for uptime reports
+type UptimeController struct {
+	uptimeService *services.UptimeService
+}
+
+// NewUptimeController creates a new uptime controller instance
+func NewUptimeController() *UptimeController {
+	return &UptimeController{
+		uptimeService: services.NewUptimeService(),
+	}
+}
+
+// service returns the uptime service scoped to the workspace the request acts on
+func (ctrl *UptimeController) service(c *gin.Context) *services.UptimeService {
+	return ctrl.uptimeService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetUptime handles GET /api/v1/urls/:id/uptime
+func (ctrl *UptimeController) GetUptime(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	report, err := ctrl.service(c).GetUptime(uint(id), c.Query("range"), c.Query("checks") == "true")
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get uptime")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// handleError maps uptime service errors to HTTP responses
+func (ctrl *UptimeController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "URL not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidUptimeRange):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
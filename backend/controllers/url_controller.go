@@ -1,29 +1,48 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 
+	"website-analyzer-backend/middlewares"
 	"website-analyzer-backend/models"
 	"website-analyzer-backend/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// maxURLPageLimit caps the limit of numbered URL list pages
+	maxURLPageLimit = 100
+	// maxURLCursorPageLimit caps the limit of cursor-paginated URL list pages
+	maxURLCursorPageLimit = 1000
+)
+
 // URLController handles HTTP requests for URL operations
 type URLController struct {
-	urlService    *services.URLService
-	importService *services.ImportService
+	urlService       *services.URLService
+	importService    *services.ImportService
+	savedViewService *services.SavedViewService
 }
 
 // NewURLController creates a new URL controller instance
 func NewURLController() *URLController {
 	return &URLController{
-		urlService:    services.NewURLService(),
-		importService: services.NewImportService(),
+		urlService:       services.NewURLService(),
+		importService:    services.NewImportService(),
+		savedViewService: services.NewSavedViewService(),
 	}
 }
 
+// service returns the URL service scoped to the workspace the request acts on
+func (ctrl *URLController) service(c *gin.Context) *services.URLService {
+	return ctrl.urlService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
 // CreateURL handles POST /api/urls
 func (ctrl *URLController) CreateURL(c *gin.Context) {
 	var req models.URLCreateRequest
@@ -36,7 +55,7 @@ func (ctrl *URLController) CreateURL(c *gin.Context) {
 		return
 	}
 
-	url, err := ctrl.urlService.CreateURL(req)
+	url, err := ctrl.service(c).CreateURL(req)
 	if err != nil {
 		if err.Error() == "URL already exists" {
 			c.JSON(http.StatusConflict, gin.H{
@@ -45,6 +64,31 @@ func (ctrl *URLController) CreateURL(c *gin.Context) {
 			})
 			return
 		}
+		var trashedErr *services.TrashedURLError
+		if errors.As(err, &trashedErr) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":       "Conflict",
+				"message":     err.Error(),
+				"details":     fmt.Sprintf("Restore it with POST /api/v1/urls/%d/restore to keep its history, or purge it from the trash first", trashedErr.URL.ID),
+				"trashed_url": trashedErr.URL.ToResponse(),
+			})
+			return
+		}
+		if err.Error() == "folder not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Not Found",
+				"message": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidTag) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid tags",
+				"details": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Internal Server Error",
 			"message": "Failed to create URL",
@@ -53,6 +97,8 @@ func (ctrl *URLController) CreateURL(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetURL, []uint{url.ID}, nil, url.ToResponse())
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "URL created successfully",
 		"data":    url.ToResponse(),
@@ -71,7 +117,7 @@ func (ctrl *URLController) GetURL(c *gin.Context) {
 		return
 	}
 
-	url, err := ctrl.urlService.GetURLByID(uint(id))
+	url, err := ctrl.service(c).GetURLByID(uint(id))
 	if err != nil {
 		if err.Error() == "URL not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -104,20 +150,109 @@ func (ctrl *URLController) GetAllURLs(c *gin.Context) {
 		page = 1
 	}
 
+	// Cursor pagination, requested with a cursor parameter that is empty for the
+	// first page, does not slow down on deep pages and allows larger ones
+	options := services.URLListOptions{Page: page, SkipCount: c.Query("count") == "false"}
+	maxLimit := maxURLPageLimit
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		options.Cursor = &cursor
+		maxLimit = maxURLCursorPageLimit
+	}
+
 	limit, err := strconv.Atoi(limitParam)
-	if err != nil || limit < 1 || limit > 100 {
+	if err != nil || limit < 1 || limit > maxLimit {
 		limit = 10
 	}
+	options.Limit = limit
+
+	// Parse the sparse fieldset
+	if fieldsParam := c.Query("fields"); fieldsParam != "" {
+		fields, err := services.NormalizeURLFields(strings.Split(fieldsParam, ","))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid fields",
+				"details": err.Error(),
+			})
+			return
+		}
+		options.Fields = fields
+	}
 
 	// Parse filter parameters
 	filters := services.URLFilters{
-		Search:    c.Query("search"),
-		Status:    c.Query("status"),
-		SortBy:    c.Query("sort_by"),
-		SortOrder: c.Query("sort_order"),
+		Search:     c.Query("search"),
+		Status:     c.Query("status"),
+		Technology: c.Query("technology"),
+		SortBy:     c.Query("sort_by"),
+		SortOrder:  c.Query("sort_order"),
+		OverBudget: c.Query("over_budget") == "true",
+	}
+	if ruleID, err := strconv.ParseUint(c.Query("rule_id"), 10, 32); err == nil {
+		filters.RuleID = uint(ruleID)
+	}
+	for _, tag := range c.QueryArray("tag") {
+		filters.Tags = append(filters.Tags, strings.Split(tag, ",")...)
+	}
+	if folderParam := c.Query("folder_id"); folderParam == "none" {
+		var none uint
+		filters.FolderID = &none
+	} else if folderID, err := strconv.ParseUint(folderParam, 10, 32); err == nil {
+		id := uint(folderID)
+		filters.FolderID = &id
 	}
 
-	urls, total, err := ctrl.urlService.GetAllURLs(page, limit, filters)
+	// Apply the filter expression and sort, combined with a saved view's
+	filters.Query = strings.TrimSpace(c.Query("filter"))
+	filters.Sort = c.Query("sort")
+	if viewParam := c.Query("view"); viewParam != "" {
+		viewID, err := strconv.ParseUint(viewParam, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid saved view ID",
+			})
+			return
+		}
+		view, err := ctrl.savedViewService.ForWorkspace(middlewares.CurrentWorkspaceID(c)).ForUser(middlewares.CurrentUser(c).ID).GetViewByID(uint(viewID))
+		if err != nil {
+			if err.Error() == "saved view not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error":   "Not Found",
+					"message": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Internal Server Error",
+				"message": "Failed to get saved view",
+				"details": err.Error(),
+			})
+			return
+		}
+		if view.Filter != "" && filters.Query != "" {
+			filters.Query = "(" + view.Filter + ") AND (" + filters.Query + ")"
+		} else if view.Filter != "" {
+			filters.Query = view.Filter
+		}
+		if filters.Sort == "" {
+			filters.Sort = view.Sort
+		}
+	}
+
+	result, err := ctrl.service(c).GetAllURLs(filters, options)
+	if errors.Is(err, services.ErrInvalidFilter) || errors.Is(err, services.ErrInvalidCursor) {
+		message := "Invalid filter"
+		if errors.Is(err, services.ErrInvalidCursor) {
+			message = "Invalid cursor"
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Internal Server Error",
@@ -128,25 +263,104 @@ func (ctrl *URLController) GetAllURLs(c *gin.Context) {
 	}
 
 	// Convert to response format
-	var urlResponses []models.URLResponse
-	for _, url := range urls {
-		urlResponses = append(urlResponses, url.ToResponse())
+	var data interface{}
+	if len(options.Fields) > 0 {
+		sparseResponses := make([]gin.H, 0, len(result.URLs))
+		for _, url := range result.URLs {
+			sparseResponses = append(sparseResponses, sparseURLResponse(url.ToResponse(), options.Fields))
+		}
+		data = sparseResponses
+	} else {
+		var urlResponses []models.URLResponse
+		for _, url := range result.URLs {
+			urlResponses = append(urlResponses, url.ToResponse())
+		}
+		data = urlResponses
 	}
 
 	// Calculate pagination metadata
-	totalPages := (int(total) + limit - 1) / limit
+	pagination := gin.H{
+		"limit":    limit,
+		"has_more": result.HasMore,
+	}
+	if options.Cursor != nil {
+		pagination["next_cursor"] = nil
+		if result.NextCursor != "" {
+			pagination["next_cursor"] = result.NextCursor
+		}
+	} else {
+		pagination["page"] = page
+	}
+	if result.Total != nil {
+		pagination["total"] = *result.Total
+		pagination["total_pages"] = (int(*result.Total) + limit - 1) / limit
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": urlResponses,
-		"pagination": gin.H{
-			"page":        page,
-			"limit":       limit,
-			"total":       total,
-			"total_pages": totalPages,
+		"data":       data,
+		"pagination": pagination,
+	})
+}
+
+// sparseURLResponse keeps only the named top-level fields of a URL response
+func sparseURLResponse(response models.URLResponse, fields []string) gin.H {
+	sparse := gin.H{}
+	value := reflect.ValueOf(response)
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		for _, field := range fields {
+			if field == name {
+				sparse[name] = value.Field(i).Interface()
+			}
+		}
+	}
+	return sparse
+}
+
+// GetURLFindings handles GET /api/urls/:id/findings
+func (ctrl *URLController) GetURLFindings(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	findings, metrics, err := ctrl.service(c).GetURLFindings(uint(id), c.Query("check"), c.Query("severity"))
+	if err != nil {
+		if err.Error() == "URL not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Not Found",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get findings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"findings": findings,
+			"metrics":  metrics,
 		},
 	})
 }
 
+// GetChecks handles GET /api/checks
+func (ctrl *URLController) GetChecks(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": ctrl.service(c).GetChecks(),
+	})
+}
+
 // UpdateURL handles PUT /api/urls/:id
 func (ctrl *URLController) UpdateURL(c *gin.Context) {
 	idParam := c.Param("id")
@@ -169,15 +383,28 @@ func (ctrl *URLController) UpdateURL(c *gin.Context) {
 		return
 	}
 
-	url, err := ctrl.urlService.UpdateURL(uint(id), req)
+	// Snapshot the URL for the audit log before it changes
+	var url *models.URL
+	before, err := ctrl.service(c).GetURLByID(uint(id))
+	if err == nil {
+		url, err = ctrl.service(c).UpdateURL(uint(id), req)
+	}
 	if err != nil {
-		if err.Error() == "URL not found" {
+		if err.Error() == "URL not found" || err.Error() == "folder not found" {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error":   "Not Found",
 				"message": err.Error(),
 			})
 			return
 		}
+		if errors.Is(err, services.ErrInvalidTag) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid tags",
+				"details": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Internal Server Error",
 			"message": "Failed to update URL",
@@ -186,6 +413,8 @@ func (ctrl *URLController) UpdateURL(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetURL, []uint{url.ID}, before.ToResponse(), url.ToResponse())
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "URL updated successfully",
 		"data":    url.ToResponse(),
@@ -205,7 +434,7 @@ func (ctrl *URLController) DeleteURL(c *gin.Context) {
 	}
 
 	// Delete URL and get detailed response
-	deleteResponse, err := ctrl.urlService.DeleteURLWithDetails(uint(id))
+	deleteResponse, err := ctrl.service(c).DeleteURLWithDetails(uint(id))
 	if err != nil {
 		if err.Error() == "URL not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -222,6 +451,8 @@ func (ctrl *URLController) DeleteURL(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetURL, []uint{uint(id)}, deleteResponse.DeletedURL, nil)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "URL deleted successfully",
 		"data":    deleteResponse,
@@ -241,7 +472,7 @@ func (ctrl *URLController) AnalyzeURL(c *gin.Context) {
 	}
 
 	// Perform synchronous analysis and get the complete result
-	url, err := ctrl.urlService.AnalyzeURLSync(uint(id))
+	url, err := ctrl.service(c).AnalyzeURLSync(uint(id))
 	if err != nil {
 		if err.Error() == "URL not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -250,6 +481,14 @@ func (ctrl *URLController) AnalyzeURL(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, services.ErrQuotaExceeded) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too Many Requests",
+				"message": "Monthly analysis quota exceeded",
+				"details": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Internal Server Error",
 			"message": "Failed to analyze URL",
@@ -258,6 +497,8 @@ func (ctrl *URLController) AnalyzeURL(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, models.AuditActionAnalyze, models.AuditTargetURL, []uint{url.ID}, nil, nil)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "URL analysis completed successfully",
 		"data":    url.ToResponse(),
@@ -276,7 +517,7 @@ func (ctrl *URLController) AnalyzeURLAsync(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.urlService.AnalyzeURL(uint(id))
+	err = ctrl.service(c).AnalyzeURL(uint(id))
 	if err != nil {
 		if err.Error() == "URL not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -285,6 +526,14 @@ func (ctrl *URLController) AnalyzeURLAsync(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, services.ErrQuotaExceeded) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too Many Requests",
+				"message": "Monthly analysis quota exceeded",
+				"details": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Internal Server Error",
 			"message": "Failed to start URL analysis",
@@ -293,6 +542,8 @@ func (ctrl *URLController) AnalyzeURLAsync(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, models.AuditActionAnalyze, models.AuditTargetURL, []uint{uint(id)}, nil, nil)
+
 	c.JSON(http.StatusAccepted, gin.H{
 		"message": "URL analysis started successfully",
 	})
@@ -319,7 +570,7 @@ func (ctrl *URLController) BulkDeleteURLs(c *gin.Context) {
 	}
 
 	// Delete URLs and get detailed response
-	deleteResponse, err := ctrl.urlService.BulkDeleteURLsWithDetails(req.IDs)
+	deleteResponse, err := ctrl.service(c).BulkDeleteURLsWithDetails(req.IDs)
 	if err != nil {
 		if err.Error() == "no URLs found with the provided IDs" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -336,6 +587,12 @@ func (ctrl *URLController) BulkDeleteURLs(c *gin.Context) {
 		return
 	}
 
+	deletedIDs := make([]uint, 0, len(deleteResponse.DeletedURLs))
+	for _, url := range deleteResponse.DeletedURLs {
+		deletedIDs = append(deletedIDs, url.ID)
+	}
+	recordAudit(c, models.AuditActionBulkDelete, models.AuditTargetURL, deletedIDs, deleteResponse.DeletedURLs, nil)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "URLs deleted successfully",
 		"data":    deleteResponse,
@@ -362,7 +619,7 @@ func (ctrl *URLController) BulkAnalyzeURLs(c *gin.Context) {
 		return
 	}
 
-	err := ctrl.urlService.BulkAnalyzeURLs(req.IDs)
+	ids, err := ctrl.service(c).BulkAnalyzeURLs(req.IDs)
 	if err != nil {
 		if err.Error() == "no URLs found with the provided IDs" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -371,6 +628,14 @@ func (ctrl *URLController) BulkAnalyzeURLs(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, services.ErrQuotaExceeded) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too Many Requests",
+				"message": "Monthly analysis quota exceeded",
+				"details": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Internal Server Error",
 			"message": "Failed to start bulk analysis",
@@ -379,6 +644,8 @@ func (ctrl *URLController) BulkAnalyzeURLs(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, models.AuditActionBulkAnalyze, models.AuditTargetURL, ids, nil, nil)
+
 	c.JSON(http.StatusAccepted, gin.H{
 		"message": "Bulk analysis started successfully",
 	})
@@ -428,7 +695,7 @@ func (ctrl *URLController) BulkImportURLs(c *gin.Context) {
 	}
 
 	// Import URLs
-	createdURLs, importErrors := ctrl.urlService.BulkImportURLs(result.URLs)
+	createdURLs, importErrors := ctrl.service(c).BulkImportURLs(result.URLs)
 
 	// Combine parsing and import errors
 	allErrors := result.Errors
@@ -438,10 +705,18 @@ func (ctrl *URLController) BulkImportURLs(c *gin.Context) {
 
 	// Convert created URLs to response format
 	var urlResponses []models.URLResponse
+	createdIDs := make([]uint, 0, len(createdURLs))
 	for _, url := range createdURLs {
 		urlResponses = append(urlResponses, url.ToResponse())
+		createdIDs = append(createdIDs, url.ID)
 	}
 
+	recordAudit(c, models.AuditActionImport, models.AuditTargetURL, createdIDs, nil, gin.H{
+		"file":           file.Filename,
+		"imported_count": len(createdURLs),
+		"errors":         allErrors,
+	})
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Bulk import completed",
 		"data": gin.H{
@@ -452,3 +727,315 @@ func (ctrl *URLController) BulkImportURLs(c *gin.Context) {
 		},
 	})
 }
+
+// GetTrash handles GET /api/urls/trash
+func (ctrl *URLController) GetTrash(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	urls, total, err := ctrl.service(c).GetTrash(page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get trash",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	urlResponses := make([]models.URLResponse, 0, len(urls))
+	for _, url := range urls {
+		urlResponses = append(urlResponses, url.ToResponse())
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": urlResponses,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// RestoreURL handles POST /api/urls/:id/restore
+func (ctrl *URLController) RestoreURL(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	url, err := ctrl.service(c).RestoreURL(uint(id))
+	if err != nil {
+		switch err.Error() {
+		case "URL not found in trash":
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Not Found",
+				"message": err.Error(),
+			})
+		case "URL already exists":
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Conflict",
+				"message": err.Error(),
+				"details": "The address was added again after it was deleted",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Internal Server Error",
+				"message": "Failed to restore URL",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	recordAudit(c, models.AuditActionRestore, models.AuditTargetURL, []uint{url.ID}, nil, url.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "URL restored successfully",
+		"data":    url.ToResponse(),
+	})
+}
+
+// BulkRestoreURLs handles POST /api/urls/bulk/restore
+func (ctrl *URLController) BulkRestoreURLs(c *gin.Context) {
+	var req models.BulkRestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "No IDs provided",
+		})
+		return
+	}
+
+	restoredURLs, restoreErrors := ctrl.service(c).BulkRestoreURLs(req.IDs)
+
+	errorMessages := make([]string, 0, len(restoreErrors))
+	for _, err := range restoreErrors {
+		errorMessages = append(errorMessages, err.Error())
+	}
+
+	urlResponses := make([]models.URLResponse, 0, len(restoredURLs))
+	restoredIDs := make([]uint, 0, len(restoredURLs))
+	for _, url := range restoredURLs {
+		urlResponses = append(urlResponses, url.ToResponse())
+		restoredIDs = append(restoredIDs, url.ID)
+	}
+
+	if len(restoredIDs) > 0 {
+		recordAudit(c, models.AuditActionRestore, models.AuditTargetURL, restoredIDs, nil, urlResponses)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Bulk restore completed",
+		"data": gin.H{
+			"restored_count": len(restoredURLs),
+			"error_count":    len(errorMessages),
+			"urls":           urlResponses,
+			"errors":         errorMessages,
+		},
+	})
+}
+
+// PurgeURL handles DELETE /api/urls/trash/:id
+func (ctrl *URLController) PurgeURL(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	ctrl.purge(c, []uint{uint(id)}, false)
+}
+
+// PurgeTrash handles DELETE /api/urls/trash
+func (ctrl *URLController) PurgeTrash(c *gin.Context) {
+	var req models.TrashPurgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.IDs) == 0 && !req.All {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "No IDs provided",
+			"details": "Pass ids, or all: true to empty the trash",
+		})
+		return
+	}
+
+	ctrl.purge(c, req.IDs, req.All)
+}
+
+// purge permanently deletes URLs from the trash and writes the response
+func (ctrl *URLController) purge(c *gin.Context, ids []uint, all bool) {
+	purgedIDs, err := ctrl.service(c).PurgeTrash(ids, all)
+	if err != nil {
+		if err.Error() == "no URLs found in trash" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "Not Found",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to purge URLs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	recordAudit(c, models.AuditActionPurge, models.AuditTargetURL, purgedIDs, nil, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "URLs purged successfully",
+		"data": gin.H{
+			"purged_count": len(purgedIDs),
+			"purged_ids":   purgedIDs,
+		},
+	})
+}
+
+// BulkTagURLs handles POST /api/urls/bulk/tag
+func (ctrl *URLController) BulkTagURLs(c *gin.Context) {
+	ctrl.bulkChangeTags(c, models.AuditActionTag, "URLs tagged successfully", ctrl.service(c).BulkTagURLs)
+}
+
+// BulkUntagURLs handles POST /api/urls/bulk/untag
+func (ctrl *URLController) BulkUntagURLs(c *gin.Context) {
+	ctrl.bulkChangeTags(c, models.AuditActionUntag, "URLs untagged successfully", ctrl.service(c).BulkUntagURLs)
+}
+
+// bulkChangeTags binds a bulk tag request, applies change and writes the response
+func (ctrl *URLController) bulkChangeTags(c *gin.Context, action, message string, change func(ids []uint, tags []string) ([]models.URL, error)) {
+	var req models.BulkTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.IDs) == 0 || len(req.Tags) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "No IDs or tags provided",
+		})
+		return
+	}
+
+	urls, err := change(req.IDs, req.Tags)
+	if err != nil {
+		ctrl.handleBulkError(c, err, "Failed to change URL tags")
+		return
+	}
+
+	ctrl.respondBulkChange(c, action, message, urls, gin.H{"tags": req.Tags})
+}
+
+// BulkMoveURLs handles POST /api/urls/bulk/move
+func (ctrl *URLController) BulkMoveURLs(c *gin.Context) {
+	var req models.BulkMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "No IDs provided",
+		})
+		return
+	}
+
+	urls, err := ctrl.service(c).BulkMoveURLs(req.IDs, req.FolderID)
+	if err != nil {
+		ctrl.handleBulkError(c, err, "Failed to move URLs")
+		return
+	}
+
+	ctrl.respondBulkChange(c, models.AuditActionMove, "URLs moved successfully", urls, gin.H{"folder_id": req.FolderID})
+}
+
+// respondBulkChange records a bulk change to URLs in the audit log and returns the changed URLs
+func (ctrl *URLController) respondBulkChange(c *gin.Context, action, message string, urls []models.URL, change gin.H) {
+	urlResponses := make([]models.URLResponse, 0, len(urls))
+	ids := make([]uint, 0, len(urls))
+	for _, url := range urls {
+		urlResponses = append(urlResponses, url.ToResponse())
+		ids = append(ids, url.ID)
+	}
+
+	recordAudit(c, action, models.AuditTargetURL, ids, nil, change)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data": gin.H{
+			"updated_count": len(urls),
+			"urls":          urlResponses,
+		},
+	})
+}
+
+// handleBulkError maps errors from bulk tag and folder changes to HTTP responses
+func (ctrl *URLController) handleBulkError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "no URLs found with the provided IDs" || err.Error() == "folder not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidTag):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
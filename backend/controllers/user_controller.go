@@ -0,0 +1,365 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserController handles HTTP requests for users and their API keys
+type UserController struct {
+	userService      *services.UserService
+	apiKeyService    *services.APIKeyService
+	authService      *services.AuthService
+	workspaceService *services.WorkspaceService
+}
+
+// NewUserController creates a new user controller instance
+func NewUserController() *UserController {
+	return &UserController{
+		userService:      services.NewUserService(),
+		apiKeyService:    services.NewAPIKeyService(),
+		authService:      services.NewAuthService(),
+		workspaceService: services.NewWorkspaceService(),
+	}
+}
+
+// GetCurrentUser handles GET /api/v1/me
+func (ctrl *UserController) GetCurrentUser(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	workspaces, err := ctrl.workspaceService.GetWorkspaces(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get workspaces",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data := gin.H{
+		"user":       user,
+		"workspaces": workspaces,
+	}
+	// Requests authenticated with an access token have no API key
+	if apiKey := middlewares.CurrentAPIKey(c); apiKey != nil {
+		data["api_key"] = apiKey.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// ChangePassword handles PUT /api/v1/me/password
+func (ctrl *UserController) ChangePassword(c *gin.Context) {
+	var req models.PasswordChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := ctrl.authService.ChangePassword(middlewares.CurrentUser(c), req.CurrentPassword, req.NewPassword); err != nil {
+		ctrl.handleError(c, err, "Failed to change password")
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	recordAudit(c, models.AuditActionChangePassword, models.AuditTargetUser, []uint{user.ID}, nil, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password changed successfully",
+	})
+}
+
+// GetAllUsers handles GET /api/v1/users
+func (ctrl *UserController) GetAllUsers(c *gin.Context) {
+	users, err := ctrl.userService.GetAllUsers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get users",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  users,
+		"roles": models.Roles,
+	})
+}
+
+// GetUser handles GET /api/v1/users/:id
+func (ctrl *UserController) GetUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := ctrl.userService.GetUserByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": user,
+	})
+}
+
+// CreateUser handles POST /api/v1/users
+func (ctrl *UserController) CreateUser(c *gin.Context) {
+	var req models.UserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := ctrl.userService.CreateUser(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create user")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetUser, []uint{user.ID}, nil, user)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "User created successfully",
+		"data":    user,
+	})
+}
+
+// UpdateUser handles PUT /api/v1/users/:id
+func (ctrl *UserController) UpdateUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.userService.GetUserByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update user")
+		return
+	}
+
+	user, err := ctrl.userService.UpdateUser(id, req, middlewares.CurrentUser(c).ID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update user")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetUser, []uint{user.ID}, before, user)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User updated successfully",
+		"data":    user,
+	})
+}
+
+// DeleteUser handles DELETE /api/v1/users/:id
+func (ctrl *UserController) DeleteUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	before, err := ctrl.userService.GetUserByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete user")
+		return
+	}
+
+	if err := ctrl.userService.DeleteUser(id, middlewares.CurrentUser(c).ID); err != nil {
+		ctrl.handleError(c, err, "Failed to delete user")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetUser, []uint{before.ID}, before, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deleted successfully",
+	})
+}
+
+// GetAPIKeys handles GET /api/v1/api-keys
+func (ctrl *UserController) GetAPIKeys(c *gin.Context) {
+	userID := middlewares.CurrentUser(c).ID
+	if userIDParam := c.Query("user_id"); userIDParam != "" {
+		id, err := strconv.ParseUint(userIDParam, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Invalid user ID",
+			})
+			return
+		}
+		userID = uint(id)
+	}
+
+	keys, err := ctrl.apiKeyService.GetAPIKeys(userID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get API keys")
+		return
+	}
+
+	keyResponses := make([]models.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		keyResponses = append(keyResponses, key.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": keyResponses,
+	})
+}
+
+// GetAPIKey handles GET /api/v1/api-keys/:id
+func (ctrl *UserController) GetAPIKey(c *gin.Context) {
+	id, ok := parseAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := ctrl.apiKeyService.GetAPIKeyByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": key.ToResponse(),
+	})
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys
+func (ctrl *UserController) CreateAPIKey(c *gin.Context) {
+	var req models.APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := middlewares.CurrentUser(c).ID
+	if req.UserID != nil {
+		userID = *req.UserID
+	}
+
+	key, plain, err := ctrl.apiKeyService.CreateAPIKey(userID, req.Name, req.ExpiresAt)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create API key")
+		return
+	}
+
+	// The key is only returned on creation; only its hash is stored
+	response := key.ToResponse()
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetAPIKey, []uint{key.ID}, nil, response)
+	response.Key = plain
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "API key created successfully",
+		"data":    response,
+	})
+}
+
+// RevokeAPIKey handles POST /api/v1/api-keys/:id/revoke
+func (ctrl *UserController) RevokeAPIKey(c *gin.Context) {
+	id, ok := parseAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := ctrl.apiKeyService.RevokeAPIKey(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	recordAudit(c, models.AuditActionRevoke, models.AuditTargetAPIKey, []uint{key.ID}, nil, key.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked successfully",
+		"data":    key.ToResponse(),
+	})
+}
+
+// parseUserID parses the user ID path parameter, responding with 400 if it is invalid
+func parseUserID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid user ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseAPIKeyID parses the API key ID path parameter, responding with 400 if it is invalid
+func parseAPIKeyID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid API key ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError maps user and API key service errors to HTTP responses
+func (ctrl *UserController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "user not found" || err.Error() == "API key not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "user already exists":
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidUser) || errors.Is(err, services.ErrInvalidAPIKey) || errors.Is(err, services.ErrInvalidPassword):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
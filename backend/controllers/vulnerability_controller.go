@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VulnerabilityController handles HTTP requests for the JavaScript library vulnerability feed
+type VulnerabilityController struct {
+	libraryDB *services.JSLibraryDatabase
+}
+
+// NewVulnerabilityController creates a new vulnerability controller instance
+func NewVulnerabilityController() *VulnerabilityController {
+	return &VulnerabilityController{
+		libraryDB: services.GetJSLibraryDatabase(),
+	}
+}
+
+// GetFeed handles GET /api/v1/vulnerability-feed
+func (ctrl *VulnerabilityController) GetFeed(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": ctrl.libraryDB.Info(),
+	})
+}
+
+// ImportFeed handles POST /api/v1/vulnerability-feed
+// Accepts either a multipart "file" upload or a raw JSON request body.
+func (ctrl *VulnerabilityController) ImportFeed(c *gin.Context) {
+	var data []byte
+
+	if file, err := c.FormFile("file"); err == nil {
+		// Validate file size (max 10MB)
+		if file.Size > int64(10<<20) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "File size exceeds 10MB limit",
+			})
+			return
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Failed to open uploaded file",
+				"details": err.Error(),
+			})
+			return
+		}
+		defer src.Close()
+
+		if data, err = io.ReadAll(src); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Failed to read uploaded file",
+				"details": err.Error(),
+			})
+			return
+		}
+	} else {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, 10<<20))
+		if err != nil || len(body) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "A feed file or JSON body is required",
+			})
+			return
+		}
+		data = body
+	}
+
+	before := ctrl.libraryDB.Info()
+
+	info, err := ctrl.libraryDB.Import(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Failed to import vulnerability feed",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	recordAudit(c, models.AuditActionImport, models.AuditTargetVulnerabilityFeed, nil, before, info)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vulnerability feed imported successfully",
+		"data":    info,
+	})
+}
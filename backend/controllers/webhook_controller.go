@@ -0,0 +1,300 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController handles HTTP requests for webhooks and their deliveries
+type WebhookController struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookController creates a new webhook controller instance
+func NewWebhookController() *WebhookController {
+	return &WebhookController{
+		webhookService: services.NewWebhookService(),
+	}
+}
+
+// service returns the webhook service scoped to the workspace the request acts on
+func (ctrl *WebhookController) service(c *gin.Context) *services.WebhookService {
+	return ctrl.webhookService.ForWorkspace(middlewares.CurrentWorkspaceID(c))
+}
+
+// GetAllWebhooks handles GET /api/v1/webhooks
+func (ctrl *WebhookController) GetAllWebhooks(c *gin.Context) {
+	webhooks, err := ctrl.service(c).GetAllWebhooks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get webhooks",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	webhookResponses := make([]models.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookResponses = append(webhookResponses, webhook.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   webhookResponses,
+		"events": models.WebhookEvents,
+	})
+}
+
+// GetWebhook handles GET /api/v1/webhooks/:id
+func (ctrl *WebhookController) GetWebhook(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := ctrl.service(c).GetWebhookByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": webhook.ToResponse(),
+	})
+}
+
+// CreateWebhook handles POST /api/v1/webhooks
+func (ctrl *WebhookController) CreateWebhook(c *gin.Context) {
+	var req models.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	webhook, err := ctrl.service(c).CreateWebhook(req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create webhook")
+		return
+	}
+
+	// The secret is only returned on creation so it can be stored by the receiver
+	// and is kept out of the audit log
+	response := webhook.ToResponse()
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetWebhook, []uint{webhook.ID}, nil, response)
+	response.Secret = webhook.Secret
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created successfully",
+		"data":    response,
+	})
+}
+
+// UpdateWebhook handles PUT /api/v1/webhooks/:id
+func (ctrl *WebhookController) UpdateWebhook(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	var req models.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	before, err := ctrl.service(c).GetWebhookByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update webhook")
+		return
+	}
+
+	webhook, err := ctrl.service(c).UpdateWebhook(id, req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update webhook")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetWebhook, []uint{webhook.ID}, before.ToResponse(), webhook.ToResponse())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook updated successfully",
+		"data":    webhook.ToResponse(),
+	})
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/:id
+func (ctrl *WebhookController) DeleteWebhook(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	before, err := ctrl.service(c).GetWebhookByID(id)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	if err := ctrl.service(c).DeleteWebhook(id); err != nil {
+		ctrl.handleError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetWebhook, []uint{before.ID}, before.ToResponse(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// GetDeliveries handles GET /api/v1/webhooks/:id/deliveries
+func (ctrl *WebhookController) GetDeliveries(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	// Parse pagination parameters
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	filters := services.WebhookDeliveryFilters{
+		Status: c.Query("status"),
+		Event:  c.Query("event"),
+	}
+
+	deliveries, total, err := ctrl.service(c).GetDeliveries(id, page, limit, filters)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get webhook deliveries")
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetDelivery handles GET /api/v1/webhooks/:id/deliveries/:deliveryId
+func (ctrl *WebhookController) GetDelivery(c *gin.Context) {
+	id, deliveryID, ok := parseDeliveryIDs(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := ctrl.service(c).GetDelivery(id, deliveryID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": delivery,
+	})
+}
+
+// RedeliverDelivery handles POST /api/v1/webhooks/:id/deliveries/:deliveryId/redeliver
+func (ctrl *WebhookController) RedeliverDelivery(c *gin.Context) {
+	id, deliveryID, ok := parseDeliveryIDs(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := ctrl.service(c).Redeliver(id, deliveryID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to redeliver webhook event")
+		return
+	}
+
+	recordAudit(c, models.AuditActionRedeliver, models.AuditTargetWebhookDelivery, []uint{delivery.ID}, nil, gin.H{
+		"webhook_id":           id,
+		"original_delivery_id": deliveryID,
+	})
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Webhook event queued for redelivery",
+		"data":    delivery,
+	})
+}
+
+// parseWebhookID parses the webhook ID path parameter, responding with 400 if it is invalid
+func parseWebhookID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid webhook ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseDeliveryIDs parses the webhook and delivery ID path parameters, responding with 400 if either is invalid
+func parseDeliveryIDs(c *gin.Context) (uint, uint, bool) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid delivery ID",
+		})
+		return 0, 0, false
+	}
+	return id, uint(deliveryID), true
+}
+
+// handleError maps webhook service errors to HTTP responses
+func (ctrl *WebhookController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case err.Error() == "webhook not found" || err.Error() == "webhook delivery not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidWebhook):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceController handles HTTP requests for workspaces and their members
+type WorkspaceController struct {
+	workspaceService *services.WorkspaceService
+}
+
+// NewWorkspaceController creates a new workspace controller instance
+func NewWorkspaceController() *WorkspaceController {
+	return &WorkspaceController{
+		workspaceService: services.NewWorkspaceService(),
+	}
+}
+
+// GetAllWorkspaces handles GET /api/v1/workspaces
+func (ctrl *WorkspaceController) GetAllWorkspaces(c *gin.Context) {
+	workspaces, err := ctrl.workspaceService.GetWorkspaces(middlewares.CurrentUser(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": "Failed to get workspaces",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": workspaces,
+	})
+}
+
+// GetWorkspace handles GET /api/v1/workspaces/:id
+func (ctrl *WorkspaceController) GetWorkspace(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": models.WorkspaceResponse{
+			Workspace: *middlewares.CurrentWorkspace(c),
+			Role:      middlewares.CurrentWorkspaceRole(c),
+		},
+	})
+}
+
+// CreateWorkspace handles POST /api/v1/workspaces
+func (ctrl *WorkspaceController) CreateWorkspace(c *gin.Context) {
+	var req models.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	workspace, err := ctrl.workspaceService.CreateWorkspace(req, middlewares.CurrentUser(c).ID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to create workspace")
+		return
+	}
+
+	recordAudit(c, models.AuditActionCreate, models.AuditTargetWorkspace, []uint{workspace.ID}, nil, workspace)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Workspace created successfully",
+		"data":    workspace,
+	})
+}
+
+// UpdateWorkspace handles PUT /api/v1/workspaces/:id
+func (ctrl *WorkspaceController) UpdateWorkspace(c *gin.Context) {
+	var req models.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Workspace admins may rename their workspace but not raise its quota
+	before := middlewares.CurrentWorkspace(c)
+	if req.MonthlyAnalysisQuota != nil && !middlewares.CurrentUser(c).HasRole(models.RoleAdmin) &&
+		(before.MonthlyAnalysisQuota == nil || *before.MonthlyAnalysisQuota != *req.MonthlyAnalysisQuota) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": "Only instance admins can change the analysis quota",
+		})
+		return
+	}
+
+	workspace, err := ctrl.workspaceService.UpdateWorkspace(before.ID, req)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to update workspace")
+		return
+	}
+
+	recordAudit(c, models.AuditActionUpdate, models.AuditTargetWorkspace, []uint{workspace.ID}, before, workspace)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace updated successfully",
+		"data":    workspace,
+	})
+}
+
+// DeleteWorkspace handles DELETE /api/v1/workspaces/:id
+func (ctrl *WorkspaceController) DeleteWorkspace(c *gin.Context) {
+	before := middlewares.CurrentWorkspace(c)
+	if err := ctrl.workspaceService.DeleteWorkspace(before.ID); err != nil {
+		ctrl.handleError(c, err, "Failed to delete workspace")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetWorkspace, []uint{before.ID}, before, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace deleted successfully",
+	})
+}
+
+// GetUsage handles GET /api/v1/workspaces/:id/usage
+func (ctrl *WorkspaceController) GetUsage(c *gin.Context) {
+	usage, err := ctrl.workspaceService.GetAnalysisUsage(middlewares.CurrentWorkspaceID(c))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get workspace usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": usage,
+	})
+}
+
+// GetMembers handles GET /api/v1/workspaces/:id/members
+func (ctrl *WorkspaceController) GetMembers(c *gin.Context) {
+	members, err := ctrl.workspaceService.GetMembers(middlewares.CurrentWorkspaceID(c))
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to get workspace members")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  members,
+		"roles": models.Roles,
+	})
+}
+
+// SetMember handles PUT /api/v1/workspaces/:id/members/:userId
+func (ctrl *WorkspaceController) SetMember(c *gin.Context) {
+	userID, ok := parseMemberUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.WorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	previousRole, err := ctrl.workspaceService.MemberRole(middlewares.CurrentWorkspaceID(c), userID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to set workspace member")
+		return
+	}
+
+	member, err := ctrl.workspaceService.SetMember(middlewares.CurrentWorkspaceID(c), userID, req.Role)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to set workspace member")
+		return
+	}
+
+	// Members are identified by user ID in the audit log, as in the route
+	action := models.AuditActionCreate
+	var before interface{}
+	if previousRole != "" {
+		action = models.AuditActionUpdate
+		before = gin.H{"user_id": userID, "role": previousRole}
+	}
+	recordAudit(c, action, models.AuditTargetWorkspaceMember, []uint{userID}, before, gin.H{"user_id": userID, "role": member.Role})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace member saved successfully",
+		"data":    member,
+	})
+}
+
+// RemoveMember handles DELETE /api/v1/workspaces/:id/members/:userId
+func (ctrl *WorkspaceController) RemoveMember(c *gin.Context) {
+	userID, ok := parseMemberUserID(c)
+	if !ok {
+		return
+	}
+
+	previousRole, err := ctrl.workspaceService.MemberRole(middlewares.CurrentWorkspaceID(c), userID)
+	if err != nil {
+		ctrl.handleError(c, err, "Failed to remove workspace member")
+		return
+	}
+
+	if err := ctrl.workspaceService.RemoveMember(middlewares.CurrentWorkspaceID(c), userID); err != nil {
+		ctrl.handleError(c, err, "Failed to remove workspace member")
+		return
+	}
+
+	recordAudit(c, models.AuditActionDelete, models.AuditTargetWorkspaceMember, []uint{userID}, gin.H{"user_id": userID, "role": previousRole}, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace member removed successfully",
+	})
+}
+
+// parseMemberUserID parses the member user ID path parameter, responding with 400 if it is invalid
+func parseMemberUserID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": "Invalid user ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleError maps workspace service errors to HTTP responses
+func (ctrl *WorkspaceController) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWorkspaceNotFound) || err.Error() == "workspace member not found" || err.Error() == "user not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	case err.Error() == "workspace already exists":
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Conflict",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidWorkspace):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad Request",
+			"message": message,
+			"details": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Internal Server Error",
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -66,6 +66,29 @@ func AutoMigrate() error {
 	
 	err := DB.AutoMigrate(
 		&models.URL{},
+		&models.Analysis{},
+		&models.AuditRule{},
+		&models.RuleViolation{},
+		&models.Finding{},
+		&models.Metric{},
+		&models.Tag{},
+		&models.PerformanceBudget{},
+		&models.Schedule{},
+		&models.AlertRule{},
+		&models.Alert{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.NotificationPreference{},
+		&models.UptimeCheck{},
+		&models.User{},
+		&models.APIKey{},
+		&models.RefreshToken{},
+		&models.Workspace{},
+		&models.WorkspaceMember{},
+		&models.AuditLogEntry{},
+		&models.AnalysisUsage{},
+		&models.Folder{},
+		&models.SavedView{},
 		// Add more models here as they are created
 	)
 	
@@ -1,17 +1,43 @@
 package middlewares
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
-	"website-analyzer-backend/config"
+	"website-analyzer-backend/models"
+	"website-analyzer-backend/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates API token for protected routes
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// Context keys under which AuthMiddleware stores the authenticated caller and
+// RequireRole stores the workspace the request acts on
+const (
+	userContextKey          = "user"
+	apiKeyContextKey        = "api_key"
+	workspaceContextKey     = "workspace"
+	workspaceRoleContextKey = "workspace_role"
+	requestIDContextKey     = "request_id"
+)
+
+// WorkspaceHeader selects the workspace a request acts on
+const WorkspaceHeader = "X-Workspace-ID"
+
+// RequestIDHeader carries the ID that ties a request to its log lines and audit log entries
+const RequestIDHeader = "X-Request-ID"
+
+// AuthMiddleware authenticates protected routes with a per-user API key, an
+// access token from the login endpoint or a token from the OIDC provider, and
+// stores the user (and API key, if one was used) on the request context
+func AuthMiddleware() gin.HandlerFunc {
+	apiKeyService := services.NewAPIKeyService()
+	authService := services.NewAuthService()
+
 	return func(c *gin.Context) {
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -38,28 +64,237 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		// Extract the token
 		token := strings.TrimPrefix(authHeader, "Bearer ")
 		
-		// Validate the token
-		if token != cfg.Auth.APIToken {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid API token",
+		// Resolve the JWT or API key to its user
+		var user *models.User
+		var apiKey *models.APIKey
+		var err error
+		if services.IsJWT(token) {
+			user, err = authService.Authenticate(token)
+		} else {
+			user, apiKey, err = apiKeyService.Authenticate(token)
+		}
+		if err != nil {
+			if errors.Is(err, services.ErrUnauthorized) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "Unauthorized",
+					"message": "Invalid API key or access token",
+					"details": err.Error(),
+				})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error":   "Internal Server Error",
+					"message": "Failed to authenticate request",
+					"details": err.Error(),
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		// Credentials are valid, continue to the next handler
+		c.Set(userContextKey, user)
+		if apiKey != nil {
+			c.Set(apiKeyContextKey, apiKey)
+		}
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests whose caller's role in the workspace they act
+// on is less privileged than role with 403. The workspace is taken from the
+// X-Workspace-ID header or the workspace_id query parameter and defaults to the
+// caller's first workspace. It must run after AuthMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return requireWorkspaceRole(role, func(c *gin.Context) string {
+		if id := c.GetHeader(WorkspaceHeader); id != "" {
+			return id
+		}
+		return c.Query("workspace_id")
+	})
+}
+
+// RequireWorkspaceRole is like RequireRole for routes that name the workspace
+// in the path parameter param
+func RequireWorkspaceRole(param, role string) gin.HandlerFunc {
+	return requireWorkspaceRole(role, func(c *gin.Context) string {
+		return c.Param(param)
+	})
+}
+
+// requireWorkspaceRole resolves the workspace named by requested, checks the
+// caller's role in it and stores it on the request context
+func requireWorkspaceRole(role string, requested func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": fmt.Sprintf("This action requires the %s role", role),
+			})
+			c.Abort()
+			return
+		}
+
+		var requestedID uint
+		if value := requested(c); value != "" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Bad Request",
+					"message": "Invalid workspace ID",
+				})
+				c.Abort()
+				return
+			}
+			requestedID = uint(id)
+		}
+
+		workspace, workspaceRole, err := services.NewWorkspaceService().ResolveWorkspace(user, requestedID)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrWorkspaceNotFound):
+				c.JSON(http.StatusNotFound, gin.H{
+					"error":   "Not Found",
+					"message": err.Error(),
+				})
+			case errors.Is(err, services.ErrWorkspaceAccess):
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":   "Forbidden",
+					"message": "You do not have access to this workspace",
+					"details": err.Error(),
+				})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error":   "Internal Server Error",
+					"message": "Failed to resolve workspace",
+					"details": err.Error(),
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		if models.RoleRank(workspaceRole) < models.RoleRank(role) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": fmt.Sprintf("This action requires the %s role", role),
+				"details": fmt.Sprintf("Your role in workspace %q is %q", workspace.Name, workspaceRole),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(workspaceContextKey, workspace)
+		c.Set(workspaceRoleContextKey, workspaceRole)
+		c.Next()
+	}
+}
+
+// RequireInstanceRole rejects requests from users whose own role is less
+// privileged than role with 403. It guards routes that are not tied to a
+// workspace, such as user management. It must run after AuthMiddleware.
+func RequireInstanceRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil || !user.HasRole(role) {
+			currentRole := ""
+			if user != nil {
+				currentRole = user.Role
+			}
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": fmt.Sprintf("This action requires the %s role", role),
+				"details": fmt.Sprintf("Your role is %q", currentRole),
 			})
 			c.Abort()
 			return
 		}
 
-		// Token is valid, continue to the next handler
 		c.Next()
 	}
 }
 
+// CurrentUser returns the user who made an authenticated request
+func CurrentUser(c *gin.Context) *models.User {
+	if user, ok := c.Get(userContextKey); ok {
+		return user.(*models.User)
+	}
+	return nil
+}
+
+// CurrentAPIKey returns the API key an authenticated request was made with
+func CurrentAPIKey(c *gin.Context) *models.APIKey {
+	if key, ok := c.Get(apiKeyContextKey); ok {
+		return key.(*models.APIKey)
+	}
+	return nil
+}
+
+// CurrentWorkspace returns the workspace a request acts on, as resolved by RequireRole
+func CurrentWorkspace(c *gin.Context) *models.Workspace {
+	if workspace, ok := c.Get(workspaceContextKey); ok {
+		return workspace.(*models.Workspace)
+	}
+	return nil
+}
+
+// CurrentWorkspaceID returns the ID of the workspace a request acts on, or 0
+// for routes that are not tied to a workspace
+func CurrentWorkspaceID(c *gin.Context) uint {
+	if workspace := CurrentWorkspace(c); workspace != nil {
+		return workspace.ID
+	}
+	return 0
+}
+
+// CurrentWorkspaceRole returns the caller's role in the workspace a request acts on
+func CurrentWorkspaceRole(c *gin.Context) string {
+	return c.GetString(workspaceRoleContextKey)
+}
+
+// RequestIDMiddleware assigns every request an ID, reusing the caller's
+// X-Request-ID if it is a reasonable token, and echoes it in the response
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			buf := make([]byte, 16)
+			rand.Read(buf)
+			requestID = hex.EncodeToString(buf)
+		}
+
+		c.Set(requestIDContextKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// validRequestID reports whether a client-supplied request ID is safe to log and store
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestID returns the ID assigned to a request by RequestIDMiddleware
+func RequestID(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
+
 // CORSMiddleware handles Cross-Origin Resource Sharing
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Workspace-ID, X-Request-ID, accept, origin, Cache-Control, X-Requested-With")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -70,11 +305,22 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
-// LoggerMiddleware provides request logging
+// LoggerMiddleware provides request logging, including the authenticated user's email
+// and the request ID
 func LoggerMiddleware() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf("%s - [%s] \"%s %s %s %d %s \"%s\" %s\"\n",
+		userEmail := "-"
+		if user, ok := param.Keys[userContextKey].(*models.User); ok {
+			userEmail = user.Email
+		}
+		requestID := "-"
+		if id, ok := param.Keys[requestIDContextKey].(string); ok {
+			requestID = id
+		}
+		return fmt.Sprintf("%s %s %s [%s] \"%s %s %s %d %s \"%s\" %s\"\n",
 			param.ClientIP,
+			requestID,
+			userEmail,
 			param.TimeStamp.Format("02/Jan/2006:15:04:05 -0700"),
 			param.Method,
 			param.Path,
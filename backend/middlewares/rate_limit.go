@@ -0,0 +1,228 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rate limit classes. Every request draws from the standard bucket of its
+// client; expensive operations also draw from a smaller one.
+const (
+	RateLimitStandard  = "standard"
+	RateLimitExpensive = "expensive"
+)
+
+// rateLimitSweepInterval is how often buckets that have refilled are dropped
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitRule is a token bucket that holds Burst tokens and refills at PerMinute
+type RateLimitRule struct {
+	PerMinute int
+	Burst     int
+}
+
+// RateLimitSettings configures the limits applied by RateLimit
+type RateLimitSettings struct {
+	Enabled   bool
+	Standard  RateLimitRule
+	Expensive RateLimitRule
+}
+
+// rateLimiters holds one limiter per class; it is replaced by ConfigureRateLimits
+// before the server starts and only read afterwards
+var rateLimiters = map[string]*rateLimiter{}
+
+// ConfigureRateLimits sets the request rate limits. Rules without a positive
+// rate and burst are not enforced.
+func ConfigureRateLimits(settings RateLimitSettings) {
+	limiters := map[string]*rateLimiter{}
+	if settings.Enabled {
+		for class, rule := range map[string]RateLimitRule{
+			RateLimitStandard:  settings.Standard,
+			RateLimitExpensive: settings.Expensive,
+		} {
+			if rule.PerMinute > 0 && rule.Burst > 0 {
+				limiters[class] = newRateLimiter(rule)
+			}
+		}
+	}
+	rateLimiters = limiters
+}
+
+// RateLimit rejects requests from clients that exhausted their bucket for
+// class with 429 and a Retry-After header. Clients are identified by API key,
+// then by user, then by IP address, so it should run after AuthMiddleware on
+// protected routes. Every response carries the X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset (seconds until the bucket is
+// full) headers of the bucket it drew from.
+func RateLimit(class string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := rateLimiters[class]
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		allowed, remaining, retryAfter, reset := limiter.take(rateLimitKey(c), time.Now())
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limiter.rule.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too Many Requests",
+				"message": "Rate limit exceeded",
+				"details": fmt.Sprintf("The %s limit is %d requests per minute; retry in %d seconds", class, limiter.rule.PerMinute, ceilSeconds(retryAfter)),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RateLimitFailedAuth limits authentication attempts per IP address. It runs
+// before AuthMiddleware: requests from an address whose bucket for class is
+// exhausted are rejected with 429, and each request the server answers with
+// 401 draws a token from that bucket. Successful requests draw nothing here,
+// so clients sharing an address are not limited together.
+func RateLimitFailedAuth(class string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := rateLimiters[class]
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		key := "ip:" + c.ClientIP()
+		if retryAfter := limiter.wait(key, time.Now()); retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too Many Requests",
+				"message": "Rate limit exceeded",
+				"details": fmt.Sprintf("Too many failed authentication attempts; retry in %d seconds", ceilSeconds(retryAfter)),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		if c.Writer.Status() == http.StatusUnauthorized {
+			limiter.take(key, time.Now())
+		}
+	}
+}
+
+// rateLimitKey identifies the client a request counts against
+func rateLimitKey(c *gin.Context) string {
+	if apiKey := CurrentAPIKey(c); apiKey != nil {
+		return fmt.Sprintf("key:%d", apiKey.ID)
+	}
+	if user := CurrentUser(c); user != nil {
+		return fmt.Sprintf("user:%d", user.ID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// tokenBucket is the state of one client's bucket
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter keeps a token bucket per client in memory
+type rateLimiter struct {
+	rule      RateLimitRule
+	perSecond float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// newRateLimiter creates a limiter that applies rule to every client
+func newRateLimiter(rule RateLimitRule) *rateLimiter {
+	return &rateLimiter{
+		rule:      rule,
+		perSecond: float64(rule.PerMinute) / 60,
+		buckets:   map[string]*tokenBucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+// take draws a token from key's bucket. It reports whether the request is
+// allowed, the whole tokens left, how long until a token is available and how
+// long until the bucket is full again.
+func (l *rateLimiter) take(key string, now time.Time) (allowed bool, remaining int, retryAfter, reset time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		l.sweep(now)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.rule.Burst), updated: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = l.refill(bucket, now)
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		allowed = true
+	} else {
+		retryAfter = l.duration(1 - bucket.tokens)
+	}
+	return allowed, int(bucket.tokens), retryAfter, l.duration(float64(l.rule.Burst) - bucket.tokens)
+}
+
+// wait returns how long until key's bucket holds a token, without drawing one
+func (l *rateLimiter) wait(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		return 0
+	}
+	if tokens := l.refill(bucket, now); tokens < 1 {
+		return l.duration(1 - tokens)
+	}
+	return 0
+}
+
+// refill returns the tokens in a bucket at now
+func (l *rateLimiter) refill(bucket *tokenBucket, now time.Time) float64 {
+	return math.Min(float64(l.rule.Burst), bucket.tokens+now.Sub(bucket.updated).Seconds()*l.perSecond)
+}
+
+// duration returns how long it takes to refill tokens
+func (l *rateLimiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.perSecond * float64(time.Second))
+}
+
+// sweep drops full buckets, which behave like a client that was never seen
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, bucket := range l.buckets {
+		if l.refill(bucket, now) >= float64(l.rule.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+)
+
+// Alert conditions compared between an analysis and the previous one of the same URL
+const (
+	AlertConditionStatusCodeChanged      = "status_code_changed"
+	AlertConditionTitleChanged           = "title_changed"
+	AlertConditionDescriptionChanged     = "meta_description_changed"
+	AlertConditionNewBrokenLink          = "new_broken_link"
+	AlertConditionH1Removed              = "h1_removed"
+	AlertConditionLoadTimeRegression     = "load_time_regression"
+	AlertConditionLoginFormRemoved       = "login_form_removed"
+	AlertConditionNoindex                = "noindex"
+	AlertConditionScoreDrop              = "score_drop"
+	AlertConditionAccessibilityIncreased = "accessibility_violations_increased"
+)
+
+// Alert states
+const (
+	AlertStateOpen         = "open"
+	AlertStateAcknowledged = "acknowledged"
+	AlertStateResolved     = "resolved"
+)
+
+// AlertRule describes a change between consecutive analyses that should raise an alert
+type AlertRule struct {
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint    `json:"workspace_id" gorm:"not null;default:0;index"`
+	Name        string  `json:"name" gorm:"size:200;not null"`
+	Description string  `json:"description" gorm:"type:text"`
+	Condition   string  `json:"condition" gorm:"size:50;not null;index"`
+	Threshold   float64 `json:"threshold" gorm:"default:0"` // percentage for load_time_regression, points for score_drop
+	Severity    string  `json:"severity" gorm:"size:20;default:'medium'"`
+	URLID       *uint   `json:"url_id" gorm:"index"`
+	TagID       *uint   `json:"tag_id" gorm:"index"`
+	Tag         *Tag    `json:"tag,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	Enabled     bool    `json:"enabled" gorm:"not null"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the AlertRule model
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
+// AlertRuleResponse represents the response format for an alert rule
+type AlertRuleResponse struct {
+	ID          uint      `json:"id"`
+	WorkspaceID uint      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Condition   string    `json:"condition"`
+	Threshold   float64   `json:"threshold,omitempty"`
+	Severity    string    `json:"severity"`
+	URLID       *uint     `json:"url_id,omitempty"`
+	Tag         string    `json:"tag,omitempty"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToResponse converts AlertRule model to AlertRuleResponse
+func (r *AlertRule) ToResponse() AlertRuleResponse {
+	response := AlertRuleResponse{
+		ID:          r.ID,
+		WorkspaceID: r.WorkspaceID,
+		Name:        r.Name,
+		Description: r.Description,
+		Condition:   r.Condition,
+		Threshold:   r.Threshold,
+		Severity:    r.Severity,
+		URLID:       r.URLID,
+		Enabled:     r.Enabled,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.Tag != nil {
+		response.Tag = r.Tag.Name
+	}
+	return response
+}
+
+// AlertRuleRequest represents the request payload for creating or replacing an alert rule.
+// URLID and Tag are optional and mutually exclusive; a rule with neither applies to all URLs.
+type AlertRuleRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description,omitempty"`
+	Condition   string  `json:"condition" binding:"required"`
+	Threshold   float64 `json:"threshold,omitempty"`
+	Severity    string  `json:"severity,omitempty"`
+	URLID       *uint   `json:"url_id,omitempty"`
+	Tag         string  `json:"tag,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+}
+
+// Alert is raised when an alert rule fires for a URL. While it is open or
+// acknowledged, later firings of the same rule update it instead of raising a new one.
+type Alert struct {
+	ID            uint   `json:"id" gorm:"primaryKey"`
+	AlertRuleID   uint   `json:"rule_id" gorm:"not null;index"`
+	RuleName      string `json:"rule_name" gorm:"size:200"`
+	Condition     string `json:"condition" gorm:"size:50"`
+	Severity      string `json:"severity" gorm:"size:20;index"`
+	URLID         uint   `json:"url_id" gorm:"not null;index"`
+	AnalysisID    uint   `json:"analysis_id" gorm:"index"` // latest analysis that fired the rule
+	State         string `json:"state" gorm:"size:20;not null;index"`
+	Message       string `json:"message" gorm:"type:text"`
+	PreviousValue string `json:"previous_value" gorm:"type:text"`
+	CurrentValue  string `json:"current_value" gorm:"type:text"`
+	Occurrences   int    `json:"occurrences" gorm:"default:1"`
+
+	// Lifecycle
+	FirstSeenAt    time.Time  `json:"first_seen_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	Note           string     `json:"note" gorm:"type:text"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Alert model
+func (Alert) TableName() string {
+	return "alerts"
+}
+
+// AlertStateRequest represents the optional payload when acknowledging or resolving an alert
+type AlertStateRequest struct {
+	Note string `json:"note,omitempty"`
+}
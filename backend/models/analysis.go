@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Analysis represents a single completed analysis run of a URL
+type Analysis struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	URLID      uint   `json:"url_id" gorm:"not null;index"`
+	Status     string `json:"status" gorm:"size:20"`
+	StatusCode int    `json:"status_code"`
+
+	// Key metrics captured at analysis time
+	SEOScore                    int     `json:"seo_score"`
+	LoadTime                    float64 `json:"load_time"`
+	PageSize                    int64   `json:"page_size"`
+	BrokenLinks                 int     `json:"broken_links"`
+	AccessibilityViolationCount int     `json:"accessibility_violation_count"`
+	VulnerabilityCount          int     `json:"vulnerability_count"`
+	RuleViolationCount          int     `json:"rule_violation_count"`
+	FindingCount                int     `json:"finding_count"`
+	OverBudget                  bool    `json:"over_budget"`
+
+	ErrorMessage string    `json:"error_message" gorm:"type:text"`
+	AnalyzedAt   time.Time `json:"analyzed_at" gorm:"index"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the Analysis model
+func (Analysis) TableName() string {
+	return "analyses"
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditActionCreate         = "create"
+	AuditActionUpdate         = "update"
+	AuditActionDelete         = "delete"
+	AuditActionBulkDelete     = "bulk_delete"
+	AuditActionRestore        = "restore"
+	AuditActionPurge          = "purge"
+	AuditActionTag            = "tag"
+	AuditActionUntag          = "untag"
+	AuditActionMove           = "move"
+	AuditActionAnalyze        = "analyze"
+	AuditActionBulkAnalyze    = "bulk_analyze"
+	AuditActionImport         = "import"
+	AuditActionAcknowledge    = "acknowledge"
+	AuditActionResolve        = "resolve"
+	AuditActionRevoke         = "revoke"
+	AuditActionRedeliver      = "redeliver"
+	AuditActionSendTest       = "send_test"
+	AuditActionSendDigest     = "send_digest"
+	AuditActionChangePassword = "change_password"
+)
+
+// Audit log target types
+const (
+	AuditTargetURL                    = "url"
+	AuditTargetTag                    = "tag"
+	AuditTargetFolder                 = "folder"
+	AuditTargetSavedView              = "saved_view"
+	AuditTargetAuditRule              = "audit_rule"
+	AuditTargetBudget                 = "budget"
+	AuditTargetSchedule               = "schedule"
+	AuditTargetAlertRule              = "alert_rule"
+	AuditTargetAlert                  = "alert"
+	AuditTargetWebhook                = "webhook"
+	AuditTargetWebhookDelivery        = "webhook_delivery"
+	AuditTargetNotificationPreference = "notification_preference"
+	AuditTargetVulnerabilityFeed      = "vulnerability_feed"
+	AuditTargetUser                   = "user"
+	AuditTargetAPIKey                 = "api_key"
+	AuditTargetWorkspace              = "workspace"
+	AuditTargetWorkspaceMember        = "workspace_member"
+)
+
+// AuditLogEntry records one change made through the API: who made it, when,
+// from where, and what the targets looked like before and after. Entries are
+// only ever appended.
+type AuditLogEntry struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint   `json:"workspace_id" gorm:"not null;default:0;index"` // 0 for changes outside a workspace, such as users
+	UserID      *uint  `json:"user_id" gorm:"index"`
+	UserEmail   string `json:"user_email" gorm:"size:320"` // kept when the user is deleted
+	APIKeyID    *uint  `json:"api_key_id"`
+	Action      string `json:"action" gorm:"size:50;not null;index"`
+	TargetType  string `json:"target_type" gorm:"size:50;not null;index"`
+	TargetIDs   string `json:"-" gorm:"type:text"` // JSON array of IDs
+	Before      string `json:"-" gorm:"type:mediumtext"`
+	After       string `json:"-" gorm:"type:mediumtext"`
+	RequestID   string `json:"request_id" gorm:"size:64;index"`
+	SourceIP    string `json:"source_ip" gorm:"size:45"`
+	Method      string `json:"method" gorm:"size:10"`
+	Path        string `json:"path" gorm:"size:2048"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName specifies the table name for the AuditLogEntry model
+func (AuditLogEntry) TableName() string {
+	return "audit_log"
+}
+
+// AuditLogResponse represents the response format for an audit log entry
+type AuditLogResponse struct {
+	ID          uint            `json:"id"`
+	WorkspaceID uint            `json:"workspace_id"`
+	UserID      *uint           `json:"user_id"`
+	UserEmail   string          `json:"user_email"`
+	APIKeyID    *uint           `json:"api_key_id"`
+	Action      string          `json:"action"`
+	TargetType  string          `json:"target_type"`
+	TargetIDs   []uint          `json:"target_ids"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	RequestID   string          `json:"request_id"`
+	SourceIP    string          `json:"source_ip"`
+	Method      string          `json:"method"`
+	Path        string          `json:"path"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// ToResponse converts AuditLogEntry model to AuditLogResponse
+func (e *AuditLogEntry) ToResponse() AuditLogResponse {
+	targetIDs := []uint{}
+	if e.TargetIDs != "" {
+		json.Unmarshal([]byte(e.TargetIDs), &targetIDs)
+	}
+
+	response := AuditLogResponse{
+		ID:          e.ID,
+		WorkspaceID: e.WorkspaceID,
+		UserID:      e.UserID,
+		UserEmail:   e.UserEmail,
+		APIKeyID:    e.APIKeyID,
+		Action:      e.Action,
+		TargetType:  e.TargetType,
+		TargetIDs:   targetIDs,
+		RequestID:   e.RequestID,
+		SourceIP:    e.SourceIP,
+		Method:      e.Method,
+		Path:        e.Path,
+		CreatedAt:   e.CreatedAt,
+	}
+	if e.Before != "" {
+		response.Before = json.RawMessage(e.Before)
+	}
+	if e.After != "" {
+		response.After = json.RawMessage(e.After)
+	}
+	return response
+}
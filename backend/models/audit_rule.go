@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit rule types
+const (
+	AuditRuleTypeSelector  = "selector"  // elements matching a CSS selector (optionally containing text)
+	AuditRuleTypeAttribute = "attribute" // attribute values of matching elements against a regex
+	AuditRuleTypeRegex     = "regex"     // raw HTML against a regex
+)
+
+// Audit rule assertions
+const (
+	AuditAssertExists = "exists" // at least one match is required
+	AuditAssertAbsent = "absent" // no match is allowed
+)
+
+// AuditRule represents a user-defined house rule evaluated on every analysis
+type AuditRule struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint   `json:"workspace_id" gorm:"not null;default:0;uniqueIndex:idx_audit_rules_workspace_name,priority:1"`
+	Name        string `json:"name" gorm:"size:200;not null;uniqueIndex:idx_audit_rules_workspace_name,priority:2"`
+	Description string `json:"description" gorm:"type:text"`
+	Type        string `json:"type" gorm:"size:20;not null"` // selector, attribute, regex
+	Selector    string `json:"selector" gorm:"size:500"`
+	Attribute   string `json:"attribute" gorm:"size:100"`
+	Pattern     string `json:"pattern" gorm:"size:1000"` // regex for attribute and regex rules
+	Text        string `json:"text" gorm:"size:500"`     // required element text for selector rules
+	Assert      string `json:"assert" gorm:"size:20;default:'exists'"`
+	Severity    string `json:"severity" gorm:"size:20;default:'medium'"` // low, medium, high, critical
+	URLPattern  string `json:"url_pattern" gorm:"size:500"`              // regex scoping the rule to matching page URLs
+	Enabled     bool   `json:"enabled" gorm:"not null"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the AuditRule model
+func (AuditRule) TableName() string {
+	return "audit_rules"
+}
+
+// AuditRuleRequest represents the request payload for creating or replacing an audit rule
+type AuditRuleRequest struct {
+	Name        string `json:"name" yaml:"name" binding:"required"`
+	Description string `json:"description,omitempty" yaml:"description"`
+	Type        string `json:"type" yaml:"type" binding:"required"`
+	Selector    string `json:"selector,omitempty" yaml:"selector"`
+	Attribute   string `json:"attribute,omitempty" yaml:"attribute"`
+	Pattern     string `json:"pattern,omitempty" yaml:"pattern"`
+	Text        string `json:"text,omitempty" yaml:"text"`
+	Assert      string `json:"assert,omitempty" yaml:"assert"`
+	Severity    string `json:"severity,omitempty" yaml:"severity"`
+	URLPattern  string `json:"url_pattern,omitempty" yaml:"url_pattern"`
+	Enabled     *bool  `json:"enabled,omitempty" yaml:"enabled"`
+}
+
+// AuditRuleFile represents a YAML or JSON document containing audit rules
+type AuditRuleFile struct {
+	Rules []AuditRuleRequest `json:"rules" yaml:"rules"`
+}
+
+// RuleViolation represents a failed audit rule recorded for a single analysis
+type RuleViolation struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AnalysisID uint      `json:"analysis_id" gorm:"not null;index"`
+	URLID      uint      `json:"url_id" gorm:"not null;index"`
+	RuleID     uint      `json:"rule_id" gorm:"not null;index"`
+	RuleName   string    `json:"rule_name" gorm:"size:200"`
+	Severity   string    `json:"severity" gorm:"size:20;index"`
+	Message    string    `json:"message" gorm:"type:text"`
+	Selector   string    `json:"selector,omitempty" gorm:"size:500"`
+	AnalyzedAt time.Time `json:"analyzed_at" gorm:"index"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the RuleViolation model
+func (RuleViolation) TableName() string {
+	return "rule_violations"
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BudgetLimit represents the maximum allowed value of a single metric
+type BudgetLimit struct {
+	Metric string  `json:"metric"` // load_time (seconds), page_size (bytes), third_party_requests, total_requests, total_links, ...
+	Max    float64 `json:"max"`
+}
+
+// PerformanceBudget represents a set of metric limits applied to a URL or to every URL with a tag
+type PerformanceBudget struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint   `json:"workspace_id" gorm:"not null;default:0;index"`
+	Name        string `json:"name" gorm:"size:200;not null"`
+	URLID       *uint  `json:"url_id" gorm:"index"`
+	TagID       *uint  `json:"tag_id" gorm:"index"`
+	Tag         *Tag   `json:"tag,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	Limits      string `json:"-" gorm:"type:text"`
+	Enabled     bool   `json:"enabled" gorm:"not null"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the PerformanceBudget model
+func (PerformanceBudget) TableName() string {
+	return "performance_budgets"
+}
+
+// GetLimits parses the stored budget limits
+func (b *PerformanceBudget) GetLimits() []BudgetLimit {
+	limits := []BudgetLimit{}
+	if b.Limits != "" {
+		json.Unmarshal([]byte(b.Limits), &limits)
+	}
+	return limits
+}
+
+// BudgetResponse represents the response format for a performance budget
+type BudgetResponse struct {
+	ID          uint          `json:"id"`
+	WorkspaceID uint          `json:"workspace_id"`
+	Name        string        `json:"name"`
+	URLID       *uint         `json:"url_id"`
+	Tag         string        `json:"tag,omitempty"`
+	Limits      []BudgetLimit `json:"limits"`
+	Enabled     bool          `json:"enabled"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// ToResponse converts PerformanceBudget model to BudgetResponse
+func (b *PerformanceBudget) ToResponse() BudgetResponse {
+	response := BudgetResponse{
+		ID:          b.ID,
+		WorkspaceID: b.WorkspaceID,
+		Name:        b.Name,
+		URLID:       b.URLID,
+		Limits:      b.GetLimits(),
+		Enabled:     b.Enabled,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+	if b.Tag != nil {
+		response.Tag = b.Tag.Name
+	}
+	return response
+}
+
+// BudgetRequest represents the request payload for creating or replacing a performance budget
+type BudgetRequest struct {
+	Name    string        `json:"name" binding:"required"`
+	URLID   *uint         `json:"url_id,omitempty"`
+	Tag     string        `json:"tag,omitempty"`
+	Limits  []BudgetLimit `json:"limits" binding:"required,min=1"`
+	Enabled *bool         `json:"enabled,omitempty"`
+}
+
+// BudgetResult represents the outcome of one budget limit for an analysis
+type BudgetResult struct {
+	BudgetID   uint    `json:"budget_id"`
+	BudgetName string  `json:"budget_name"`
+	Metric     string  `json:"metric"`
+	Max        float64 `json:"max"`
+	Actual     float64 `json:"actual"`
+	Passed     bool    `json:"passed"`
+	Overage    float64 `json:"overage"` // amount over the limit, 0 when passed
+}
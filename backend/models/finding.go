@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// Finding represents a single issue reported by an analyzer check
+type Finding struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AnalysisID uint      `json:"analysis_id" gorm:"not null;index"`
+	URLID      uint      `json:"url_id" gorm:"not null;index"`
+	Check      string    `json:"check" gorm:"column:check_name;size:100;index"`
+	Severity   string    `json:"severity" gorm:"size:20;index"` // low, medium, high, critical
+	Message    string    `json:"message" gorm:"type:text"`
+	Selector   string    `json:"selector,omitempty" gorm:"size:500"`
+	Details    string    `json:"details,omitempty" gorm:"type:text"`
+	AnalyzedAt time.Time `json:"analyzed_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the Finding model
+func (Finding) TableName() string {
+	return "findings"
+}
+
+// Metric represents a single numeric measurement reported by an analyzer check
+type Metric struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AnalysisID uint      `json:"analysis_id" gorm:"not null;index"`
+	URLID      uint      `json:"url_id" gorm:"not null;index"`
+	Check      string    `json:"check" gorm:"column:check_name;size:100;index"`
+	Name       string    `json:"name" gorm:"size:100;index"`
+	Value      float64   `json:"value"`
+	AnalyzedAt time.Time `json:"analyzed_at" gorm:"index"`
+}
+
+// TableName specifies the table name for the Metric model
+func (Metric) TableName() string {
+	return "metrics"
+}
+
+// CheckResult summarizes how a single check ran during an analysis
+type CheckResult struct {
+	Name         string `json:"name"`
+	Status       string `json:"status"` // completed, disabled, skipped, timeout, failed
+	Message      string `json:"message,omitempty"`
+	DurationMs   int64  `json:"duration_ms"`
+	FindingCount int    `json:"finding_count"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Folder groups URLs into projects and sub-folders within a workspace. A URL
+// is in at most one folder; folders without a parent are at the top level.
+type Folder struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint   `json:"workspace_id" gorm:"not null;default:0;index"`
+	ParentID    *uint  `json:"parent_id" gorm:"index"`
+	Name        string `json:"name" gorm:"size:100;not null"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Folder model
+func (Folder) TableName() string {
+	return "folders"
+}
+
+// FolderResponse represents the response format for a folder
+type FolderResponse struct {
+	ID          uint      `json:"id"`
+	WorkspaceID uint      `json:"workspace_id"`
+	ParentID    *uint     `json:"parent_id"`
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`      // names from the top level down, separated by "/"
+	URLCount    int64     `json:"url_count"` // URLs directly in the folder
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// FolderRequest represents the request payload for creating or replacing a
+// folder. Omitting parent_id puts the folder at the top level.
+type FolderRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
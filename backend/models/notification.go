@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+)
+
+// Digest frequencies
+const (
+	DigestNone   = "none"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// NotificationPreference holds the email notifications a user wants from a
+// workspace. Mail goes to the user's current email address.
+type NotificationPreference struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID    uint   `json:"workspace_id" gorm:"not null;default:0;uniqueIndex:idx_notification_preferences_workspace_user,priority:1"`
+	UserID         uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_notification_preferences_workspace_user,priority:2"`
+	User           *User  `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CriticalAlerts bool   `json:"critical_alerts" gorm:"not null"`      // immediate mail for critical alerts
+	Digest         string `json:"digest" gorm:"size:20;default:'none'"` // none, daily or weekly
+	Enabled        bool   `json:"enabled" gorm:"not null"`
+
+	// Digest state
+	LastDigestAt *time.Time `json:"last_digest_at"`
+	NextDigestAt *time.Time `json:"next_digest_at" gorm:"index"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the NotificationPreference model
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// NotificationPreferenceRequest represents the request payload for creating or replacing notification preferences
+type NotificationPreferenceRequest struct {
+	UserID         uint   `json:"user_id,omitempty"` // recipient, required on create; your own preferences and updates keep their user
+	CriticalAlerts *bool  `json:"critical_alerts,omitempty"`
+	Digest         string `json:"digest,omitempty"`
+	Enabled        *bool  `json:"enabled,omitempty"`
+}
+
+// Digest summarizes analysis activity across all URLs over a period
+type Digest struct {
+	Frequency      string              `json:"frequency"`
+	Since          time.Time           `json:"since"`
+	Until          time.Time           `json:"until"`
+	AnalysisCount  int                 `json:"analysis_count"`
+	URLCount       int                 `json:"url_count"`
+	BrokenLinks    []DigestBrokenLinks `json:"broken_links"`
+	FailedAnalyses []DigestFailure     `json:"failed_analyses"`
+	ScoreChanges   []DigestScoreChange `json:"score_changes"`
+}
+
+// Empty reports whether the digest has nothing worth sending
+func (d *Digest) Empty() bool {
+	return len(d.BrokenLinks) == 0 && len(d.FailedAnalyses) == 0 && len(d.ScoreChanges) == 0
+}
+
+// DigestBrokenLinks describes a URL whose broken link count grew during the digest period
+type DigestBrokenLinks struct {
+	URLID    uint     `json:"url_id"`
+	URL      string   `json:"url"`
+	Previous int      `json:"previous"`
+	Current  int      `json:"current"`
+	Links    []string `json:"links"` // currently broken links
+}
+
+// DigestFailure describes a URL whose analyses failed during the digest period
+type DigestFailure struct {
+	URLID     uint      `json:"url_id"`
+	URL       string    `json:"url"`
+	Count     int       `json:"count"`
+	LastError string    `json:"last_error"`
+	FailedAt  time.Time `json:"failed_at"`
+}
+
+// DigestScoreChange describes a URL whose SEO score changed during the digest period
+type DigestScoreChange struct {
+	URLID    uint   `json:"url_id"`
+	URL      string `json:"url"`
+	Previous int    `json:"previous"`
+	Current  int    `json:"current"`
+	Change   int    `json:"change"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// SavedView is a named URL list filter and sort that a user saved in a workspace
+type SavedView struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint      `json:"workspace_id" gorm:"not null;uniqueIndex:idx_saved_views_owner_name,priority:1"`
+	UserID      uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_saved_views_owner_name,priority:2"`
+	Name        string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_saved_views_owner_name,priority:3"`
+	Filter      string    `json:"filter" gorm:"type:text"`
+	Sort        string    `json:"sort" gorm:"size:200"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the SavedView model
+func (SavedView) TableName() string {
+	return "saved_views"
+}
+
+// SavedViewResponse represents the response format for a saved view
+type SavedViewResponse struct {
+	ID          uint      `json:"id"`
+	WorkspaceID uint      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Filter      string    `json:"filter"`
+	Sort        string    `json:"sort"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToResponse converts SavedView model to SavedViewResponse
+func (v *SavedView) ToResponse() SavedViewResponse {
+	return SavedViewResponse{
+		ID:          v.ID,
+		WorkspaceID: v.WorkspaceID,
+		Name:        v.Name,
+		Filter:      v.Filter,
+		Sort:        v.Sort,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+}
+
+// SavedViewRequest represents the request payload for creating or updating a
+// saved view. Filter is a URL filter expression and Sort a list of fields such
+// as "-load_time,title"; at least one of them is required.
+type SavedViewRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Filter string `json:"filter"`
+	Sort   string `json:"sort"`
+}
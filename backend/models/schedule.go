@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+)
+
+// Schedule targets
+const (
+	ScheduleTargetURL = "url"
+	ScheduleTargetTag = "tag"
+	ScheduleTargetAll = "all"
+)
+
+// Schedule represents a recurring re-analysis of a URL, a tag group or all URLs
+type Schedule struct {
+	ID              uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID     uint   `json:"workspace_id" gorm:"not null;default:0;index"`
+	Name            string `json:"name" gorm:"size:200;not null"`
+	Cron            string `json:"cron" gorm:"size:100"`              // five-field cron expression or macro such as @weekly
+	IntervalSeconds int    `json:"interval_seconds" gorm:"default:0"` // fixed interval, used when cron is empty
+	Timezone        string `json:"timezone" gorm:"size:64"`           // IANA zone for cron expressions, server local time if empty
+	JitterSeconds   int    `json:"jitter_seconds" gorm:"default:0"`   // random delay added to every run
+	URLID           *uint  `json:"url_id" gorm:"index"`
+	TagID           *uint  `json:"tag_id" gorm:"index"`
+	Tag             *Tag   `json:"tag,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	Enabled         bool   `json:"enabled" gorm:"not null"`
+
+	// Run state
+	NextRunAt     *time.Time `json:"next_run_at" gorm:"index"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastRunStatus string     `json:"last_run_status" gorm:"size:20"` // running, completed, failed, skipped
+	LastRunError  string     `json:"last_run_error" gorm:"type:text"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Schedule model
+func (Schedule) TableName() string {
+	return "schedules"
+}
+
+// Target returns what the schedule analyzes: a single URL, a tag group or all URLs
+func (s *Schedule) Target() string {
+	switch {
+	case s.URLID != nil:
+		return ScheduleTargetURL
+	case s.TagID != nil:
+		return ScheduleTargetTag
+	default:
+		return ScheduleTargetAll
+	}
+}
+
+// ScheduleResponse represents the response format for a schedule
+type ScheduleResponse struct {
+	ID            uint       `json:"id"`
+	WorkspaceID   uint       `json:"workspace_id"`
+	Name          string     `json:"name"`
+	Cron          string     `json:"cron,omitempty"`
+	Interval      string     `json:"interval,omitempty"`
+	Timezone      string     `json:"timezone,omitempty"`
+	JitterSeconds int        `json:"jitter_seconds"`
+	Target        string     `json:"target"`
+	URLID         *uint      `json:"url_id,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+	Enabled       bool       `json:"enabled"`
+	NextRunAt     *time.Time `json:"next_run_at"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastRunStatus string     `json:"last_run_status"`
+	LastRunError  string     `json:"last_run_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// ToResponse converts Schedule model to ScheduleResponse
+func (s *Schedule) ToResponse() ScheduleResponse {
+	response := ScheduleResponse{
+		ID:            s.ID,
+		WorkspaceID:   s.WorkspaceID,
+		Name:          s.Name,
+		Cron:          s.Cron,
+		Timezone:      s.Timezone,
+		JitterSeconds: s.JitterSeconds,
+		Target:        s.Target(),
+		URLID:         s.URLID,
+		Enabled:       s.Enabled,
+		NextRunAt:     s.NextRunAt,
+		LastRunAt:     s.LastRunAt,
+		LastRunStatus: s.LastRunStatus,
+		LastRunError:  s.LastRunError,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+	if s.Cron == "" && s.IntervalSeconds > 0 {
+		response.Interval = (time.Duration(s.IntervalSeconds) * time.Second).String()
+	}
+	if s.Tag != nil {
+		response.Tag = s.Tag.Name
+	}
+	return response
+}
+
+// ScheduleRequest represents the request payload for creating or replacing a schedule.
+// Exactly one of Cron or Interval is required; URLID and Tag are optional and
+// mutually exclusive, and a schedule with neither covers all URLs.
+type ScheduleRequest struct {
+	Name          string `json:"name" binding:"required"`
+	Cron          string `json:"cron,omitempty"`
+	Interval      string `json:"interval,omitempty"` // Go duration such as "24h" or "168h"
+	Timezone      string `json:"timezone,omitempty"`
+	JitterSeconds int    `json:"jitter_seconds,omitempty"`
+	URLID         *uint  `json:"url_id,omitempty"`
+	Tag           string `json:"tag,omitempty"`
+	Enabled       *bool  `json:"enabled,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// Tag represents a label used to group URLs within a workspace
+type Tag struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint      `json:"workspace_id" gorm:"not null;default:0;uniqueIndex:idx_tags_workspace_name,priority:1"`
+	Name        string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_tags_workspace_name,priority:2"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TagResponse represents the response format for a tag
+type TagResponse struct {
+	ID          uint      `json:"id"`
+	WorkspaceID uint      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	URLCount    int64     `json:"url_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToResponse converts Tag model to TagResponse, given the number of URLs with the tag
+func (t *Tag) ToResponse(urlCount int64) TagResponse {
+	return TagResponse{
+		ID:          t.ID,
+		WorkspaceID: t.WorkspaceID,
+		Name:        t.Name,
+		URLCount:    urlCount,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// TagRequest represents the request payload for creating or renaming a tag
+type TagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
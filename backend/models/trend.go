@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// TrendPoint aggregates one metric over the analyses in a time bucket
+type TrendPoint struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"` // analyses in the bucket
+	Min   float64   `json:"min"`
+	Avg   float64   `json:"avg"`
+	Max   float64   `json:"max"`
+}
+
+// TrendSeries is the time-bucketed history of a single metric
+type TrendSeries struct {
+	Metric string       `json:"metric"`
+	Points []TrendPoint `json:"points"` // buckets without analyses are omitted
+	Change *float64     `json:"change"` // average of the last bucket minus the one before it
+}
+
+// TrendReport holds the metric series for a URL or a portfolio of URLs
+type TrendReport struct {
+	URLID    *uint         `json:"url_id,omitempty"`
+	Tag      string        `json:"tag,omitempty"`
+	URLCount int           `json:"url_count"` // URLs with analyses in the period
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Bucket   string        `json:"bucket"`
+	Series   []TrendSeries `json:"series"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// UptimeCheck is a single lightweight availability probe of a URL
+type UptimeCheck struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	URLID      uint      `json:"url_id" gorm:"not null;index:idx_uptime_checks_url_checked,priority:1"`
+	Up         bool      `json:"up"`
+	StatusCode int       `json:"status_code"`
+	Latency    int64     `json:"latency_ms"` // time to response headers in milliseconds
+	Error      string    `json:"error,omitempty" gorm:"type:text"`
+	CheckedAt  time.Time `json:"checked_at" gorm:"not null;index;index:idx_uptime_checks_url_checked,priority:2"`
+}
+
+// TableName specifies the table name for the UptimeCheck model
+func (UptimeCheck) TableName() string {
+	return "uptime_checks"
+}
+
+// UptimeIncident is a run of consecutive failed probes
+type UptimeIncident struct {
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at"` // first successful probe after the incident, nil while ongoing
+	Duration   float64    `json:"duration_seconds"`
+	Failures   int        `json:"failures"`
+	StatusCode int        `json:"status_code"` // of the last failed probe
+	Error      string     `json:"error,omitempty"`
+	Ongoing    bool       `json:"ongoing"`
+}
+
+// UptimeReport summarizes the availability of a URL over a time range
+type UptimeReport struct {
+	URLID         uint             `json:"url_id"`
+	URL           string           `json:"url"`
+	Range         string           `json:"range"`
+	Since         time.Time        `json:"since"`
+	Until         time.Time        `json:"until"`
+	TotalChecks   int              `json:"total_checks"`
+	UpChecks      int              `json:"up_checks"`
+	DownChecks    int              `json:"down_checks"`
+	UptimePercent *float64         `json:"uptime_percent"` // nil when there were no probes in the range
+	AvgLatency    int64            `json:"avg_latency_ms"`
+	MaxLatency    int64            `json:"max_latency_ms"`
+	CurrentlyUp   *bool            `json:"currently_up"`
+	LastCheck     *UptimeCheck     `json:"last_check"`
+	Incidents     []UptimeIncident `json:"incidents"`
+	Checks        []UptimeCheck    `json:"checks,omitempty"` // only when requested
+}
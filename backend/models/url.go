@@ -11,6 +11,7 @@ import (
 // URL represents a URL entity in the database
 type URL struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint           `json:"workspace_id" gorm:"not null;default:0;index;index:idx_urls_workspace_created,priority:1"`
 	URL         string         `json:"url" gorm:"not null;index" validate:"required,url"`
 	Title       string         `json:"title" gorm:"size:500"`
 	Description string         `json:"description" gorm:"type:text"`
@@ -21,6 +22,7 @@ type URL struct {
 	MetaTitle       string `json:"meta_title" gorm:"size:500"`
 	MetaDescription string `json:"meta_description" gorm:"type:text"`
 	HTMLVersion     string `json:"html_version" gorm:"size:50"`
+	Noindex         bool   `json:"noindex" gorm:"default:false"`
 
 	// Heading tags analysis
 	H1Tags          string `json:"h1_tags" gorm:"type:text"`
@@ -47,17 +49,57 @@ type URL struct {
 	// Form analysis
 	HasLoginForm    bool   `json:"has_login_form" gorm:"default:false"`
 	FormCount       int    `json:"form_count" gorm:"default:0"`
+	Forms             string `json:"forms" gorm:"type:text"`
+	HighSeverityForms int    `json:"high_severity_forms" gorm:"default:0"`
+
+	// Mobile-friendliness analysis
+	MobileAnalysis string `json:"mobile_analysis" gorm:"type:text"`
+	MobileFriendly bool   `json:"mobile_friendly" gorm:"default:false"`
+
+	// Accessibility analysis (violations are stored as findings)
+	AccessibilityViolationCount int `json:"accessibility_violation_count" gorm:"default:0"`
+
+	// Technology fingerprinting
+	Technologies string `json:"technologies" gorm:"type:text"`
+
+	// JavaScript inventory and known vulnerabilities
+	Scripts               string `json:"scripts" gorm:"type:text"`
+	JSLibraries           string `json:"js_libraries" gorm:"type:text"`
+	VulnerableLibraries   int    `json:"vulnerable_libraries" gorm:"default:0"`
+	VulnerabilityCount    int    `json:"vulnerability_count" gorm:"default:0"`
+	VulnerabilitySeverity string `json:"vulnerability_severity" gorm:"size:20"`
+
+	// Composite health score
+	SEOScore       int    `json:"seo_score" gorm:"default:0;index"`
+	ScoreBreakdown string `json:"score_breakdown" gorm:"type:text"`
+
+	// User-defined audit rules (violations are stored per analysis)
+	LastAnalysisID     uint `json:"last_analysis_id" gorm:"default:0;index"`
+	RuleViolationCount int  `json:"rule_violation_count" gorm:"default:0"`
+
+	// Check pipeline run summary (findings and metrics are stored per analysis)
+	CheckResults string `json:"check_results" gorm:"type:text"`
 	
 	// Performance fields
 	LoadTime     float64 `json:"load_time" gorm:"default:0"`
 	PageSize     int64   `json:"page_size" gorm:"default:0"`
+	TotalRequests      int `json:"total_requests" gorm:"default:0"`
+	ThirdPartyRequests int `json:"third_party_requests" gorm:"default:0"`
+
+	// Performance budgets
+	BudgetResults string `json:"budget_results" gorm:"type:text"`
+	OverBudget    bool   `json:"over_budget" gorm:"default:false;index"`
+
+	// Grouping
+	Tags     []Tag `json:"tags" gorm:"many2many:url_tags"`
+	FolderID *uint `json:"folder_id" gorm:"index"`
 	
 	// Analysis metadata
 	AnalyzedAt   *time.Time `json:"analyzed_at"`
 	ErrorMessage string     `json:"error_message" gorm:"type:text"`
 	
 	// Timestamps
-	CreatedAt time.Time      `json:"created_at"`
+	CreatedAt time.Time      `json:"created_at" gorm:"index:idx_urls_workspace_created,priority:2"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
@@ -69,8 +111,10 @@ func (URL) TableName() string {
 
 // URLCreateRequest represents the request payload for creating a URL
 type URLCreateRequest struct {
-	URL   string `json:"url" validate:"required,url" binding:"required"`
-	Title string `json:"title,omitempty" validate:"omitempty,min=2,max=100"`
+	URL      string   `json:"url" validate:"required,url" binding:"required"`
+	Title    string   `json:"title,omitempty" validate:"omitempty,min=2,max=100"`
+	Tags     []string `json:"tags,omitempty"`
+	FolderID *uint    `json:"folder_id,omitempty"`
 }
 
 // URLUpdateRequest represents the request payload for updating a URL
@@ -78,6 +122,8 @@ type URLUpdateRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Status      *string `json:"status,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+	FolderID    *uint     `json:"folder_id,omitempty"` // 0 removes the URL from its folder
 }
 
 // BulkDeleteRequest represents the request payload for bulk deleting URLs
@@ -85,6 +131,32 @@ type BulkDeleteRequest struct {
 	IDs []uint `json:"ids" validate:"required,min=1" binding:"required"`
 }
 
+// BulkRestoreRequest represents the request payload for restoring URLs from the trash
+type BulkRestoreRequest struct {
+	IDs []uint `json:"ids" validate:"required,min=1" binding:"required"`
+}
+
+// BulkTagRequest represents the request payload for adding tags to or removing
+// tags from several URLs
+type BulkTagRequest struct {
+	IDs  []uint   `json:"ids" validate:"required,min=1" binding:"required"`
+	Tags []string `json:"tags" validate:"required,min=1" binding:"required"`
+}
+
+// BulkMoveRequest represents the request payload for moving several URLs into
+// a folder; a folder_id of 0 or none moves them out of their folders
+type BulkMoveRequest struct {
+	IDs      []uint `json:"ids" validate:"required,min=1" binding:"required"`
+	FolderID uint   `json:"folder_id"`
+}
+
+// TrashPurgeRequest represents the request payload for permanently deleting
+// URLs from the trash: either the given IDs or, with All, the whole trash
+type TrashPurgeRequest struct {
+	IDs []uint `json:"ids"`
+	All bool   `json:"all"`
+}
+
 // BulkAnalyzeRequest represents the request payload for bulk analyzing URLs
 type BulkAnalyzeRequest struct {
 	IDs []uint `json:"ids" validate:"required,min=1" binding:"required"`
@@ -98,6 +170,7 @@ type BulkImportRequest struct {
 // URLResponse represents the response format for URL data
 type URLResponse struct {
 	ID          uint       `json:"id"`
+	WorkspaceID uint       `json:"workspace_id"`
 	URL         string     `json:"url"`
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
@@ -109,11 +182,32 @@ type URLResponse struct {
 	
 	// Performance
 	Performance Performance `json:"performance"`
+
+	// Grouping
+	Tags     []string `json:"tags"`
+	FolderID *uint    `json:"folder_id"`
+
+	// Technologies
+	Technologies []Technology `json:"technologies"`
+
+	// JavaScript
+	JavaScript JavaScriptAnalysis `json:"javascript"`
+
+	// Health score
+	Score ScoreReport `json:"score"`
+
+	// User-defined audit rules
+	LastAnalysisID     uint `json:"last_analysis_id"`
+	RuleViolationCount int  `json:"rule_violation_count"`
+
+	// Check pipeline
+	Checks []CheckResult `json:"checks"`
 	
 	// Metadata
 	AnalyzedAt *time.Time `json:"analyzed_at"`
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"` // set for URLs in the trash
 }
 
 // SEOAnalysis represents SEO analysis data
@@ -121,9 +215,12 @@ type SEOAnalysis struct {
 	MetaTitle       string      `json:"meta_title"`
 	MetaDescription string      `json:"meta_description"`
 	HTMLVersion     string      `json:"html_version"`
+	Noindex         bool        `json:"noindex"`
 	HeadingTags     HeadingTags `json:"heading_tags"`
 	LinkAnalysis    LinkAnalysis `json:"link_analysis"`
 	FormAnalysis    FormAnalysis `json:"form_analysis"`
+	Accessibility   AccessibilityAnalysis `json:"accessibility"`
+	Mobile          MobileAnalysis        `json:"mobile"`
 	ImageCount      int         `json:"image_count"`
 }
 
@@ -150,6 +247,58 @@ type BrokenLinkInfo struct {
 	Error      string `json:"error,omitempty"`
 }
 
+// Technology represents a technology detected on a page (CMS, framework, server, CDN, analytics, ...)
+type Technology struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Version  string `json:"version,omitempty"`
+	Website  string `json:"website,omitempty"`
+}
+
+// ScriptInfo represents a single <script> element found on a page
+type ScriptInfo struct {
+	Src        string `json:"src,omitempty"`
+	Inline     bool   `json:"inline"`
+	ThirdParty bool   `json:"third_party"`
+	Async      bool   `json:"async,omitempty"`
+	Defer      bool   `json:"defer,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Size       int    `json:"size,omitempty"` // inline content length in bytes
+}
+
+// JSVulnerability represents a known vulnerability affecting a JavaScript library version
+type JSVulnerability struct {
+	Identifiers []string `json:"identifiers"`
+	Severity    string   `json:"severity"`
+	Summary     string   `json:"summary"`
+	AtOrAbove   string   `json:"at_or_above,omitempty"`
+	Below       string   `json:"below"`
+	Info        []string `json:"info,omitempty"`
+}
+
+// JSLibrary represents a JavaScript library detected on a page
+type JSLibrary struct {
+	Name            string            `json:"name"`
+	Version         string            `json:"version"`
+	DetectedBy      string            `json:"detected_by"` // filename, uri, filecontent, globals
+	Source          string            `json:"source"`      // script URL or "inline"
+	Vulnerabilities []JSVulnerability `json:"vulnerabilities"`
+	Severity        string            `json:"severity,omitempty"` // highest vulnerability severity
+}
+
+// JavaScriptAnalysis represents the script inventory and library vulnerability report
+type JavaScriptAnalysis struct {
+	TotalScripts        int          `json:"total_scripts"`
+	InlineScripts       int          `json:"inline_scripts"`
+	ExternalScripts     int          `json:"external_scripts"`
+	ThirdPartyScripts   int          `json:"third_party_scripts"`
+	Scripts             []ScriptInfo `json:"scripts"`
+	Libraries           []JSLibrary  `json:"libraries"`
+	VulnerableLibraries int          `json:"vulnerable_libraries"`
+	VulnerabilityCount  int          `json:"vulnerability_count"`
+	Severity            string       `json:"severity"`
+}
+
 // LinkAnalysis represents link analysis data
 type LinkAnalysis struct {
 	TotalLinks      int               `json:"total_links"`
@@ -161,14 +310,114 @@ type LinkAnalysis struct {
 
 // FormAnalysis represents form analysis data
 type FormAnalysis struct {
-	HasLoginForm bool `json:"has_login_form"`
-	FormCount    int  `json:"form_count"`
+	HasLoginForm      bool         `json:"has_login_form"`
+	FormCount         int          `json:"form_count"`
+	HighSeverityForms int          `json:"high_severity_forms"`
+	Forms             []FormReport `json:"forms"`
+}
+
+// FormField represents a single field of a form
+type FormField struct {
+	Tag          string `json:"tag"`  // input, select, textarea
+	Type         string `json:"type"` // input type, e.g. text, email, password
+	Name         string `json:"name,omitempty"`
+	Required     bool   `json:"required,omitempty"`
+	Autocomplete string `json:"autocomplete,omitempty"`
+}
+
+// FormIssue represents a security or usability finding on a form
+type FormIssue struct {
+	Severity string `json:"severity"` // high, medium, low
+	Message  string `json:"message"`
+}
+
+// FormReport represents the detailed analysis of a single form
+type FormReport struct {
+	Selector        string      `json:"selector"`
+	Type            string      `json:"type"` // login, signup, search, newsletter, contact, other
+	Method          string      `json:"method"`
+	Action          string      `json:"action"` // resolved absolute URL
+	CrossOrigin     bool        `json:"cross_origin"`
+	InsecureAction  bool        `json:"insecure_action"` // submits over plain HTTP
+	Fields          []FormField `json:"fields"`
+	HasPassword     bool        `json:"has_password"`
+	HasCSRFToken    bool        `json:"has_csrf_token"`
+	CSRFTokenName   string      `json:"csrf_token_name,omitempty"`
+	HasCaptcha      bool        `json:"has_captcha"`
+	CaptchaProvider string      `json:"captcha_provider,omitempty"`
+	Severity        string      `json:"severity,omitempty"` // highest issue severity
+	Issues          []FormIssue `json:"-"`                  // reported as findings
+}
+
+// AccessibilityViolation represents a single failed automated WCAG check
+type AccessibilityViolation struct {
+	Rule     string `json:"rule"`
+	WCAG     string `json:"wcag"`     // success criterion, e.g. "1.1.1"
+	Level    string `json:"level"`    // A, AA or AAA
+	Severity string `json:"severity"` // critical, serious, moderate, minor
+	Message  string `json:"message"`
+	Selector string `json:"selector,omitempty"`
+	HTML     string `json:"html,omitempty"`
+}
+
+// AccessibilityAnalysis represents accessibility analysis data; the violations
+// themselves are the findings of the accessibility check
+type AccessibilityAnalysis struct {
+	ViolationCount int `json:"violation_count"`
+}
+
+// MobileIssue represents a single failed mobile-friendliness check
+type MobileIssue struct {
+	Check    string `json:"check"`    // viewport, fixed-width, font-size, plugins, touch-targets
+	Severity string `json:"severity"` // high, medium, low
+	Message  string `json:"message"`
+	Selector string `json:"selector,omitempty"`
+}
+
+// MobileAnalysis represents static mobile-friendliness analysis data
+type MobileAnalysis struct {
+	MobileFriendly        bool          `json:"mobile_friendly"`
+	HasViewport           bool          `json:"has_viewport"`
+	Viewport              string        `json:"viewport"`
+	DeviceWidth           bool          `json:"device_width"`
+	ZoomDisabled          bool          `json:"zoom_disabled"`
+	FixedWidthElements    int           `json:"fixed_width_elements"`
+	SmallFontDeclarations int           `json:"small_font_declarations"`
+	PluginElements        int           `json:"plugin_elements"`
+	UsesFlash             bool          `json:"uses_flash"`
+	CrowdedLinks          int           `json:"crowded_links"`
+	IssueCount            int           `json:"issue_count"`
+	Issues                []MobileIssue `json:"-"` // reported as findings
+}
+
+// ScoreRuleResult represents the outcome of a single scoring rule
+type ScoreRuleResult struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Category string  `json:"category"`
+	Weight   float64 `json:"weight"`
+	Passed   bool    `json:"passed"`
+	Detail   string  `json:"detail"`
+	FixHint  string  `json:"fix_hint,omitempty"`
+}
+
+// ScoreReport represents the composite 0-100 health score and its breakdown
+type ScoreReport struct {
+	Score       int               `json:"score"`
+	Categories  map[string]int    `json:"categories"`
+	PassedRules int               `json:"passed_rules"`
+	FailedRules int               `json:"failed_rules"`
+	Rules       []ScoreRuleResult `json:"rules"`
 }
 
 // Performance represents performance metrics
 type Performance struct {
-	LoadTime float64 `json:"load_time"`
-	PageSize int64   `json:"page_size"`
+	LoadTime           float64        `json:"load_time"`
+	PageSize           int64          `json:"page_size"`
+	TotalRequests      int            `json:"total_requests"`
+	ThirdPartyRequests int            `json:"third_party_requests"`
+	OverBudget         bool           `json:"over_budget"`
+	Budgets            []BudgetResult `json:"budgets"`
 }
 
 // ToResponse converts URL model to URLResponse
@@ -176,6 +425,14 @@ func (u *URL) ToResponse() URLResponse {
 	// Parse JSON strings back to arrays
 	var h1Tags, h2Tags, h3Tags, h4Tags, h5Tags, h6Tags []string
 	var brokenLinksList []BrokenLinkInfo
+	var technologies []Technology
+	var scripts []ScriptInfo
+	var forms []FormReport
+	var mobile MobileAnalysis
+	var score ScoreReport
+	var checks []CheckResult
+	var budgets []BudgetResult
+	var jsLibraries []JSLibrary
 
 	// Parse heading tags
 	if u.H1Tags != "" {
@@ -202,8 +459,73 @@ func (u *URL) ToResponse() URLResponse {
 		json.Unmarshal([]byte(u.BrokenLinksList), &brokenLinksList)
 	}
 
+	// Parse detected technologies
+	if u.Technologies != "" {
+		json.Unmarshal([]byte(u.Technologies), &technologies)
+	}
+
+	// Parse form reports
+	if u.Forms != "" {
+		json.Unmarshal([]byte(u.Forms), &forms)
+	}
+
+	// Parse mobile-friendliness analysis
+	if u.MobileAnalysis != "" {
+		json.Unmarshal([]byte(u.MobileAnalysis), &mobile)
+	}
+
+	// Parse score breakdown
+	if u.ScoreBreakdown != "" {
+		json.Unmarshal([]byte(u.ScoreBreakdown), &score)
+	}
+	score.Score = u.SEOScore
+
+	// Parse performance budget results
+	if u.BudgetResults != "" {
+		json.Unmarshal([]byte(u.BudgetResults), &budgets)
+	}
+
+	// Collect tag names
+	tags := make([]string, 0, len(u.Tags))
+	for _, tag := range u.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	// Parse check pipeline results
+	if u.CheckResults != "" {
+		json.Unmarshal([]byte(u.CheckResults), &checks)
+	}
+
+	// Parse script inventory and detected libraries
+	if u.Scripts != "" {
+		json.Unmarshal([]byte(u.Scripts), &scripts)
+	}
+	if u.JSLibraries != "" {
+		json.Unmarshal([]byte(u.JSLibraries), &jsLibraries)
+	}
+
+	javaScript := JavaScriptAnalysis{
+		TotalScripts:        len(scripts),
+		Scripts:             scripts,
+		Libraries:           jsLibraries,
+		VulnerableLibraries: u.VulnerableLibraries,
+		VulnerabilityCount:  u.VulnerabilityCount,
+		Severity:            u.VulnerabilitySeverity,
+	}
+	for _, script := range scripts {
+		if script.Inline {
+			javaScript.InlineScripts++
+			continue
+		}
+		javaScript.ExternalScripts++
+		if script.ThirdParty {
+			javaScript.ThirdPartyScripts++
+		}
+	}
+
 	return URLResponse{
 		ID:          u.ID,
+		WorkspaceID: u.WorkspaceID,
 		URL:         u.URL,
 		Title:       u.Title,
 		Description: u.Description,
@@ -213,6 +535,7 @@ func (u *URL) ToResponse() URLResponse {
 			MetaTitle:       u.MetaTitle,
 			MetaDescription: u.MetaDescription,
 			HTMLVersion:     u.HTMLVersion,
+			Noindex:         u.Noindex,
 			HeadingTags: HeadingTags{
 				H1Tags:  strings.Join(h1Tags, ", "),
 				H2Tags:  strings.Join(h2Tags, ", "),
@@ -235,19 +558,48 @@ func (u *URL) ToResponse() URLResponse {
 				BrokenLinksList: brokenLinksList,
 			},
 			FormAnalysis: FormAnalysis{
-				HasLoginForm: u.HasLoginForm,
-				FormCount:    u.FormCount,
+				HasLoginForm:      u.HasLoginForm,
+				FormCount:         u.FormCount,
+				HighSeverityForms: u.HighSeverityForms,
+				Forms:             forms,
+			},
+			Accessibility: AccessibilityAnalysis{
+				ViolationCount: u.AccessibilityViolationCount,
 			},
+			Mobile:     mobile,
 			ImageCount: u.ImageCount,
 		},
 		Performance: Performance{
-			LoadTime: u.LoadTime,
-			PageSize: u.PageSize,
+			LoadTime:           u.LoadTime,
+			PageSize:           u.PageSize,
+			TotalRequests:      u.TotalRequests,
+			ThirdPartyRequests: u.ThirdPartyRequests,
+			OverBudget:         u.OverBudget,
+			Budgets:            budgets,
 		},
+		Tags: tags,
+		FolderID: u.FolderID,
+		Technologies: technologies,
+		JavaScript:   javaScript,
+		Score:        score,
+
+		LastAnalysisID:     u.LastAnalysisID,
+		RuleViolationCount: u.RuleViolationCount,
+		Checks:             checks,
 		AnalyzedAt: u.AnalyzedAt,
 		CreatedAt:  u.CreatedAt,
 		UpdatedAt:  u.UpdatedAt,
+		DeletedAt:  u.trashedAt(),
+	}
+}
+
+// trashedAt returns when a soft-deleted URL was moved to the trash, or nil
+func (u *URL) trashedAt() *time.Time {
+	if !u.DeletedAt.Valid {
+		return nil
 	}
+	deletedAt := u.DeletedAt.Time
+	return &deletedAt
 }
 
 // URLDeleteResponse represents the response format for single URL deletion
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+)
+
+// User roles, from least to most privileged
+const (
+	RoleViewer  = "viewer"  // read-only access
+	RoleAnalyst = "analyst" // can also create, edit and analyze URLs and their rules
+	RoleAdmin   = "admin"   // can also bulk delete and manage schedules, integrations and workspace members; instance admins also manage users and API keys
+)
+
+// Roles lists the user roles from least to most privileged
+var Roles = []string{RoleViewer, RoleAnalyst, RoleAdmin}
+
+// RoleRank returns the privilege level of a role; unknown roles rank below viewer
+func RoleRank(role string) int {
+	for i, r := range Roles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// User is a person (or service) that calls the API with their own API keys,
+// an access token from the login endpoint or a token from the OIDC provider
+type User struct {
+	ID           uint    `json:"id" gorm:"primaryKey"`
+	Name         string  `json:"name" gorm:"size:200;not null"`
+	Email        string  `json:"email" gorm:"size:320;not null;uniqueIndex"`
+	Role         string  `json:"role" gorm:"size:20;not null;default:'viewer'"`
+	Active       bool    `json:"active" gorm:"not null"`                             // inactive users cannot authenticate
+	PasswordHash string  `json:"-" gorm:"size:100"`                                  // bcrypt hash; users without one cannot log in with a password
+	OIDCSubject  *string `json:"oidc_subject,omitempty" gorm:"size:255;uniqueIndex"` // sub claim of the linked OIDC identity
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the User model
+func (User) TableName() string {
+	return "users"
+}
+
+// HasRole reports whether the user's role is at least as privileged as role
+func (u *User) HasRole(role string) bool {
+	return RoleRank(u.Role) >= RoleRank(role) && RoleRank(role) > 0
+}
+
+// UserRequest represents the request payload for creating or replacing a user
+type UserRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Role     string `json:"role,omitempty"` // viewer, analyst or admin; defaults to viewer, or the current role on update
+	Active   *bool  `json:"active,omitempty"`
+	Password string `json:"password,omitempty"` // sets the login password; omit to keep the current one
+}
+
+// APIKey is a credential belonging to a user. Only a SHA-256 hash of the key is
+// stored; the prefix identifies the key in listings without revealing it.
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	User       *User      `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name       string     `json:"name" gorm:"size:200"`
+	Prefix     string     `json:"prefix" gorm:"size:20;index"`
+	Hash       string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for the APIKey model
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// Active reports whether the key can still be used to authenticate
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// APIKeyResponse represents the response format for an API key
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"` // only returned when the key is created
+	Active     bool       `json:"active"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ToResponse converts APIKey model to APIKeyResponse
+func (k *APIKey) ToResponse() APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		UserID:     k.UserID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Active:     k.Active(time.Now()),
+		LastUsedAt: k.LastUsedAt,
+		ExpiresAt:  k.ExpiresAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// APIKeyRequest represents the request payload for creating an API key
+type APIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	UserID    *uint      `json:"user_id,omitempty"` // defaults to the calling user
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
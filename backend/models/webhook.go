@@ -0,0 +1,162 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Webhook events
+const (
+	WebhookEventAnalysisCompleted = "analysis.completed"
+	WebhookEventAnalysisFailed    = "analysis.failed"
+	WebhookEventURLCreated        = "url.created"
+	WebhookEventURLDeleted        = "url.deleted"
+	WebhookEventImportCompleted   = "import.completed"
+	WebhookEventAlertTriggered    = "alert.triggered"
+	WebhookEventAll               = "*"
+)
+
+// WebhookEvents lists the events a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookEventAnalysisCompleted,
+	WebhookEventAnalysisFailed,
+	WebhookEventURLCreated,
+	WebhookEventURLDeleted,
+	WebhookEventImportCompleted,
+	WebhookEventAlertTriggered,
+}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an endpoint that receives signed event notifications
+type Webhook struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint   `json:"workspace_id" gorm:"not null;default:0;index"`
+	Name        string `json:"name" gorm:"size:200;not null"`
+	URL         string `json:"url" gorm:"size:2048;not null"`
+	Secret      string `json:"-" gorm:"size:200;not null"` // HMAC-SHA256 signing key
+	Events      string `json:"-" gorm:"type:text"`         // comma-separated event names, "*" for all
+	Enabled     bool   `json:"enabled" gorm:"not null"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Webhook model
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// GetEvents returns the events the webhook subscribes to
+func (w *Webhook) GetEvents() []string {
+	events := []string{}
+	for _, event := range strings.Split(w.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Subscribes reports whether the webhook receives an event
+func (w *Webhook) Subscribes(event string) bool {
+	for _, subscribed := range w.GetEvents() {
+		if subscribed == event || subscribed == WebhookEventAll {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookResponse represents the response format for a webhook
+type WebhookResponse struct {
+	ID          uint      `json:"id"`
+	WorkspaceID uint      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Enabled     bool      `json:"enabled"`
+	Secret      string    `json:"secret,omitempty"` // only returned when the secret is set
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToResponse converts Webhook model to WebhookResponse, without the secret
+func (w *Webhook) ToResponse() WebhookResponse {
+	return WebhookResponse{
+		ID:          w.ID,
+		WorkspaceID: w.WorkspaceID,
+		Name:        w.Name,
+		URL:         w.URL,
+		Events:      w.GetEvents(),
+		Enabled:     w.Enabled,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
+
+// WebhookRequest represents the request payload for creating or replacing a webhook.
+// A random secret is generated when none is given on creation, and kept when omitted on update.
+type WebhookRequest struct {
+	Name    string   `json:"name" binding:"required"`
+	URL     string   `json:"url" binding:"required,url"`
+	Secret  string   `json:"secret,omitempty"`
+	Events  []string `json:"events" binding:"required,min=1"`
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+// WebhookDelivery records one event sent to a webhook and its delivery attempts
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null;index"`
+	Webhook        *Webhook   `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	EventID        string     `json:"event_id" gorm:"size:64;index"` // shared by all deliveries of the same event
+	Event          string     `json:"event" gorm:"size:50;index"`
+	Payload        string     `json:"payload" gorm:"type:mediumtext"`
+	Status         string     `json:"status" gorm:"size:20;index"`
+	Attempts       int        `json:"attempts" gorm:"default:0"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `json:"response_body" gorm:"type:text"`
+	Error          string     `json:"error" gorm:"type:text"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	RedeliveryOf   *uint      `json:"redelivery_of"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookPayload is the JSON body sent to webhooks
+type WebhookPayload struct {
+	EventID   string      `json:"event_id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// AlertTriggeredPayload is the data of an alert.triggered event
+type AlertTriggeredPayload struct {
+	Alert Alert       `json:"alert"`
+	URL   URLResponse `json:"url"`
+}
+
+// ImportCompletedPayload is the data of an import.completed event
+type ImportCompletedPayload struct {
+	ImportedCount int           `json:"imported_count"`
+	ErrorCount    int           `json:"error_count"`
+	Errors        []string      `json:"errors"`
+	URLs          []URLResponse `json:"urls"`
+}
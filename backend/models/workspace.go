@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// Workspace isolates the URLs, rules, schedules and integrations of one client
+type Workspace struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name" gorm:"size:200;not null;uniqueIndex"`
+
+	// Analyses allowed per calendar month; nil uses the instance default and 0 is unlimited
+	MonthlyAnalysisQuota *int `json:"monthly_analysis_quota"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Workspace model
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceRequest represents the request payload for creating or renaming a
+// workspace. The quota is left unchanged when omitted and can only be set by
+// instance admins.
+type WorkspaceRequest struct {
+	Name                 string `json:"name" binding:"required"`
+	MonthlyAnalysisQuota *int   `json:"monthly_analysis_quota"`
+}
+
+// WorkspaceMember grants a user a role within a workspace
+type WorkspaceMember struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint       `json:"workspace_id" gorm:"not null;uniqueIndex:idx_workspace_members_workspace_user,priority:1"`
+	Workspace   *Workspace `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	UserID      uint       `json:"user_id" gorm:"not null;index;uniqueIndex:idx_workspace_members_workspace_user,priority:2"`
+	User        *User      `json:"user,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	Role        string     `json:"role" gorm:"size:20;not null"` // viewer, analyst or admin
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the WorkspaceMember model
+func (WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+// WorkspaceMemberRequest represents the request payload for adding a member or changing their role
+type WorkspaceMemberRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// WorkspaceResponse represents a workspace along with the caller's role in it
+type WorkspaceResponse struct {
+	Workspace
+	Role string `json:"role,omitempty"`
+}
+
+// AnalysisUsage counts the analyses a workspace started in a calendar month
+type AnalysisUsage struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint       `json:"workspace_id" gorm:"not null;uniqueIndex:idx_analysis_usage_workspace_period,priority:1"`
+	Workspace   *Workspace `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Period      string     `json:"period" gorm:"size:7;not null;uniqueIndex:idx_analysis_usage_workspace_period,priority:2"` // YYYY-MM
+	Analyses    int        `json:"analyses" gorm:"not null;default:0"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the AnalysisUsage model
+func (AnalysisUsage) TableName() string {
+	return "analysis_usage"
+}
+
+// AnalysisUsageResponse reports a workspace's analyses this month against its quota
+type AnalysisUsageResponse struct {
+	WorkspaceID uint      `json:"workspace_id"`
+	Period      string    `json:"period"`
+	Analyses    int       `json:"analyses"`
+	Quota       int       `json:"quota"`     // 0 is unlimited
+	Remaining   *int      `json:"remaining"` // nil when unlimited
+	ResetsAt    time.Time `json:"resets_at"`
+}
@@ -1,25 +1,74 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"website-analyzer-backend/config"
 	"website-analyzer-backend/controllers"
 	"website-analyzer-backend/middlewares"
+	"website-analyzer-backend/models"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Role requirements applied per protected route. Workspace roles are checked
+// in the workspace a request acts on; instance roles guard routes that are not
+// tied to a workspace. Every authenticated user is at least an instance viewer.
+var (
+	requireViewer  = middlewares.RequireRole(models.RoleViewer)
+	requireAnalyst = middlewares.RequireRole(models.RoleAnalyst)
+	requireAdmin   = middlewares.RequireRole(models.RoleAdmin)
+
+	requireInstanceViewer = middlewares.RequireInstanceRole(models.RoleViewer)
+	requireInstanceAdmin  = middlewares.RequireInstanceRole(models.RoleAdmin)
+
+	// For /workspaces/:id routes, which name the workspace in the path
+	requireWorkspaceViewer = middlewares.RequireWorkspaceRole("id", models.RoleViewer)
+	requireWorkspaceAdmin  = middlewares.RequireWorkspaceRole("id", models.RoleAdmin)
+)
+
+// Rate limits per client. Every API request counts against the standard limit;
+// operations that run analyses also count against the expensive one.
+var (
+	limitRequests  = middlewares.RateLimit(middlewares.RateLimitStandard)
+	limitExpensive = middlewares.RateLimit(middlewares.RateLimitExpensive)
+
+	// Failed authentication draws from the standard bucket of the client IP,
+	// so API keys and passwords cannot be guessed faster than that limit
+	limitFailedAuth = middlewares.RateLimitFailedAuth(middlewares.RateLimitStandard)
+)
+
 // SetupRouter configures and returns the main router
-func SetupRouter(cfg *config.Config) *gin.Engine {
+func SetupRouter(cfg *config.Config) (*gin.Engine, error) {
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
+	// Configure per-client rate limits
+	middlewares.ConfigureRateLimits(middlewares.RateLimitSettings{
+		Enabled: cfg.RateLimit.Enabled,
+		Standard: middlewares.RateLimitRule{
+			PerMinute: cfg.RateLimit.PerMinute,
+			Burst:     cfg.RateLimit.Burst,
+		},
+		Expensive: middlewares.RateLimitRule{
+			PerMinute: cfg.RateLimit.ExpensivePerMinute,
+			Burst:     cfg.RateLimit.ExpensiveBurst,
+		},
+	})
+
 	// Create router
 	router := gin.New()
 
+	// Only believe X-Forwarded-For from configured proxies; gin trusts every
+	// proxy otherwise, which lets clients pick the IP they are limited by
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	// Add global middlewares
+	router.Use(middlewares.RequestIDMiddleware())
 	router.Use(middlewares.LoggerMiddleware())
 	router.Use(middlewares.RecoveryMiddleware())
 	router.Use(middlewares.CORSMiddleware())
@@ -32,16 +81,32 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 	{
 		// Public routes (no auth required)
 		v1.GET("/health", HealthCheck)
+		setupAuthRoutes(v1)
 
 		// Protected routes (auth required)
 		protected := v1.Group("")
-		protected.Use(middlewares.AuthMiddleware(cfg))
+		protected.Use(limitFailedAuth, middlewares.AuthMiddleware(), limitRequests)
 		{
 			setupURLRoutes(protected)
+			setupTagRoutes(protected)
+			setupFolderRoutes(protected)
+			setupSavedViewRoutes(protected)
+			setupVulnerabilityRoutes(protected)
+			setupAuditRuleRoutes(protected)
+			setupBudgetRoutes(protected)
+			setupScheduleRoutes(protected)
+			setupAlertRoutes(protected)
+			setupWebhookRoutes(protected)
+			setupNotificationRoutes(protected)
+			setupUptimeRoutes(protected)
+			setupTrendRoutes(protected)
+			setupUserRoutes(protected)
+			setupWorkspaceRoutes(protected)
+			setupAuditLogRoutes(protected)
 		}
 	}
 
-	return router
+	return router, nil
 }
 
 // HealthCheck handles health check requests
@@ -60,20 +125,274 @@ func setupURLRoutes(rg *gin.RouterGroup) {
 
 	urls := rg.Group("/urls")
 	{
-		urls.POST("", urlController.CreateURL)           // POST /api/v1/urls
-		urls.GET("", urlController.GetAllURLs)           // GET /api/v1/urls
-		urls.GET("/:id", urlController.GetURL)           // GET /api/v1/urls/:id
-		urls.PUT("/:id", urlController.UpdateURL)        // PUT /api/v1/urls/:id
-		urls.DELETE("/:id", urlController.DeleteURL)     // DELETE /api/v1/urls/:id
-		urls.POST("/:id/analyze", urlController.AnalyzeURL) // POST /api/v1/urls/:id/analyze (synchronous)
-		urls.POST("/:id/analyze-async", urlController.AnalyzeURLAsync) // POST /api/v1/urls/:id/analyze-async (asynchronous)
+		urls.POST("", requireAnalyst, urlController.CreateURL)                                         // POST /api/v1/urls
+		urls.GET("", requireViewer, urlController.GetAllURLs)                                          // GET /api/v1/urls
+		urls.GET("/:id", requireViewer, urlController.GetURL)                                          // GET /api/v1/urls/:id
+		urls.GET("/:id/findings", requireViewer, urlController.GetURLFindings)                         // GET /api/v1/urls/:id/findings
+		urls.PUT("/:id", requireAnalyst, urlController.UpdateURL)                                      // PUT /api/v1/urls/:id
+		urls.DELETE("/:id", requireAnalyst, urlController.DeleteURL)                                   // DELETE /api/v1/urls/:id
+		urls.POST("/:id/analyze", requireAnalyst, limitExpensive, urlController.AnalyzeURL)            // POST /api/v1/urls/:id/analyze (synchronous)
+		urls.POST("/:id/analyze-async", requireAnalyst, limitExpensive, urlController.AnalyzeURLAsync) // POST /api/v1/urls/:id/analyze-async (asynchronous)
+		urls.POST("/:id/restore", requireAnalyst, urlController.RestoreURL)                            // POST /api/v1/urls/:id/restore
+
+		// Trash (deleted URLs that can still be restored)
+		trash := urls.Group("/trash")
+		{
+			trash.GET("", requireViewer, urlController.GetTrash)       // GET /api/v1/urls/trash
+			trash.DELETE("", requireAdmin, urlController.PurgeTrash)   // DELETE /api/v1/urls/trash
+			trash.DELETE("/:id", requireAdmin, urlController.PurgeURL) // DELETE /api/v1/urls/trash/:id
+		}
 
 		// Bulk operations
 		bulk := urls.Group("/bulk")
 		{
-			bulk.DELETE("", urlController.BulkDeleteURLs)     // DELETE /api/v1/urls/bulk
-			bulk.POST("/analyze", urlController.BulkAnalyzeURLs) // POST /api/v1/urls/bulk/analyze
-			bulk.POST("/import", urlController.BulkImportURLs)   // POST /api/v1/urls/bulk/import
+			bulk.DELETE("", requireAdmin, urlController.BulkDeleteURLs)                          // DELETE /api/v1/urls/bulk
+			bulk.POST("/analyze", requireAnalyst, limitExpensive, urlController.BulkAnalyzeURLs) // POST /api/v1/urls/bulk/analyze
+			bulk.POST("/import", requireAnalyst, limitExpensive, urlController.BulkImportURLs)   // POST /api/v1/urls/bulk/import
+			bulk.POST("/restore", requireAnalyst, urlController.BulkRestoreURLs)                 // POST /api/v1/urls/bulk/restore
+			bulk.POST("/tag", requireAnalyst, urlController.BulkTagURLs)                         // POST /api/v1/urls/bulk/tag
+			bulk.POST("/untag", requireAnalyst, urlController.BulkUntagURLs)                     // POST /api/v1/urls/bulk/untag
+			bulk.POST("/move", requireAnalyst, urlController.BulkMoveURLs)                       // POST /api/v1/urls/bulk/move
 		}
 	}
+
+	rg.GET("/checks", requireInstanceViewer, urlController.GetChecks) // GET /api/v1/checks
+}
+
+// setupTagRoutes configures tag routes
+func setupTagRoutes(rg *gin.RouterGroup) {
+	tagController := controllers.NewTagController()
+
+	tags := rg.Group("/tags")
+	{
+		tags.GET("", requireViewer, tagController.GetAllTags)        // GET /api/v1/tags
+		tags.POST("", requireAnalyst, tagController.CreateTag)       // POST /api/v1/tags
+		tags.GET("/:id", requireViewer, tagController.GetTag)        // GET /api/v1/tags/:id
+		tags.PUT("/:id", requireAnalyst, tagController.UpdateTag)    // PUT /api/v1/tags/:id
+		tags.DELETE("/:id", requireAnalyst, tagController.DeleteTag) // DELETE /api/v1/tags/:id
+	}
+}
+
+// setupFolderRoutes configures folder routes
+func setupFolderRoutes(rg *gin.RouterGroup) {
+	folderController := controllers.NewFolderController()
+
+	folders := rg.Group("/folders")
+	{
+		folders.GET("", requireViewer, folderController.GetAllFolders)        // GET /api/v1/folders
+		folders.POST("", requireAnalyst, folderController.CreateFolder)       // POST /api/v1/folders
+		folders.GET("/:id", requireViewer, folderController.GetFolder)        // GET /api/v1/folders/:id
+		folders.PUT("/:id", requireAnalyst, folderController.UpdateFolder)    // PUT /api/v1/folders/:id
+		folders.DELETE("/:id", requireAnalyst, folderController.DeleteFolder) // DELETE /api/v1/folders/:id
+	}
+}
+
+// setupSavedViewRoutes configures saved view routes. Views are private to the
+// user who saved them, so viewers may manage their own.
+func setupSavedViewRoutes(rg *gin.RouterGroup) {
+	savedViewController := controllers.NewSavedViewController()
+
+	views := rg.Group("/views")
+	{
+		views.GET("", requireViewer, savedViewController.GetAllViews)       // GET /api/v1/views
+		views.POST("", requireViewer, savedViewController.CreateView)       // POST /api/v1/views
+		views.GET("/:id", requireViewer, savedViewController.GetView)       // GET /api/v1/views/:id
+		views.PUT("/:id", requireViewer, savedViewController.UpdateView)    // PUT /api/v1/views/:id
+		views.DELETE("/:id", requireViewer, savedViewController.DeleteView) // DELETE /api/v1/views/:id
+	}
+}
+
+// setupVulnerabilityRoutes configures JavaScript library vulnerability feed routes
+func setupVulnerabilityRoutes(rg *gin.RouterGroup) {
+	vulnerabilityController := controllers.NewVulnerabilityController()
+
+	feed := rg.Group("/vulnerability-feed")
+	{
+		feed.GET("", requireInstanceViewer, vulnerabilityController.GetFeed)    // GET /api/v1/vulnerability-feed
+		feed.POST("", requireInstanceAdmin, vulnerabilityController.ImportFeed) // POST /api/v1/vulnerability-feed
+	}
+}
+
+// setupAuditRuleRoutes configures user-defined audit rule routes
+func setupAuditRuleRoutes(rg *gin.RouterGroup) {
+	ruleController := controllers.NewAuditRuleController()
+
+	rules := rg.Group("/rules")
+	{
+		rules.GET("", requireViewer, ruleController.GetAllRules)          // GET /api/v1/rules
+		rules.POST("", requireAnalyst, ruleController.CreateRule)         // POST /api/v1/rules
+		rules.POST("/import", requireAnalyst, ruleController.ImportRules) // POST /api/v1/rules/import
+		rules.GET("/:id", requireViewer, ruleController.GetRule)          // GET /api/v1/rules/:id
+		rules.PUT("/:id", requireAnalyst, ruleController.UpdateRule)      // PUT /api/v1/rules/:id
+		rules.DELETE("/:id", requireAnalyst, ruleController.DeleteRule)   // DELETE /api/v1/rules/:id
+	}
+
+	rg.GET("/rule-violations", requireViewer, ruleController.GetViolations) // GET /api/v1/rule-violations
+}
+
+// setupBudgetRoutes configures performance budget routes
+func setupBudgetRoutes(rg *gin.RouterGroup) {
+	budgetController := controllers.NewBudgetController()
+
+	budgets := rg.Group("/budgets")
+	{
+		budgets.GET("", requireViewer, budgetController.GetAllBudgets)        // GET /api/v1/budgets
+		budgets.POST("", requireAnalyst, budgetController.CreateBudget)       // POST /api/v1/budgets
+		budgets.GET("/:id", requireViewer, budgetController.GetBudget)        // GET /api/v1/budgets/:id
+		budgets.PUT("/:id", requireAnalyst, budgetController.UpdateBudget)    // PUT /api/v1/budgets/:id
+		budgets.DELETE("/:id", requireAnalyst, budgetController.DeleteBudget) // DELETE /api/v1/budgets/:id
+	}
+}
+
+// setupScheduleRoutes configures recurring analysis schedule routes
+func setupScheduleRoutes(rg *gin.RouterGroup) {
+	scheduleController := controllers.NewScheduleController()
+
+	schedules := rg.Group("/schedules")
+	{
+		schedules.GET("", requireViewer, scheduleController.GetAllSchedules)      // GET /api/v1/schedules
+		schedules.POST("", requireAdmin, scheduleController.CreateSchedule)       // POST /api/v1/schedules
+		schedules.GET("/:id", requireViewer, scheduleController.GetSchedule)      // GET /api/v1/schedules/:id
+		schedules.PUT("/:id", requireAdmin, scheduleController.UpdateSchedule)    // PUT /api/v1/schedules/:id
+		schedules.DELETE("/:id", requireAdmin, scheduleController.DeleteSchedule) // DELETE /api/v1/schedules/:id
+	}
+}
+
+// setupAlertRoutes configures change detection alert rule and alert routes
+func setupAlertRoutes(rg *gin.RouterGroup) {
+	alertController := controllers.NewAlertController()
+
+	alertRules := rg.Group("/alert-rules")
+	{
+		alertRules.GET("", requireViewer, alertController.GetAllRules)        // GET /api/v1/alert-rules
+		alertRules.POST("", requireAnalyst, alertController.CreateRule)       // POST /api/v1/alert-rules
+		alertRules.GET("/:id", requireViewer, alertController.GetRule)        // GET /api/v1/alert-rules/:id
+		alertRules.PUT("/:id", requireAnalyst, alertController.UpdateRule)    // PUT /api/v1/alert-rules/:id
+		alertRules.DELETE("/:id", requireAnalyst, alertController.DeleteRule) // DELETE /api/v1/alert-rules/:id
+	}
+
+	alerts := rg.Group("/alerts")
+	{
+		alerts.GET("", requireViewer, alertController.GetAlerts)                          // GET /api/v1/alerts
+		alerts.GET("/:id", requireViewer, alertController.GetAlert)                       // GET /api/v1/alerts/:id
+		alerts.POST("/:id/acknowledge", requireAnalyst, alertController.AcknowledgeAlert) // POST /api/v1/alerts/:id/acknowledge
+		alerts.POST("/:id/resolve", requireAnalyst, alertController.ResolveAlert)         // POST /api/v1/alerts/:id/resolve
+	}
+}
+
+// setupWebhookRoutes configures outgoing webhook and delivery log routes
+func setupWebhookRoutes(rg *gin.RouterGroup) {
+	webhookController := controllers.NewWebhookController()
+
+	webhooks := rg.Group("/webhooks")
+	{
+		webhooks.GET("", requireViewer, webhookController.GetAllWebhooks)      // GET /api/v1/webhooks
+		webhooks.POST("", requireAdmin, webhookController.CreateWebhook)       // POST /api/v1/webhooks
+		webhooks.GET("/:id", requireViewer, webhookController.GetWebhook)      // GET /api/v1/webhooks/:id
+		webhooks.PUT("/:id", requireAdmin, webhookController.UpdateWebhook)    // PUT /api/v1/webhooks/:id
+		webhooks.DELETE("/:id", requireAdmin, webhookController.DeleteWebhook) // DELETE /api/v1/webhooks/:id
+
+		webhooks.GET("/:id/deliveries", requireViewer, webhookController.GetDeliveries)                           // GET /api/v1/webhooks/:id/deliveries
+		webhooks.GET("/:id/deliveries/:deliveryId", requireViewer, webhookController.GetDelivery)                 // GET /api/v1/webhooks/:id/deliveries/:deliveryId
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", requireAdmin, webhookController.RedeliverDelivery) // POST /api/v1/webhooks/:id/deliveries/:deliveryId/redeliver
+	}
+}
+
+// setupNotificationRoutes configures email notification preference routes
+func setupNotificationRoutes(rg *gin.RouterGroup) {
+	notificationController := controllers.NewNotificationController()
+
+	preferences := rg.Group("/notification-preferences")
+	{
+		preferences.GET("", requireViewer, notificationController.GetAllPreferences)      // GET /api/v1/notification-preferences
+		preferences.POST("", requireAdmin, notificationController.CreatePreference)       // POST /api/v1/notification-preferences
+		preferences.GET("/:id", requireViewer, notificationController.GetPreference)      // GET /api/v1/notification-preferences/:id
+		preferences.PUT("/:id", requireAdmin, notificationController.UpdatePreference)    // PUT /api/v1/notification-preferences/:id
+		preferences.DELETE("/:id", requireAdmin, notificationController.DeletePreference) // DELETE /api/v1/notification-preferences/:id
+		preferences.POST("/:id/test", requireAdmin, notificationController.SendTestEmail) // POST /api/v1/notification-preferences/:id/test
+		preferences.POST("/:id/digest", requireAdmin, notificationController.SendDigest)  // POST /api/v1/notification-preferences/:id/digest
+	}
+
+	// Every user manages their own preferences in the workspace they act on
+	rg.GET("/me/notification-preferences", requireViewer, notificationController.GetOwnPreference)    // GET /api/v1/me/notification-preferences
+	rg.PUT("/me/notification-preferences", requireViewer, notificationController.UpdateOwnPreference) // PUT /api/v1/me/notification-preferences
+}
+
+// setupUptimeRoutes configures uptime monitoring report routes
+func setupUptimeRoutes(rg *gin.RouterGroup) {
+	uptimeController := controllers.NewUptimeController()
+
+	rg.GET("/urls/:id/uptime", requireViewer, uptimeController.GetUptime) // GET /api/v1/urls/:id/uptime
+}
+
+// setupTrendRoutes configures historical metric trend routes
+func setupTrendRoutes(rg *gin.RouterGroup) {
+	trendController := controllers.NewTrendController()
+
+	rg.GET("/urls/:id/trends", requireViewer, trendController.GetURLTrends) // GET /api/v1/urls/:id/trends
+	rg.GET("/trends", requireViewer, trendController.GetPortfolioTrends)    // GET /api/v1/trends
+}
+
+// setupAuthRoutes configures public password login and token routes
+func setupAuthRoutes(rg *gin.RouterGroup) {
+	authController := controllers.NewAuthController()
+
+	auth := rg.Group("/auth", limitRequests)
+	{
+		auth.POST("/login", authController.Login)        // POST /api/v1/auth/login
+		auth.POST("/refresh", authController.Refresh)    // POST /api/v1/auth/refresh
+		auth.POST("/logout", authController.Logout)      // POST /api/v1/auth/logout
+		auth.GET("/jwks.json", authController.GetKeySet) // GET /api/v1/auth/jwks.json
+	}
+}
+
+// setupUserRoutes configures user and API key routes
+func setupUserRoutes(rg *gin.RouterGroup) {
+	userController := controllers.NewUserController()
+
+	rg.GET("/me", requireInstanceViewer, userController.GetCurrentUser)          // GET /api/v1/me
+	rg.PUT("/me/password", requireInstanceViewer, userController.ChangePassword) // PUT /api/v1/me/password
+
+	users := rg.Group("/users")
+	{
+		users.GET("", requireInstanceAdmin, userController.GetAllUsers)       // GET /api/v1/users
+		users.POST("", requireInstanceAdmin, userController.CreateUser)       // POST /api/v1/users
+		users.GET("/:id", requireInstanceAdmin, userController.GetUser)       // GET /api/v1/users/:id
+		users.PUT("/:id", requireInstanceAdmin, userController.UpdateUser)    // PUT /api/v1/users/:id
+		users.DELETE("/:id", requireInstanceAdmin, userController.DeleteUser) // DELETE /api/v1/users/:id
+	}
+
+	apiKeys := rg.Group("/api-keys")
+	{
+		apiKeys.GET("", requireInstanceAdmin, userController.GetAPIKeys)               // GET /api/v1/api-keys
+		apiKeys.POST("", requireInstanceAdmin, userController.CreateAPIKey)            // POST /api/v1/api-keys
+		apiKeys.GET("/:id", requireInstanceAdmin, userController.GetAPIKey)            // GET /api/v1/api-keys/:id
+		apiKeys.POST("/:id/revoke", requireInstanceAdmin, userController.RevokeAPIKey) // POST /api/v1/api-keys/:id/revoke
+	}
+}
+
+// setupWorkspaceRoutes configures workspace and workspace member routes
+func setupWorkspaceRoutes(rg *gin.RouterGroup) {
+	workspaceController := controllers.NewWorkspaceController()
+
+	workspaces := rg.Group("/workspaces")
+	{
+		workspaces.GET("", requireInstanceViewer, workspaceController.GetAllWorkspaces)                             // GET /api/v1/workspaces
+		workspaces.POST("", requireInstanceAdmin, workspaceController.CreateWorkspace)                              // POST /api/v1/workspaces
+		workspaces.GET("/:id", requireWorkspaceViewer, workspaceController.GetWorkspace)                            // GET /api/v1/workspaces/:id
+		workspaces.PUT("/:id", requireWorkspaceAdmin, workspaceController.UpdateWorkspace)                          // PUT /api/v1/workspaces/:id
+		workspaces.DELETE("/:id", requireInstanceAdmin, requireWorkspaceAdmin, workspaceController.DeleteWorkspace) // DELETE /api/v1/workspaces/:id
+
+		workspaces.GET("/:id/usage", requireWorkspaceViewer, workspaceController.GetUsage)                 // GET /api/v1/workspaces/:id/usage
+		workspaces.GET("/:id/members", requireWorkspaceViewer, workspaceController.GetMembers)             // GET /api/v1/workspaces/:id/members
+		workspaces.PUT("/:id/members/:userId", requireWorkspaceAdmin, workspaceController.SetMember)       // PUT /api/v1/workspaces/:id/members/:userId
+		workspaces.DELETE("/:id/members/:userId", requireWorkspaceAdmin, workspaceController.RemoveMember) // DELETE /api/v1/workspaces/:id/members/:userId
+	}
+}
+
+// setupAuditLogRoutes configures audit log routes
+func setupAuditLogRoutes(rg *gin.RouterGroup) {
+	auditLogController := controllers.NewAuditLogController()
+
+	rg.GET("/audit-log", requireInstanceAdmin, auditLogController.GetAuditLog) // GET /api/v1/audit-log
 }
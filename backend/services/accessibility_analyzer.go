@@ -0,0 +1,396 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"website-analyzer-backend/models"
+)
+
+// maxViolationsPerRule caps how many instances of a single rule are reported per page
+const maxViolationsPerRule = 50
+
+// accessibilityRule describes an automated WCAG check
+type accessibilityRule struct {
+	id          string
+	wcag        string
+	level       string
+	severity    string
+	description string
+}
+
+var (
+	ruleHTMLLang = accessibilityRule{"html-has-lang", "3.1.1", "A", "serious",
+		"<html> element must have a non-empty lang attribute"}
+	ruleImageAlt = accessibilityRule{"image-alt", "1.1.1", "A", "critical",
+		"Images must have alternate text (use alt=\"\" for decorative images)"}
+	ruleInputLabel = accessibilityRule{"label", "4.1.2", "A", "critical",
+		"Form fields must have an associated label"}
+	ruleButtonName = accessibilityRule{"button-name", "4.1.2", "A", "critical",
+		"Buttons must have an accessible name"}
+	ruleLinkName = accessibilityRule{"link-name", "2.4.4", "A", "serious",
+		"Links must have an accessible name"}
+	ruleDuplicateID = accessibilityRule{"duplicate-id", "4.1.1", "A", "minor",
+		"id attribute values must be unique"}
+	ruleARIARole = accessibilityRule{"aria-allowed-role", "4.1.2", "A", "critical",
+		"role attribute must contain a valid ARIA role"}
+	ruleARIAAttr = accessibilityRule{"aria-valid-attr", "4.1.2", "A", "critical",
+		"aria-* attributes must be valid ARIA attributes"}
+	ruleLandmarkMain = accessibilityRule{"landmark-one-main", "1.3.1", "A", "moderate",
+		"Page must contain a main landmark"}
+	ruleTabindex = accessibilityRule{"tabindex", "2.4.3", "A", "serious",
+		"Elements should not have a tabindex greater than zero"}
+	ruleAutoplay = accessibilityRule{"no-autoplay-audio", "1.4.2", "A", "serious",
+		"Media must not play audio automatically"}
+)
+
+// validARIARoles lists the roles defined by WAI-ARIA 1.2
+var validARIARoles = toSet(`alert alertdialog application article banner blockquote button caption cell
+	checkbox code columnheader combobox complementary contentinfo definition deletion dialog directory
+	document emphasis feed figure form generic grid gridcell group heading img insertion link list
+	listbox listitem log main marquee math menu menubar menuitem menuitemcheckbox menuitemradio meter
+	navigation none note option paragraph presentation progressbar radio radiogroup region row
+	rowgroup rowheader scrollbar search searchbox separator slider spinbutton status strong subscript
+	superscript switch tab table tablist tabpanel term textbox time timer toolbar tooltip tree
+	treegrid treeitem doc-abstract doc-acknowledgments doc-afterword doc-appendix doc-backlink
+	doc-biblioentry doc-bibliography doc-biblioref doc-chapter doc-colophon doc-conclusion doc-cover
+	doc-credit doc-credits doc-dedication doc-endnote doc-endnotes doc-epigraph doc-epilogue
+	doc-errata doc-example doc-footnote doc-foreword doc-glossary doc-glossref doc-index
+	doc-introduction doc-noteref doc-notice doc-pagebreak doc-pagelist doc-part doc-preface
+	doc-prologue doc-pullquote doc-qna doc-subtitle doc-tip doc-toc graphics-document
+	graphics-object graphics-symbol`)
+
+// validARIAAttributes lists the states and properties defined by WAI-ARIA 1.2
+var validARIAAttributes = toSet(`aria-activedescendant aria-atomic aria-autocomplete aria-braillelabel
+	aria-brailleroledescription aria-busy aria-checked aria-colcount aria-colindex aria-colindextext
+	aria-colspan aria-controls aria-current aria-describedby aria-description aria-details
+	aria-disabled aria-dropeffect aria-errormessage aria-expanded aria-flowto aria-grabbed
+	aria-haspopup aria-hidden aria-invalid aria-keyshortcuts aria-label aria-labelledby aria-level
+	aria-live aria-modal aria-multiline aria-multiselectable aria-orientation aria-owns
+	aria-placeholder aria-posinset aria-pressed aria-readonly aria-relevant aria-required
+	aria-roledescription aria-rowcount aria-rowindex aria-rowindextext aria-rowspan aria-selected
+	aria-setsize aria-sort aria-valuemax aria-valuemin aria-valuenow aria-valuetext`)
+
+// toSet builds a lookup set from a whitespace separated list
+func toSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range strings.Fields(list) {
+		set[item] = true
+	}
+	return set
+}
+
+// accessibilityReport collects violations while enforcing the per-rule cap
+type accessibilityReport struct {
+	result *SEOAnalysisResult
+	counts map[string]int
+}
+
+// add records a violation of rule for the given element
+func (r *accessibilityReport) add(rule accessibilityRule, sel *goquery.Selection, message string) {
+	r.counts[rule.id]++
+	r.result.AccessibilityViolationCount++
+	if r.counts[rule.id] > maxViolationsPerRule {
+		return
+	}
+
+	if message == "" {
+		message = rule.description
+	}
+
+	violation := models.AccessibilityViolation{
+		Rule:     rule.id,
+		WCAG:     rule.wcag,
+		Level:    rule.level,
+		Severity: rule.severity,
+		Message:  message,
+	}
+	if sel != nil {
+		violation.Selector = cssSelectorPath(sel)
+		violation.HTML = outerHTMLSnippet(sel)
+	}
+
+	r.result.AccessibilityViolations = append(r.result.AccessibilityViolations, violation)
+}
+
+// analyzeAccessibility runs automated WCAG checks against the document
+func (s *SEOAnalyzer) analyzeAccessibility(doc *goquery.Document, result *SEOAnalysisResult) {
+	report := &accessibilityReport{result: result, counts: make(map[string]int)}
+
+	// Page language
+	htmlElement := doc.Find("html").First()
+	if lang, _ := htmlElement.Attr("lang"); strings.TrimSpace(lang) == "" {
+		report.add(ruleHTMLLang, htmlElement, "")
+	}
+
+	// Images without alternate text
+	doc.Find("img, input[type='image']").Each(func(i int, sel *goquery.Selection) {
+		if _, hasAlt := sel.Attr("alt"); hasAlt {
+			return
+		}
+		if role, _ := sel.Attr("role"); role == "presentation" || role == "none" {
+			return
+		}
+		if hasARIALabel(doc, sel) {
+			return
+		}
+		report.add(ruleImageAlt, sel, "")
+	})
+
+	// Form fields without labels
+	doc.Find("input, select, textarea").Each(func(i int, sel *goquery.Selection) {
+		if goquery.NodeName(sel) == "input" {
+			switch inputType(sel) {
+			case "hidden", "submit", "button", "reset", "image":
+				return
+			}
+		}
+		if hasLabel(doc, sel) {
+			return
+		}
+		report.add(ruleInputLabel, sel, "")
+	})
+
+	// Buttons without an accessible name
+	doc.Find("button, [role='button'], input[type='submit'], input[type='button'], input[type='reset']").Each(func(i int, sel *goquery.Selection) {
+		if goquery.NodeName(sel) == "input" {
+			// Submit and reset inputs have a default accessible name
+			if strings.TrimSpace(sel.AttrOr("value", "")) != "" || inputType(sel) == "submit" || inputType(sel) == "reset" {
+				return
+			}
+		}
+		if hasAccessibleName(doc, sel) {
+			return
+		}
+		report.add(ruleButtonName, sel, "")
+	})
+
+	// Links without an accessible name
+	doc.Find("a[href]").Each(func(i int, sel *goquery.Selection) {
+		if role, _ := sel.Attr("role"); role == "button" {
+			return // reported as a button
+		}
+		if hasAccessibleName(doc, sel) {
+			return
+		}
+		report.add(ruleLinkName, sel, "")
+	})
+
+	// Duplicate ids
+	seenIDs := make(map[string]bool)
+	reportedIDs := make(map[string]bool)
+	doc.Find("[id]").Each(func(i int, sel *goquery.Selection) {
+		id := strings.TrimSpace(sel.AttrOr("id", ""))
+		if id == "" {
+			return
+		}
+		if seenIDs[id] && !reportedIDs[id] {
+			reportedIDs[id] = true
+			report.add(ruleDuplicateID, sel, fmt.Sprintf("Duplicate id attribute value %q", id))
+		}
+		seenIDs[id] = true
+	})
+
+	// ARIA roles and attributes
+	doc.Find("*").Each(func(i int, sel *goquery.Selection) {
+		if role, exists := sel.Attr("role"); exists {
+			for _, value := range strings.Fields(strings.ToLower(role)) {
+				if !validARIARoles[value] {
+					report.add(ruleARIARole, sel, fmt.Sprintf("Invalid ARIA role %q", value))
+					break
+				}
+			}
+		}
+
+		for _, attr := range sel.Nodes[0].Attr {
+			name := strings.ToLower(attr.Key)
+			if strings.HasPrefix(name, "aria-") && !validARIAAttributes[name] {
+				report.add(ruleARIAAttr, sel, fmt.Sprintf("Invalid ARIA attribute %q", name))
+			}
+		}
+
+		// Positive tabindex disrupts the natural focus order
+		if tabindex, exists := sel.Attr("tabindex"); exists {
+			if value, err := strconv.Atoi(strings.TrimSpace(tabindex)); err == nil && value > 0 {
+				report.add(ruleTabindex, sel, fmt.Sprintf("tabindex=%d changes the natural focus order", value))
+			}
+		}
+	})
+
+	// Landmarks
+	if doc.Find("main, [role='main']").Length() == 0 {
+		report.add(ruleLandmarkMain, nil, "")
+	}
+
+	// Auto-playing media with audio
+	doc.Find("audio[autoplay], video[autoplay]").Each(func(i int, sel *goquery.Selection) {
+		if _, muted := sel.Attr("muted"); muted {
+			return
+		}
+		report.add(ruleAutoplay, sel, "")
+	})
+}
+
+// inputType returns the lower-cased type attribute of an input
+func inputType(sel *goquery.Selection) string {
+	return strings.ToLower(strings.TrimSpace(sel.AttrOr("type", "text")))
+}
+
+// hasARIALabel reports whether the element is named via aria-label or a resolvable aria-labelledby
+func hasARIALabel(doc *goquery.Document, sel *goquery.Selection) bool {
+	if strings.TrimSpace(sel.AttrOr("aria-label", "")) != "" {
+		return true
+	}
+	if labelledBy := strings.TrimSpace(sel.AttrOr("aria-labelledby", "")); labelledBy != "" {
+		for _, id := range strings.Fields(labelledBy) {
+			if strings.TrimSpace(doc.Find("#"+escapeCSSIdentifier(id)).Text()) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasLabel reports whether a form field has an associated label
+func hasLabel(doc *goquery.Document, sel *goquery.Selection) bool {
+	if hasARIALabel(doc, sel) {
+		return true
+	}
+	if strings.TrimSpace(sel.AttrOr("title", "")) != "" {
+		return true
+	}
+	if sel.ParentsFiltered("label").Length() > 0 {
+		return true
+	}
+	if id := strings.TrimSpace(sel.AttrOr("id", "")); id != "" {
+		found := false
+		doc.Find("label[for]").EachWithBreak(func(i int, label *goquery.Selection) bool {
+			if label.AttrOr("for", "") == id {
+				found = true
+				return false
+			}
+			return true
+		})
+		return found
+	}
+	return false
+}
+
+// hasAccessibleName reports whether a button or link exposes a name to assistive technology
+func hasAccessibleName(doc *goquery.Document, sel *goquery.Selection) bool {
+	if hasARIALabel(doc, sel) {
+		return true
+	}
+	if strings.TrimSpace(sel.Text()) != "" {
+		return true
+	}
+	if strings.TrimSpace(sel.AttrOr("title", "")) != "" {
+		return true
+	}
+
+	named := false
+	sel.Find("img[alt], svg[aria-label], [aria-label]").EachWithBreak(func(i int, child *goquery.Selection) bool {
+		if strings.TrimSpace(child.AttrOr("alt", "")) != "" || strings.TrimSpace(child.AttrOr("aria-label", "")) != "" {
+			named = true
+			return false
+		}
+		return true
+	})
+	if named {
+		return true
+	}
+
+	return sel.Find("svg title").Length() > 0 && strings.TrimSpace(sel.Find("svg title").Text()) != ""
+}
+
+// cssSelectorPath builds a CSS selector that uniquely locates the element in the document
+func cssSelectorPath(sel *goquery.Selection) string {
+	var parts []string
+	root := sel.First().Closest("html")
+
+	for current := sel.First(); current.Length() > 0; current = current.Parent() {
+		if current.Get(0).Type != html.ElementNode {
+			break
+		}
+
+		name := goquery.NodeName(current)
+		// A unique id anchors the rest of the path
+		if id := strings.TrimSpace(current.AttrOr("id", "")); id != "" && !strings.ContainsAny(id, " \t\n") {
+			if idSelector := "#" + escapeCSSIdentifier(id); root.Find(idSelector).Length() == 1 {
+				parts = append(parts, name+idSelector)
+				break
+			}
+		}
+
+		if name == "html" || name == "body" || name == "head" {
+			parts = append(parts, name)
+			continue
+		}
+
+		// Position among siblings of the same tag
+		position := 1
+		total := 1
+		current.PrevAll().Each(func(i int, sibling *goquery.Selection) {
+			if goquery.NodeName(sibling) == name {
+				position++
+				total++
+			}
+		})
+		current.NextAll().Each(func(i int, sibling *goquery.Selection) {
+			if goquery.NodeName(sibling) == name {
+				total++
+			}
+		})
+
+		if total > 1 {
+			parts = append(parts, fmt.Sprintf("%s:nth-of-type(%d)", name, position))
+		} else {
+			parts = append(parts, name)
+		}
+	}
+
+	// Reverse into document order
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, " > ")
+}
+
+// escapeCSSIdentifier escapes characters that are not valid in a CSS identifier
+func escapeCSSIdentifier(value string) string {
+	var builder strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == '-', r > 0x7f:
+			builder.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				fmt.Fprintf(&builder, "\\%x ", r)
+			} else {
+				builder.WriteRune(r)
+			}
+		default:
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// outerHTMLSnippet returns the element's opening tag, truncated for reporting
+func outerHTMLSnippet(sel *goquery.Selection) string {
+	markup, err := goquery.OuterHtml(sel.First())
+	if err != nil {
+		return ""
+	}
+	if end := strings.Index(markup, ">"); end != -1 {
+		markup = markup[:end+1]
+	}
+	if len(markup) > 200 {
+		markup = markup[:200] + "..."
+	}
+	return markup
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"website-analyzer-backend/models"
+)
+
+// maxAlertLinks caps how many new broken links are listed in an alert message
+const maxAlertLinks = 5
+
+// alertSnapshot holds the analysis values alert conditions compare
+type alertSnapshot struct {
+	StatusCode              int
+	Title                   string
+	Description             string
+	BrokenLinks             []string
+	H1Count                 int
+	LoadTime                float64
+	HasLoginForm            bool
+	Noindex                 bool
+	Score                   int
+	AccessibilityViolations int
+}
+
+// fetched reports whether the page was retrieved successfully, so its content can be compared
+func (s *alertSnapshot) fetched() bool {
+	return s.StatusCode >= 200 && s.StatusCode < 400
+}
+
+// alertSnapshotFromURL captures the stored results of a URL's latest analysis,
+// or returns nil if the URL has never been analyzed
+func alertSnapshotFromURL(url *models.URL) *alertSnapshot {
+	if url.AnalyzedAt == nil {
+		return nil
+	}
+
+	var brokenLinks []models.BrokenLinkInfo
+	if url.BrokenLinksList != "" {
+		json.Unmarshal([]byte(url.BrokenLinksList), &brokenLinks)
+	}
+	links := make([]string, 0, len(brokenLinks))
+	for _, link := range brokenLinks {
+		links = append(links, link.URL)
+	}
+
+	return &alertSnapshot{
+		StatusCode:              url.StatusCode,
+		Title:                   url.MetaTitle,
+		Description:             url.MetaDescription,
+		BrokenLinks:             links,
+		H1Count:                 url.H1Count,
+		LoadTime:                url.LoadTime,
+		HasLoginForm:            url.HasLoginForm,
+		Noindex:                 url.Noindex,
+		Score:                   url.SEOScore,
+		AccessibilityViolations: url.AccessibilityViolationCount,
+	}
+}
+
+// alertSnapshotFromResult captures the values of a fresh analysis result
+func alertSnapshotFromResult(result *SEOAnalysisResult) *alertSnapshot {
+	links := make([]string, 0, len(result.BrokenLinks))
+	for _, link := range result.BrokenLinks {
+		links = append(links, link.URL)
+	}
+
+	return &alertSnapshot{
+		StatusCode:              result.StatusCode,
+		Title:                   result.MetaTitle,
+		Description:             result.MetaDescription,
+		BrokenLinks:             links,
+		H1Count:                 result.H1Count,
+		LoadTime:                result.LoadTime,
+		HasLoginForm:            result.HasLoginForm,
+		Noindex:                 result.Noindex,
+		Score:                   result.Score.Score,
+		AccessibilityViolations: result.AccessibilityViolationCount,
+	}
+}
+
+// alertEvent describes why an alert rule fired
+type alertEvent struct {
+	Message       string
+	PreviousValue string
+	CurrentValue  string
+}
+
+// alertCondition compares two consecutive analyses of a URL
+type alertCondition struct {
+	// threshold describes the rule threshold, empty if the condition has none
+	threshold string
+	// thresholdRequired rejects rules without a positive threshold
+	thresholdRequired bool
+	// content conditions compare page content and only run when both analyses fetched the page
+	content bool
+	// evaluate returns an event if the condition fired, or nil
+	evaluate func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent
+	// recovered reports whether an active alert can be resolved automatically; nil if it never can
+	recovered func(current *alertSnapshot) bool
+}
+
+// alertConditions maps condition names to their implementation
+var alertConditions = map[string]alertCondition{
+	models.AlertConditionStatusCodeChanged: {
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if previous.StatusCode == current.StatusCode {
+				return nil
+			}
+			return &alertEvent{
+				Message:       fmt.Sprintf("Status code changed from %s to %s", statusCodeText(previous.StatusCode), statusCodeText(current.StatusCode)),
+				PreviousValue: strconv.Itoa(previous.StatusCode),
+				CurrentValue:  strconv.Itoa(current.StatusCode),
+			}
+		},
+	},
+	models.AlertConditionTitleChanged: {
+		content: true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if previous.Title == current.Title {
+				return nil
+			}
+			return &alertEvent{
+				Message:       fmt.Sprintf("Title changed from %q to %q", previous.Title, current.Title),
+				PreviousValue: previous.Title,
+				CurrentValue:  current.Title,
+			}
+		},
+	},
+	models.AlertConditionDescriptionChanged: {
+		content: true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if previous.Description == current.Description {
+				return nil
+			}
+			return &alertEvent{
+				Message:       "Meta description changed",
+				PreviousValue: previous.Description,
+				CurrentValue:  current.Description,
+			}
+		},
+	},
+	models.AlertConditionNewBrokenLink: {
+		content: true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			known := make(map[string]bool, len(previous.BrokenLinks))
+			for _, link := range previous.BrokenLinks {
+				known[link] = true
+			}
+			var added []string
+			for _, link := range current.BrokenLinks {
+				if !known[link] {
+					added = append(added, link)
+					known[link] = true
+				}
+			}
+			if len(added) == 0 {
+				return nil
+			}
+			sort.Strings(added)
+
+			listed := added
+			if len(listed) > maxAlertLinks {
+				listed = listed[:maxAlertLinks]
+			}
+			message := fmt.Sprintf("%d new broken link(s): %s", len(added), strings.Join(listed, ", "))
+			if len(added) > len(listed) {
+				message += fmt.Sprintf(" and %d more", len(added)-len(listed))
+			}
+			return &alertEvent{
+				Message:       message,
+				PreviousValue: strconv.Itoa(len(previous.BrokenLinks)),
+				CurrentValue:  strings.Join(added, "\n"),
+			}
+		},
+	},
+	models.AlertConditionH1Removed: {
+		content: true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if previous.H1Count == 0 || current.H1Count > 0 {
+				return nil
+			}
+			return &alertEvent{
+				Message:       "The page no longer has an H1 heading",
+				PreviousValue: strconv.Itoa(previous.H1Count),
+				CurrentValue:  "0",
+			}
+		},
+		recovered: func(current *alertSnapshot) bool {
+			return current.H1Count > 0
+		},
+	},
+	models.AlertConditionLoadTimeRegression: {
+		threshold:         "percentage increase over the previous load time",
+		thresholdRequired: true,
+		content:           true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if previous.LoadTime <= 0 {
+				return nil
+			}
+			increase := (current.LoadTime - previous.LoadTime) / previous.LoadTime * 100
+			if increase <= rule.Threshold {
+				return nil
+			}
+			return &alertEvent{
+				Message: fmt.Sprintf("Load time regressed by %.0f%% (%.2fs to %.2fs, threshold %g%%)",
+					increase, previous.LoadTime, current.LoadTime, rule.Threshold),
+				PreviousValue: strconv.FormatFloat(previous.LoadTime, 'f', 3, 64),
+				CurrentValue:  strconv.FormatFloat(current.LoadTime, 'f', 3, 64),
+			}
+		},
+	},
+	models.AlertConditionLoginFormRemoved: {
+		content: true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if !previous.HasLoginForm || current.HasLoginForm {
+				return nil
+			}
+			return &alertEvent{
+				Message:       "The login form is no longer present on the page",
+				PreviousValue: "true",
+				CurrentValue:  "false",
+			}
+		},
+		recovered: func(current *alertSnapshot) bool {
+			return current.HasLoginForm
+		},
+	},
+	models.AlertConditionNoindex: {
+		content: true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			if previous.Noindex || !current.Noindex {
+				return nil
+			}
+			return &alertEvent{
+				Message:       "The page became noindex and will drop out of search results",
+				PreviousValue: "false",
+				CurrentValue:  "true",
+			}
+		},
+		recovered: func(current *alertSnapshot) bool {
+			return !current.Noindex
+		},
+	},
+	models.AlertConditionScoreDrop: {
+		threshold:         "minimum drop in SEO score points",
+		thresholdRequired: true,
+		content:           true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			drop := previous.Score - current.Score
+			if float64(drop) < rule.Threshold {
+				return nil
+			}
+			return &alertEvent{
+				Message:       fmt.Sprintf("SEO score dropped by %d points (%d to %d)", drop, previous.Score, current.Score),
+				PreviousValue: strconv.Itoa(previous.Score),
+				CurrentValue:  strconv.Itoa(current.Score),
+			}
+		},
+	},
+	models.AlertConditionAccessibilityIncreased: {
+		threshold: "number of new violations tolerated",
+		content:   true,
+		evaluate: func(rule *models.AlertRule, previous, current *alertSnapshot) *alertEvent {
+			increase := current.AccessibilityViolations - previous.AccessibilityViolations
+			if increase <= 0 || float64(increase) <= rule.Threshold {
+				return nil
+			}
+			return &alertEvent{
+				Message: fmt.Sprintf("Accessibility violations increased from %d to %d",
+					previous.AccessibilityViolations, current.AccessibilityViolations),
+				PreviousValue: strconv.Itoa(previous.AccessibilityViolations),
+				CurrentValue:  strconv.Itoa(current.AccessibilityViolations),
+			}
+		},
+	},
+}
+
+// AlertConditionInfo describes an alert condition for API clients
+type AlertConditionInfo struct {
+	Name              string `json:"name"`
+	Threshold         string `json:"threshold,omitempty"`
+	ThresholdRequired bool   `json:"threshold_required"`
+	AutoResolves      bool   `json:"auto_resolves"`
+}
+
+// AlertConditions returns the supported alert conditions sorted by name
+func AlertConditions() []AlertConditionInfo {
+	conditions := make([]AlertConditionInfo, 0, len(alertConditions))
+	for name, condition := range alertConditions {
+		conditions = append(conditions, AlertConditionInfo{
+			Name:              name,
+			Threshold:         condition.threshold,
+			ThresholdRequired: condition.thresholdRequired,
+			AutoResolves:      condition.recovered != nil,
+		})
+	}
+	sort.Slice(conditions, func(i, j int) bool {
+		return conditions[i].Name < conditions[j].Name
+	})
+	return conditions
+}
+
+// evaluateAlertRule returns the event raised by a rule, or whether an active
+// alert for it can be resolved automatically when the rule did not fire
+func evaluateAlertRule(rule *models.AlertRule, previous, current *alertSnapshot) (event *alertEvent, recovered bool) {
+	condition, ok := alertConditions[rule.Condition]
+	if !ok || previous == nil {
+		return nil, false
+	}
+	if condition.content && (!previous.fetched() || !current.fetched()) {
+		return nil, false
+	}
+
+	if event = condition.evaluate(rule, previous, current); event != nil {
+		return event, false
+	}
+	return nil, condition.recovered != nil && condition.recovered(current)
+}
+
+// statusCodeText formats a status code, describing failed fetches that have none
+func statusCodeText(code int) string {
+	if code == 0 {
+		return "no response"
+	}
+	return strconv.Itoa(code)
+}
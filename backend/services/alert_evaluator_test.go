@@ -0,0 +1,182 @@
+package services
+
+import (
+	"testing"
+
+	"website-analyzer-backend/models"
+)
+
+func TestEvaluateAlertRule(t *testing.T) {
+	page := func(change func(s *alertSnapshot)) *alertSnapshot {
+		snapshot := &alertSnapshot{
+			StatusCode:   200,
+			Title:        "Home",
+			Description:  "Welcome",
+			BrokenLinks:  []string{"https://example.com/old"},
+			H1Count:      1,
+			LoadTime:     1.0,
+			HasLoginForm: true,
+			Score:        90,
+		}
+		if change != nil {
+			change(snapshot)
+		}
+		return snapshot
+	}
+
+	tests := []struct {
+		name          string
+		condition     string
+		threshold     float64
+		previous      *alertSnapshot
+		current       *alertSnapshot
+		wantMessage   string // empty if the rule must not fire
+		wantRecovered bool
+	}{
+		{
+			name:        "status code changed",
+			condition:   models.AlertConditionStatusCodeChanged,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.StatusCode = 0 }),
+			wantMessage: "Status code changed from 200 to no response",
+		},
+		{
+			name:      "first analysis",
+			condition: models.AlertConditionStatusCodeChanged,
+			current:   page(func(s *alertSnapshot) { s.StatusCode = 500 }),
+		},
+		{
+			name:      "unknown condition",
+			condition: "moon_phase_changed",
+			previous:  page(nil),
+			current:   page(func(s *alertSnapshot) { s.StatusCode = 500 }),
+		},
+		{
+			name:        "title changed",
+			condition:   models.AlertConditionTitleChanged,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.Title = "Shop" }),
+			wantMessage: `Title changed from "Home" to "Shop"`,
+		},
+		{
+			// Content conditions ignore analyses that could not fetch the page
+			name:      "title of a failed fetch",
+			condition: models.AlertConditionTitleChanged,
+			previous:  page(nil),
+			current:   page(func(s *alertSnapshot) { s.StatusCode = 503; s.Title = "" }),
+		},
+		{
+			name:      "new broken links",
+			condition: models.AlertConditionNewBrokenLink,
+			previous:  page(nil),
+			current: page(func(s *alertSnapshot) {
+				s.BrokenLinks = []string{"https://example.com/old", "https://example.com/b", "https://example.com/a", "https://example.com/a"}
+			}),
+			wantMessage: "2 new broken link(s): https://example.com/a, https://example.com/b",
+		},
+		{
+			name:      "only known broken links",
+			condition: models.AlertConditionNewBrokenLink,
+			previous:  page(nil),
+			current:   page(func(s *alertSnapshot) { s.BrokenLinks = nil }),
+		},
+		{
+			name:        "H1 removed",
+			condition:   models.AlertConditionH1Removed,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.H1Count = 0 }),
+			wantMessage: "The page no longer has an H1 heading",
+		},
+		{
+			name:          "H1 back",
+			condition:     models.AlertConditionH1Removed,
+			previous:      page(func(s *alertSnapshot) { s.H1Count = 0 }),
+			current:       page(nil),
+			wantRecovered: true,
+		},
+		{
+			name:        "load time over the threshold",
+			condition:   models.AlertConditionLoadTimeRegression,
+			threshold:   50,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.LoadTime = 1.6 }),
+			wantMessage: "Load time regressed by 60% (1.00s to 1.60s, threshold 50%)",
+		},
+		{
+			name:      "load time at the threshold",
+			condition: models.AlertConditionLoadTimeRegression,
+			threshold: 50,
+			previous:  page(nil),
+			current:   page(func(s *alertSnapshot) { s.LoadTime = 1.5 }),
+		},
+		{
+			name:        "login form removed",
+			condition:   models.AlertConditionLoginFormRemoved,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.HasLoginForm = false }),
+			wantMessage: "The login form is no longer present on the page",
+		},
+		{
+			name:        "became noindex",
+			condition:   models.AlertConditionNoindex,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.Noindex = true }),
+			wantMessage: "The page became noindex and will drop out of search results",
+		},
+		{
+			name:          "indexable again",
+			condition:     models.AlertConditionNoindex,
+			previous:      page(func(s *alertSnapshot) { s.Noindex = true }),
+			current:       page(nil),
+			wantRecovered: true,
+		},
+		{
+			name:        "score drop at the threshold",
+			condition:   models.AlertConditionScoreDrop,
+			threshold:   10,
+			previous:    page(nil),
+			current:     page(func(s *alertSnapshot) { s.Score = 80 }),
+			wantMessage: "SEO score dropped by 10 points (90 to 80)",
+		},
+		{
+			name:      "score drop below the threshold",
+			condition: models.AlertConditionScoreDrop,
+			threshold: 10,
+			previous:  page(nil),
+			current:   page(func(s *alertSnapshot) { s.Score = 81 }),
+		},
+		{
+			name:        "accessibility violations increased",
+			condition:   models.AlertConditionAccessibilityIncreased,
+			threshold:   2,
+			previous:    page(func(s *alertSnapshot) { s.AccessibilityViolations = 4 }),
+			current:     page(func(s *alertSnapshot) { s.AccessibilityViolations = 7 }),
+			wantMessage: "Accessibility violations increased from 4 to 7",
+		},
+		{
+			name:      "accessibility increase tolerated",
+			condition: models.AlertConditionAccessibilityIncreased,
+			threshold: 2,
+			previous:  page(func(s *alertSnapshot) { s.AccessibilityViolations = 4 }),
+			current:   page(func(s *alertSnapshot) { s.AccessibilityViolations = 6 }),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &models.AlertRule{Condition: tt.condition, Threshold: tt.threshold}
+			event, recovered := evaluateAlertRule(rule, tt.previous, tt.current)
+
+			switch {
+			case tt.wantMessage == "" && event != nil:
+				t.Errorf("rule fired with %q, want no event", event.Message)
+			case tt.wantMessage != "" && event == nil:
+				t.Errorf("rule did not fire, want %q", tt.wantMessage)
+			case event != nil && event.Message != tt.wantMessage:
+				t.Errorf("message = %q, want %q", event.Message, tt.wantMessage)
+			}
+			if recovered != tt.wantRecovered {
+				t.Errorf("recovered = %v, want %v", recovered, tt.wantRecovered)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidAlertRule is returned when an alert rule definition fails validation
+	ErrInvalidAlertRule = errors.New("invalid alert rule")
+	// ErrInvalidAlertState is returned when an alert cannot move to the requested state
+	ErrInvalidAlertState = errors.New("invalid alert state transition")
+)
+
+// AlertService handles business logic for alert rules and the alerts they raise
+type AlertService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewAlertService creates a new alert service instance
+func NewAlertService() *AlertService {
+	return &AlertService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *AlertService) ForWorkspace(workspaceID uint) *AlertService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllRules retrieves all alert rules
+func (s *AlertService) GetAllRules() ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tag").Order("name ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetRuleByID retrieves an alert rule by its ID
+func (s *AlertService) GetRuleByID(id uint) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tag").First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("alert rule not found")
+		}
+		return nil, fmt.Errorf("failed to get alert rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// CreateRule validates and creates an alert rule
+func (s *AlertService) CreateRule(req models.AlertRuleRequest) (*models.AlertRule, error) {
+	rule := models.AlertRule{
+		WorkspaceID: s.workspaceID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.applyRuleRequest(&rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Omit("Tag").Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+
+	return s.GetRuleByID(rule.ID)
+}
+
+// UpdateRule validates and replaces an alert rule
+func (s *AlertService) UpdateRule(id uint, req models.AlertRuleRequest) (*models.AlertRule, error) {
+	rule, err := s.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRuleRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	rule.Tag = nil
+	if err := s.db.Omit("Tag").Save(rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	return s.GetRuleByID(id)
+}
+
+// DeleteRule deletes an alert rule and resolves the alerts it still has open
+func (s *AlertService) DeleteRule(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(inWorkspace(s.workspaceID)).Delete(&models.AlertRule{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete alert rule: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("alert rule not found")
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.Alert{}).
+			Where("alert_rule_id = ? AND state IN ?", id, []string{models.AlertStateOpen, models.AlertStateAcknowledged}).
+			Updates(map[string]interface{}{
+				"state":       models.AlertStateResolved,
+				"resolved_at": now,
+				"note":        "Alert rule deleted",
+				"updated_at":  now,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to resolve alerts of deleted rule: %w", err)
+		}
+		return nil
+	})
+}
+
+// applyRuleRequest validates an alert rule request and copies it onto the model
+func (s *AlertService) applyRuleRequest(rule *models.AlertRule, req models.AlertRuleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	conditionName := strings.ToLower(strings.TrimSpace(req.Condition))
+	severity := strings.ToLower(strings.TrimSpace(req.Severity))
+	tag := strings.TrimSpace(req.Tag)
+
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAlertRule)
+	}
+	condition, ok := alertConditions[conditionName]
+	if !ok {
+		names := make([]string, 0, len(alertConditions))
+		for _, info := range AlertConditions() {
+			names = append(names, info.Name)
+		}
+		return fmt.Errorf("%w: unknown condition %q (supported: %s)", ErrInvalidAlertRule, req.Condition, strings.Join(names, ", "))
+	}
+	if req.Threshold < 0 {
+		return fmt.Errorf("%w: threshold must not be negative", ErrInvalidAlertRule)
+	}
+	if condition.thresholdRequired && req.Threshold == 0 {
+		return fmt.Errorf("%w: condition %q requires a threshold (%s)", ErrInvalidAlertRule, conditionName, condition.threshold)
+	}
+	if condition.threshold == "" && req.Threshold != 0 {
+		return fmt.Errorf("%w: condition %q does not take a threshold", ErrInvalidAlertRule, conditionName)
+	}
+	if severity == "" {
+		severity = "medium"
+	}
+	if _, known := severityRank[severity]; !known {
+		return fmt.Errorf("%w: unknown severity %q", ErrInvalidAlertRule, req.Severity)
+	}
+	if req.URLID != nil && tag != "" {
+		return fmt.Errorf("%w: url_id and tag cannot both be set", ErrInvalidAlertRule)
+	}
+
+	rule.URLID = nil
+	rule.TagID = nil
+	if req.URLID != nil {
+		var url models.URL
+		if err := s.db.Scopes(inWorkspace(rule.WorkspaceID)).First(&url, *req.URLID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("URL not found")
+			}
+			return fmt.Errorf("failed to find URL: %w", err)
+		}
+		rule.URLID = req.URLID
+	} else if tag != "" {
+		tags, err := findOrCreateTags(s.db, rule.WorkspaceID, []string{tag})
+		if err != nil {
+			return err
+		}
+		rule.TagID = &tags[0].ID
+	}
+
+	rule.Name = name
+	rule.Description = req.Description
+	rule.Condition = conditionName
+	rule.Threshold = req.Threshold
+	rule.Severity = severity
+	rule.Enabled = true
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GetApplicableRules retrieves the enabled alert rules of a URL's workspace that
+// apply to it: rules for the URL itself, for any of its tags, and rules that
+// apply to all URLs
+func (s *AlertService) GetApplicableRules(url *models.URL) ([]models.AlertRule, error) {
+	tagIDs := make([]uint, 0, len(url.Tags))
+	for _, tag := range url.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	var rules []models.AlertRule
+	query := s.db.Where("workspace_id = ? AND enabled = ?", url.WorkspaceID, true)
+	global := "(url_id IS NULL AND tag_id IS NULL)"
+	if len(tagIDs) > 0 {
+		query = query.Where("url_id = ? OR tag_id IN ? OR "+global, url.ID, tagIDs)
+	} else {
+		query = query.Where("url_id = ? OR "+global, url.ID)
+	}
+	if err := query.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// applyAlerts evaluates alert rules against two consecutive analyses of a URL
+// within tx. A firing rule raises a new alert or updates its active one; rules
+// whose condition has cleared resolve their active alert if the condition allows it.
+// It returns the alerts raised.
+func (s *AlertService) applyAlerts(tx *gorm.DB, rules []models.AlertRule, previous, current *alertSnapshot, urlID, analysisID uint, at time.Time) ([]models.Alert, error) {
+	var raised []models.Alert
+	for i := range rules {
+		rule := &rules[i]
+		event, recovered := evaluateAlertRule(rule, previous, current)
+		if event == nil && !recovered {
+			continue
+		}
+
+		var alert models.Alert
+		err := tx.Where("alert_rule_id = ? AND url_id = ? AND state IN ?", rule.ID, urlID,
+			[]string{models.AlertStateOpen, models.AlertStateAcknowledged}).
+			Order("id DESC").First(&alert).Error
+		active := err == nil
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return raised, fmt.Errorf("failed to find active alert: %w", err)
+		}
+
+		switch {
+		case event != nil && active:
+			err = tx.Model(&alert).Updates(map[string]interface{}{
+				"analysis_id":   analysisID,
+				"message":       event.Message,
+				"current_value": event.CurrentValue,
+				"severity":      rule.Severity,
+				"occurrences":   gorm.Expr("occurrences + 1"),
+				"last_seen_at":  at,
+				"updated_at":    time.Now(),
+			}).Error
+		case event != nil:
+			alert = models.Alert{
+				AlertRuleID:   rule.ID,
+				RuleName:      rule.Name,
+				Condition:     rule.Condition,
+				Severity:      rule.Severity,
+				URLID:         urlID,
+				AnalysisID:    analysisID,
+				State:         models.AlertStateOpen,
+				Message:       event.Message,
+				PreviousValue: event.PreviousValue,
+				CurrentValue:  event.CurrentValue,
+				Occurrences:   1,
+				FirstSeenAt:   at,
+				LastSeenAt:    at,
+			}
+			if err = tx.Create(&alert).Error; err == nil {
+				raised = append(raised, alert)
+			}
+		case active:
+			err = tx.Model(&alert).Updates(map[string]interface{}{
+				"state":       models.AlertStateResolved,
+				"resolved_at": at,
+				"note":        "Resolved automatically: condition no longer holds",
+				"updated_at":  time.Now(),
+			}).Error
+		}
+		if err != nil {
+			return raised, fmt.Errorf("failed to save alert: %w", err)
+		}
+	}
+	return raised, nil
+}
+
+// AlertFilters represents filters for alert queries
+type AlertFilters struct {
+	State    string
+	URLID    uint
+	RuleID   uint
+	Severity string
+}
+
+// GetAlerts retrieves alerts with pagination and filtering, most recently seen first
+func (s *AlertService) GetAlerts(page, limit int, filters AlertFilters) ([]models.Alert, int64, error) {
+	var alerts []models.Alert
+	var total int64
+
+	query := s.db.Model(&models.Alert{}).Scopes(urlInWorkspace(s.workspaceID))
+
+	if filters.State != "" {
+		query = query.Where("state IN ?", strings.Split(strings.ToLower(filters.State), ","))
+	}
+	if filters.URLID != 0 {
+		query = query.Where("url_id = ?", filters.URLID)
+	}
+	if filters.RuleID != 0 {
+		query = query.Where("alert_rule_id = ?", filters.RuleID)
+	}
+	if filters.Severity != "" {
+		query = query.Where("severity = ?", strings.ToLower(filters.Severity))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Order("last_seen_at DESC, id DESC").Find(&alerts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get alerts: %w", err)
+	}
+
+	return alerts, total, nil
+}
+
+// GetAlertByID retrieves an alert by its ID
+func (s *AlertService) GetAlertByID(id uint) (*models.Alert, error) {
+	var alert models.Alert
+	if err := s.db.Scopes(urlInWorkspace(s.workspaceID)).First(&alert, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("alert not found")
+		}
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+	return &alert, nil
+}
+
+// AcknowledgeAlert marks an open alert as acknowledged
+func (s *AlertService) AcknowledgeAlert(id uint, note string) (*models.Alert, error) {
+	alert, err := s.GetAlertByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.State != models.AlertStateOpen {
+		return nil, fmt.Errorf("%w: alert is %s", ErrInvalidAlertState, alert.State)
+	}
+
+	now := time.Now()
+	alert.State = models.AlertStateAcknowledged
+	alert.AcknowledgedAt = &now
+	if note != "" {
+		alert.Note = note
+	}
+	alert.UpdatedAt = now
+	if err := s.db.Save(alert).Error; err != nil {
+		return nil, fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+	return alert, nil
+}
+
+// ResolveAlert marks an open or acknowledged alert as resolved
+func (s *AlertService) ResolveAlert(id uint, note string) (*models.Alert, error) {
+	alert, err := s.GetAlertByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.State == models.AlertStateResolved {
+		return nil, fmt.Errorf("%w: alert is already resolved", ErrInvalidAlertState)
+	}
+
+	now := time.Now()
+	alert.State = models.AlertStateResolved
+	alert.ResolvedAt = &now
+	if note != "" {
+		alert.Note = note
+	}
+	alert.UpdatedAt = now
+	if err := s.db.Save(alert).Error; err != nil {
+		return nil, fmt.Errorf("failed to resolve alert: %w", err)
+	}
+	return alert, nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+
+	"website-analyzer-backend/models"
+)
+
+func TestCreateAlertRuleStoresDisabled(t *testing.T) {
+	db := newTestDB(t, &models.AlertRule{})
+	service := (&AlertService{db: db}).ForWorkspace(1)
+
+	disabled := false
+	created, err := service.CreateRule(models.AlertRuleRequest{
+		Name:      "Score drop",
+		Condition: models.AlertConditionScoreDrop,
+		Threshold: 10,
+		Enabled:   &disabled,
+	})
+	if err != nil {
+		t.Fatalf("CreateRule: %v", err)
+	}
+
+	var stored models.AlertRule
+	if err := db.First(&stored, created.ID).Error; err != nil {
+		t.Fatalf("reading the alert rule back: %v", err)
+	}
+	if stored.Enabled {
+		t.Error("alert rule created disabled was stored enabled")
+	}
+}
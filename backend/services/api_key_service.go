@@ -0,0 +1,172 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyTouchInterval limits how often a key's last-used timestamp is written
+const apiKeyTouchInterval = time.Minute
+
+// ErrInvalidAPIKey is returned when an API key request fails validation
+var ErrInvalidAPIKey = errors.New("invalid API key request")
+
+// ErrUnauthorized is returned when a presented API key cannot be used
+var ErrUnauthorized = errors.New("unauthorized")
+
+// APIKeyService handles API key management and authentication
+type APIKeyService struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{
+		db: database.GetDB(),
+	}
+}
+
+// GetAPIKeys retrieves the API keys of a user, newest first
+func (s *APIKeyService) GetAPIKeys(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByID retrieves an API key by ID
+func (s *APIKeyService) GetAPIKeyByID(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := s.db.First(&key, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key not found")
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return &key, nil
+}
+
+// CreateAPIKey generates a new API key for a user. The plain key is returned
+// once and cannot be retrieved again.
+func (s *APIKeyService) CreateAPIKey(userID uint, name string, expiresAt *time.Time) (*models.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAPIKey)
+	}
+
+	var user models.User
+	if err := s.db.Select("id").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errors.New("user not found")
+		}
+		return nil, "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	plain, prefix, err := generateAPIKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	key, err := createAPIKey(s.db, userID, name, plain, prefix, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, plain, nil
+}
+
+// RevokeAPIKey revokes an API key; revoking a revoked key has no effect
+func (s *APIKeyService) RevokeAPIKey(id uint) (*models.APIKey, error) {
+	key, err := s.GetAPIKeyByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	if err := s.db.Model(key).Update("revoked_at", now).Error; err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	key.RevokedAt = &now
+	return key, nil
+}
+
+// Authenticate resolves a presented API key to its active user and key
+func (s *APIKeyService) Authenticate(plain string) (*models.User, *models.APIKey, error) {
+	var key models.APIKey
+	if err := s.db.Preload("User").Where("hash = ?", hashAPIKey(plain)).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("%w: invalid API key", ErrUnauthorized)
+		}
+		return nil, nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	now := time.Now()
+	switch {
+	case key.RevokedAt != nil:
+		return nil, nil, fmt.Errorf("%w: API key has been revoked", ErrUnauthorized)
+	case !key.Active(now):
+		return nil, nil, fmt.Errorf("%w: API key has expired", ErrUnauthorized)
+	case key.User == nil || !key.User.Active:
+		return nil, nil, fmt.Errorf("%w: user is inactive", ErrUnauthorized)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		s.db.Model(&models.APIKey{}).Where("id = ?", key.ID).Update("last_used_at", now)
+		key.LastUsedAt = &now
+	}
+
+	return key.User, &key, nil
+}
+
+// createAPIKey stores the hash of a plain key for a user
+func createAPIKey(db *gorm.DB, userID uint, name, plain, prefix string, expiresAt *time.Time) (*models.APIKey, error) {
+	key := models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		Hash:      hashAPIKey(plain),
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	if err := db.Create(&key).Error; err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	return &key, nil
+}
+
+// generateAPIKey returns a new random key of the form wa_<prefix>_<secret>
+// along with its identifying prefix
+func generateAPIKey() (string, string, error) {
+	id, err := randomHex(4)
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return "", "", err
+	}
+	prefix := "wa_" + id
+	return prefix + "_" + secret, prefix, nil
+}
+
+// hashAPIKey returns the hex SHA-256 digest under which a key is stored. Keys
+// are long random strings, so a fast hash is sufficient.
+func hashAPIKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
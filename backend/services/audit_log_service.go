@@ -0,0 +1,139 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidAuditLogQuery is returned when audit log filters are malformed
+var ErrInvalidAuditLogQuery = errors.New("invalid audit log query")
+
+// AuditLogService appends to and queries the audit log. Entries are never
+// updated or deleted through it.
+type AuditLogService struct {
+	db *gorm.DB
+}
+
+// NewAuditLogService creates a new audit log service instance
+func NewAuditLogService() *AuditLogService {
+	return &AuditLogService{
+		db: database.GetDB(),
+	}
+}
+
+// AuditLogFilters narrows down the audit log entries returned by GetEntries
+type AuditLogFilters struct {
+	WorkspaceID *uint
+	UserID      uint
+	Action      string
+	TargetType  string
+	TargetID    uint
+	RequestID   string
+	From        string // RFC 3339 time or YYYY-MM-DD date
+	To          string // RFC 3339 time or YYYY-MM-DD date, inclusive
+}
+
+// Record appends an entry to the audit log. before and after are stored as
+// JSON snapshots of the targets and may be nil.
+func (s *AuditLogService) Record(entry *models.AuditLogEntry, targetIDs []uint, before, after interface{}) error {
+	if targetIDs == nil {
+		targetIDs = []uint{}
+	}
+	ids, err := json.Marshal(targetIDs)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit log targets: %w", err)
+	}
+	entry.TargetIDs = string(ids)
+
+	if entry.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+
+	if err := s.db.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to record audit log entry: %w", err)
+	}
+	return nil
+}
+
+// auditSnapshot encodes a before or after snapshot, or returns "" for nil
+func auditSnapshot(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit log snapshot: %w", err)
+	}
+	if string(data) == "null" {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+// GetEntries retrieves audit log entries with pagination and filtering, newest first
+func (s *AuditLogService) GetEntries(page, limit int, filters AuditLogFilters) ([]models.AuditLogEntry, int64, error) {
+	var entries []models.AuditLogEntry
+	var total int64
+
+	query := s.db.Model(&models.AuditLogEntry{})
+
+	if filters.WorkspaceID != nil {
+		query = query.Where("workspace_id = ?", *filters.WorkspaceID)
+	}
+	if filters.UserID != 0 {
+		query = query.Where("user_id = ?", filters.UserID)
+	}
+	if filters.Action != "" {
+		query = query.Where("action = ?", filters.Action)
+	}
+	if filters.TargetType != "" {
+		query = query.Where("target_type = ?", filters.TargetType)
+	}
+	if filters.TargetID != 0 {
+		query = query.Where("JSON_CONTAINS(target_ids, ?)", strconv.FormatUint(uint64(filters.TargetID), 10))
+	}
+	if filters.RequestID != "" {
+		query = query.Where("request_id = ?", filters.RequestID)
+	}
+	if filters.From != "" {
+		from, _, err := parseTrendTime(filters.From)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid from %q", ErrInvalidAuditLogQuery, filters.From)
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if filters.To != "" {
+		to, dateOnly, err := parseTrendTime(filters.To)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid to %q", ErrInvalidAuditLogQuery, filters.To)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		} else {
+			to = to.Add(time.Nanosecond)
+		}
+		query = query.Where("created_at < ?", to)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit log entries: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Order("created_at DESC, id DESC").Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit log entries: %w", err)
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"website-analyzer-backend/models"
+)
+
+// maxRuleViolations caps the violations recorded per rule and page
+const maxRuleViolations = 20
+
+// evaluateAuditRules evaluates the workspace's enabled user-defined audit rules against the document
+func (s *SEOAnalyzer) evaluateAuditRules(doc *goquery.Document, pageURL string, workspaceID uint, result *SEOAnalysisResult) {
+	result.RuleViolations = []models.RuleViolation{}
+	if s.auditRules == nil {
+		return
+	}
+
+	rules, err := s.auditRules.ForWorkspace(workspaceID).GetEnabledRules()
+	if err != nil {
+		log.Printf("Skipping audit rules: %v", err)
+		return
+	}
+
+	result.RuleViolations = EvaluateAuditRules(rules, doc, pageURL)
+}
+
+// EvaluateAuditRules returns the violations of the given rules for a page
+func EvaluateAuditRules(rules []models.AuditRule, doc *goquery.Document, pageURL string) []models.RuleViolation {
+	violations := []models.RuleViolation{}
+	var rawHTML string
+
+	for _, rule := range rules {
+		if rule.URLPattern != "" {
+			scope, err := regexp.Compile(rule.URLPattern)
+			if err != nil || !scope.MatchString(pageURL) {
+				continue
+			}
+		}
+
+		var pattern *regexp.Regexp
+		if rule.Pattern != "" {
+			compiled, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				log.Printf("Skipping audit rule %q: invalid pattern: %v", rule.Name, err)
+				continue
+			}
+			pattern = compiled
+		}
+
+		add := func(message string, sel *goquery.Selection) {
+			violation := models.RuleViolation{
+				RuleID:   rule.ID,
+				RuleName: rule.Name,
+				Severity: rule.Severity,
+				Message:  message,
+			}
+			if sel != nil {
+				violation.Selector = cssSelectorPath(sel)
+			}
+			violations = append(violations, violation)
+		}
+		start := len(violations)
+		limitReached := func() bool { return len(violations)-start >= maxRuleViolations }
+
+		switch rule.Type {
+		case models.AuditRuleTypeSelector:
+			matches := doc.Find(rule.Selector)
+			if rule.Text != "" {
+				text := strings.ToLower(rule.Text)
+				matches = matches.FilterFunction(func(i int, sel *goquery.Selection) bool {
+					return strings.Contains(strings.ToLower(sel.Text()), text)
+				})
+			}
+			description := fmt.Sprintf("%q", rule.Selector)
+			if rule.Text != "" {
+				description += fmt.Sprintf(" containing %q", rule.Text)
+			}
+
+			if rule.Assert == models.AuditAssertExists {
+				if matches.Length() == 0 {
+					add("No element matches "+description, nil)
+				}
+				continue
+			}
+			matches.EachWithBreak(func(i int, sel *goquery.Selection) bool {
+				add("Element matches "+description+", which is not allowed", sel)
+				return !limitReached()
+			})
+
+		case models.AuditRuleTypeAttribute:
+			found := false
+			doc.Find(rule.Selector).EachWithBreak(func(i int, sel *goquery.Selection) bool {
+				value, ok := sel.Attr(rule.Attribute)
+				if !ok || !pattern.MatchString(value) {
+					return true
+				}
+				found = true
+				if rule.Assert == models.AuditAssertExists {
+					return false
+				}
+				add(fmt.Sprintf("%s=%q matches %q, which is not allowed", rule.Attribute, value, rule.Pattern), sel)
+				return !limitReached()
+			})
+			if rule.Assert == models.AuditAssertExists && !found {
+				add(fmt.Sprintf("No %q element has a %s attribute matching %q", rule.Selector, rule.Attribute, rule.Pattern), nil)
+			}
+
+		case models.AuditRuleTypeRegex:
+			if rawHTML == "" {
+				rawHTML, _ = doc.Html()
+			}
+			if rule.Assert == models.AuditAssertExists {
+				if !pattern.MatchString(rawHTML) {
+					add(fmt.Sprintf("Page HTML does not match %q", rule.Pattern), nil)
+				}
+				continue
+			}
+			for _, match := range pattern.FindAllString(rawHTML, maxRuleViolations) {
+				if runes := []rune(match); len(runes) > 200 {
+					match = string(runes[:200]) + "..."
+				}
+				add(fmt.Sprintf("Page HTML contains %q, which is not allowed", match), nil)
+			}
+		}
+	}
+
+	return violations
+}
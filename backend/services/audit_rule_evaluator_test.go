@@ -0,0 +1,112 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"website-analyzer-backend/models"
+)
+
+func TestEvaluateAuditRules(t *testing.T) {
+	const page = `<html><head><title>Shop</title></head><body>
+		<a href="https://staging.example.com/a">A</a>
+		<a href="https://www.example.com/b">B</a>
+		<p class="promo">Summer sale</p>
+		<!-- TODO remove -->
+	</body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		rule         models.AuditRule
+		pageURL      string
+		wantMessages []string
+	}{
+		{
+			name:         "required element exists",
+			rule:         models.AuditRule{Type: models.AuditRuleTypeSelector, Selector: "title", Assert: models.AuditAssertExists},
+			wantMessages: nil,
+		},
+		{
+			name:         "required element missing",
+			rule:         models.AuditRule{Type: models.AuditRuleTypeSelector, Selector: "link[rel=canonical]", Assert: models.AuditAssertExists},
+			wantMessages: []string{`No element matches "link[rel=canonical]"`},
+		},
+		{
+			name:         "forbidden element containing text",
+			rule:         models.AuditRule{Type: models.AuditRuleTypeSelector, Selector: "p", Text: "SALE", Assert: models.AuditAssertAbsent},
+			wantMessages: []string{`Element matches "p" containing "SALE", which is not allowed`},
+		},
+		{
+			name: "forbidden attribute value",
+			rule: models.AuditRule{Type: models.AuditRuleTypeAttribute, Selector: "a[href]", Attribute: "href",
+				Pattern: `staging\.example\.com`, Assert: models.AuditAssertAbsent},
+			wantMessages: []string{`href="https://staging.example.com/a" matches "staging\\.example\\.com", which is not allowed`},
+		},
+		{
+			name: "required attribute value missing",
+			rule: models.AuditRule{Type: models.AuditRuleTypeAttribute, Selector: "a[href]", Attribute: "href",
+				Pattern: `^https://shop\.`, Assert: models.AuditAssertExists},
+			wantMessages: []string{`No "a[href]" element has a href attribute matching "^https://shop\\."`},
+		},
+		{
+			name:         "forbidden HTML",
+			rule:         models.AuditRule{Type: models.AuditRuleTypeRegex, Pattern: `TODO \w+`, Assert: models.AuditAssertAbsent},
+			wantMessages: []string{`Page HTML contains "TODO remove", which is not allowed`},
+		},
+		{
+			name:         "required HTML missing",
+			rule:         models.AuditRule{Type: models.AuditRuleTypeRegex, Pattern: `gtag\(`, Assert: models.AuditAssertExists},
+			wantMessages: []string{`Page HTML does not match "gtag\\("`},
+		},
+		{
+			name: "out of the rule's URL scope",
+			rule: models.AuditRule{Type: models.AuditRuleTypeRegex, Pattern: `TODO`, Assert: models.AuditAssertAbsent,
+				URLPattern: `/blog/`},
+			pageURL:      "https://www.example.com/shop",
+			wantMessages: nil,
+		},
+		{
+			name:         "invalid pattern is skipped",
+			rule:         models.AuditRule{Type: models.AuditRuleTypeRegex, Pattern: `(`, Assert: models.AuditAssertAbsent},
+			wantMessages: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.ID, tt.rule.Name, tt.rule.Severity = 7, "rule", "high"
+			pageURL := tt.pageURL
+			if pageURL == "" {
+				pageURL = "https://www.example.com/"
+			}
+
+			violations := EvaluateAuditRules([]models.AuditRule{tt.rule}, doc, pageURL)
+			if len(violations) != len(tt.wantMessages) {
+				t.Fatalf("violations = %+v, want %q", violations, tt.wantMessages)
+			}
+			for i, violation := range violations {
+				if violation.Message != tt.wantMessages[i] {
+					t.Errorf("message = %q, want %q", violation.Message, tt.wantMessages[i])
+				}
+				if violation.RuleID != 7 || violation.Severity != "high" {
+					t.Errorf("violation = %+v, want rule 7 with high severity", violation)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateAuditRulesCapsViolations(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<p>" + strings.Repeat("<b>x</b>", maxRuleViolations+5) + "</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := models.AuditRule{Type: models.AuditRuleTypeSelector, Selector: "b", Assert: models.AuditAssertAbsent}
+	if violations := EvaluateAuditRules([]models.AuditRule{rule}, doc, ""); len(violations) != maxRuleViolations {
+		t.Errorf("got %d violations, want %d", len(violations), maxRuleViolations)
+	}
+}
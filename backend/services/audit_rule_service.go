@@ -0,0 +1,323 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidAuditRule is returned when a rule definition fails validation
+var ErrInvalidAuditRule = errors.New("invalid audit rule")
+
+// AuditRuleService handles business logic for user-defined audit rules
+type AuditRuleService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewAuditRuleService creates a new audit rule service instance
+func NewAuditRuleService() *AuditRuleService {
+	return &AuditRuleService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *AuditRuleService) ForWorkspace(workspaceID uint) *AuditRuleService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllRules retrieves all audit rules ordered by name
+func (s *AuditRuleService) GetAllRules() ([]models.AuditRule, error) {
+	var rules []models.AuditRule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Order("name ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get audit rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetEnabledRules retrieves the audit rules evaluated during analysis
+func (s *AuditRuleService) GetEnabledRules() ([]models.AuditRule, error) {
+	var rules []models.AuditRule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Where("enabled = ?", true).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get audit rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetRuleByID retrieves an audit rule by its ID
+func (s *AuditRuleService) GetRuleByID(id uint) (*models.AuditRule, error) {
+	var rule models.AuditRule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("audit rule not found")
+		}
+		return nil, fmt.Errorf("failed to get audit rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// CreateRule validates and creates a new audit rule
+func (s *AuditRuleService) CreateRule(req models.AuditRuleRequest) (*models.AuditRule, error) {
+	rule, err := BuildAuditRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing models.AuditRule
+	if err := s.db.Where("workspace_id = ? AND name = ?", s.workspaceID, rule.Name).First(&existing).Error; err == nil {
+		return nil, errors.New("audit rule already exists")
+	}
+
+	rule.WorkspaceID = s.workspaceID
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = time.Now()
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create audit rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// UpdateRule validates and replaces an existing audit rule
+func (s *AuditRuleService) UpdateRule(id uint, req models.AuditRuleRequest) (*models.AuditRule, error) {
+	existing, err := s.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := BuildAuditRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflict models.AuditRule
+	if err := s.db.Where("workspace_id = ? AND name = ? AND id <> ?", existing.WorkspaceID, rule.Name, id).First(&conflict).Error; err == nil {
+		return nil, errors.New("audit rule already exists")
+	}
+
+	rule.ID = existing.ID
+	rule.WorkspaceID = existing.WorkspaceID
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+	if err := s.db.Save(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update audit rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// DeleteRule deletes an audit rule by ID; recorded violations are kept as history
+func (s *AuditRuleService) DeleteRule(id uint) error {
+	result := s.db.Scopes(inWorkspace(s.workspaceID)).Delete(&models.AuditRule{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete audit rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("audit rule not found")
+	}
+	return nil
+}
+
+// ImportRules creates or replaces (by name) the rules in a YAML or JSON document.
+// The document is either a list of rules or an object with a "rules" list.
+func (s *AuditRuleService) ImportRules(data []byte) (created, updated int, err error) {
+	var file models.AuditRuleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		var list []models.AuditRuleRequest
+		if listErr := yaml.Unmarshal(data, &list); listErr != nil {
+			return 0, 0, fmt.Errorf("%w: failed to parse rules: %v", ErrInvalidAuditRule, err)
+		}
+		file.Rules = list
+	}
+	if len(file.Rules) == 0 {
+		return 0, 0, fmt.Errorf("%w: no rules found", ErrInvalidAuditRule)
+	}
+
+	// Validate everything before touching the database
+	rules := make([]models.AuditRule, 0, len(file.Rules))
+	for i, req := range file.Rules {
+		rule, err := BuildAuditRule(req)
+		if err != nil {
+			return 0, 0, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, rule := range rules {
+			var existing models.AuditRule
+			rule.WorkspaceID = s.workspaceID
+			err := tx.Where("workspace_id = ? AND name = ?", s.workspaceID, rule.Name).First(&existing).Error
+			switch {
+			case err == nil:
+				rule.ID = existing.ID
+				rule.CreatedAt = existing.CreatedAt
+				rule.UpdatedAt = time.Now()
+				if err := tx.Save(&rule).Error; err != nil {
+					return fmt.Errorf("failed to update audit rule %q: %w", rule.Name, err)
+				}
+				updated++
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				rule.CreatedAt = time.Now()
+				rule.UpdatedAt = time.Now()
+				if err := tx.Create(&rule).Error; err != nil {
+					return fmt.Errorf("failed to create audit rule %q: %w", rule.Name, err)
+				}
+				created++
+			default:
+				return fmt.Errorf("failed to look up audit rule %q: %w", rule.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
+}
+
+// ImportRulesFile imports audit rules from a YAML or JSON file; an empty path is a no-op
+func (s *AuditRuleService) ImportRulesFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read audit rules file: %w", err)
+	}
+
+	created, updated, err := s.ImportRules(data)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Loaded audit rules from %s (%d created, %d updated)", path, created, updated)
+	return nil
+}
+
+// RuleViolationFilters represents filters for rule violation queries
+type RuleViolationFilters struct {
+	URLID    uint
+	RuleID   uint
+	Severity string
+	History  bool // include violations from earlier analyses, not just each URL's latest
+}
+
+// GetViolations retrieves rule violations across URLs with pagination and filtering
+func (s *AuditRuleService) GetViolations(page, limit int, filters RuleViolationFilters) ([]models.RuleViolation, int64, error) {
+	var violations []models.RuleViolation
+	var total int64
+
+	query := s.db.Model(&models.RuleViolation{}).Scopes(urlInWorkspace(s.workspaceID))
+
+	if !filters.History {
+		query = query.Where("analysis_id IN (?)", s.db.Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID)).Select("last_analysis_id"))
+	}
+	if filters.URLID != 0 {
+		query = query.Where("url_id = ?", filters.URLID)
+	}
+	if filters.RuleID != 0 {
+		query = query.Where("rule_id = ?", filters.RuleID)
+	}
+	if filters.Severity != "" {
+		query = query.Where("severity = ?", strings.ToLower(filters.Severity))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count rule violations: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Order("analyzed_at DESC, id ASC").Find(&violations).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get rule violations: %w", err)
+	}
+
+	return violations, total, nil
+}
+
+// BuildAuditRule validates a rule definition and normalizes it into a model
+func BuildAuditRule(req models.AuditRuleRequest) (models.AuditRule, error) {
+	rule := models.AuditRule{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Type:        strings.ToLower(strings.TrimSpace(req.Type)),
+		Selector:    strings.TrimSpace(req.Selector),
+		Attribute:   strings.ToLower(strings.TrimSpace(req.Attribute)),
+		Pattern:     req.Pattern,
+		Text:        req.Text,
+		Assert:      strings.ToLower(strings.TrimSpace(req.Assert)),
+		Severity:    strings.ToLower(strings.TrimSpace(req.Severity)),
+		URLPattern:  strings.TrimSpace(req.URLPattern),
+		Enabled:     true,
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if rule.Assert == "" {
+		rule.Assert = models.AuditAssertExists
+	}
+	if rule.Severity == "" {
+		rule.Severity = "medium"
+	}
+
+	if rule.Name == "" {
+		return rule, fmt.Errorf("%w: name is required", ErrInvalidAuditRule)
+	}
+	if rule.Assert != models.AuditAssertExists && rule.Assert != models.AuditAssertAbsent {
+		return rule, fmt.Errorf("%w: assert must be %q or %q", ErrInvalidAuditRule, models.AuditAssertExists, models.AuditAssertAbsent)
+	}
+	if _, known := severityRank[rule.Severity]; !known {
+		return rule, fmt.Errorf("%w: unknown severity %q", ErrInvalidAuditRule, rule.Severity)
+	}
+
+	switch rule.Type {
+	case models.AuditRuleTypeSelector:
+		if rule.Selector == "" {
+			return rule, fmt.Errorf("%w: selector rules require a selector", ErrInvalidAuditRule)
+		}
+	case models.AuditRuleTypeAttribute:
+		if rule.Selector == "" || rule.Attribute == "" || rule.Pattern == "" {
+			return rule, fmt.Errorf("%w: attribute rules require a selector, attribute and pattern", ErrInvalidAuditRule)
+		}
+	case models.AuditRuleTypeRegex:
+		if rule.Pattern == "" {
+			return rule, fmt.Errorf("%w: regex rules require a pattern", ErrInvalidAuditRule)
+		}
+	default:
+		return rule, fmt.Errorf("%w: unknown type %q (expected selector, attribute or regex)", ErrInvalidAuditRule, rule.Type)
+	}
+
+	if rule.Selector != "" {
+		if _, err := cascadia.ParseGroup(rule.Selector); err != nil {
+			return rule, fmt.Errorf("%w: invalid selector: %v", ErrInvalidAuditRule, err)
+		}
+	}
+	if rule.Pattern != "" {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return rule, fmt.Errorf("%w: invalid pattern: %v", ErrInvalidAuditRule, err)
+		}
+	}
+	if rule.URLPattern != "" {
+		if _, err := regexp.Compile(rule.URLPattern); err != nil {
+			return rule, fmt.Errorf("%w: invalid url_pattern: %v", ErrInvalidAuditRule, err)
+		}
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,707 @@
+package services
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// minPasswordLength is the shortest password accepted for a user
+const minPasswordLength = 8
+
+// jwksRetryInterval limits how often an unknown key ID triggers a JWKS reload
+const jwksRetryInterval = time.Minute
+
+// ErrInvalidPassword is returned when a new password fails validation
+var ErrInvalidPassword = errors.New("invalid password")
+
+// AuthSettings configures password login, the access tokens it issues and
+// the external OIDC provider whose tokens are accepted
+type AuthSettings struct {
+	SigningKeyPath  string        // PEM RSA private key; generated and written here when missing
+	Issuer          string        // iss claim of issued access tokens
+	AccessTokenTTL  time.Duration // lifetime of access tokens
+	RefreshTokenTTL time.Duration // lifetime of refresh tokens
+	OIDC            OIDCSettings
+}
+
+// OIDCSettings configures validation of tokens issued by an OIDC provider and
+// how their claims map to users; it is disabled without an issuer and JWKS
+type OIDCSettings struct {
+	Issuer           string
+	Audience         string            // this server's client ID; required when OIDC is enabled
+	JWKS             string            // path or http(s) URL of the provider's key set
+	JWKSRefresh      time.Duration     // how often the key set is loaded again
+	EmailClaim       string            // claim holding the user's email address
+	NameClaim        string            // claim holding the user's display name
+	RoleClaim        string            // claim holding role names or groups; roles are not synced when empty
+	RoleMapping      map[string]string // claim value to role; values are used as role names when empty
+	DefaultRole      string            // role of users whose claims map to no role
+	AutoProvision    bool              // create users on their first login
+	DefaultWorkspace uint              // workspace provisioned users join; 0 leaves them in none
+}
+
+// authSettings holds the active authentication settings (see ConfigureAuth)
+var authSettings = AuthSettings{
+	Issuer:          "website-analyzer",
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: 30 * 24 * time.Hour,
+	OIDC: OIDCSettings{
+		JWKSRefresh: time.Hour,
+		EmailClaim:  "email",
+		NameClaim:   "name",
+		DefaultRole: models.RoleViewer,
+	},
+}
+
+// authKeys holds the key that signs access tokens and the OIDC provider's keys
+var authKeys struct {
+	once       sync.Once
+	signingKey *rsa.PrivateKey
+	publicKey  JSONWebKey
+	oidc       *jwksSource
+}
+
+// ConfigureAuth sets the authentication settings and loads the signing key;
+// zero values keep the defaults
+func ConfigureAuth(settings AuthSettings) error {
+	if settings.Issuer != "" {
+		authSettings.Issuer = settings.Issuer
+	}
+	if settings.AccessTokenTTL > 0 {
+		authSettings.AccessTokenTTL = settings.AccessTokenTTL
+	}
+	if settings.RefreshTokenTTL > 0 {
+		authSettings.RefreshTokenTTL = settings.RefreshTokenTTL
+	}
+
+	oidc := settings.OIDC
+	if oidc.JWKSRefresh <= 0 {
+		oidc.JWKSRefresh = authSettings.OIDC.JWKSRefresh
+	}
+	if oidc.EmailClaim == "" {
+		oidc.EmailClaim = authSettings.OIDC.EmailClaim
+	}
+	if oidc.NameClaim == "" {
+		oidc.NameClaim = authSettings.OIDC.NameClaim
+	}
+	if oidc.DefaultRole == "" {
+		oidc.DefaultRole = authSettings.OIDC.DefaultRole
+	}
+	if models.RoleRank(oidc.DefaultRole) == 0 {
+		return fmt.Errorf("OIDC default role must be one of %s", strings.Join(models.Roles, ", "))
+	}
+	for value, role := range oidc.RoleMapping {
+		if models.RoleRank(role) == 0 {
+			return fmt.Errorf("OIDC role mapping %q: role must be one of %s", value, strings.Join(models.Roles, ", "))
+		}
+	}
+	authSettings.OIDC = oidc
+
+	switch {
+	case oidc.Issuer != "" && oidc.JWKS != "":
+		if oidc.Issuer == authSettings.Issuer {
+			return fmt.Errorf("OIDC issuer must differ from the login issuer %q", authSettings.Issuer)
+		}
+		if oidc.Audience == "" {
+			return errors.New("OIDC_AUDIENCE is required with OIDC_ISSUER and OIDC_JWKS; set it to this server's client ID")
+		}
+		authKeys.oidc = &jwksSource{location: oidc.JWKS, refresh: oidc.JWKSRefresh}
+		if err := authKeys.oidc.reload(); err != nil {
+			log.Printf("Failed to load OIDC key set, retrying on first use: %v", err)
+		}
+	case oidc.Issuer != "" || oidc.JWKS != "":
+		log.Println("OIDC login is disabled; set both OIDC_ISSUER and OIDC_JWKS to enable it")
+	}
+
+	var err error
+	authKeys.once.Do(func() {
+		err = loadSigningKey(settings.SigningKeyPath)
+	})
+	return err
+}
+
+// loadSigningKey loads the RSA key that signs access tokens from path,
+// generating and writing it when the file does not exist. Without a path the
+// key only lives in memory and issued tokens stop working after a restart.
+func loadSigningKey(path string) error {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			key, err := parseRSAPrivateKey(data)
+			if err != nil {
+				return fmt.Errorf("failed to load signing key from %s: %w", path, err)
+			}
+			setSigningKey(key)
+			return nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read signing key: %w", err)
+		}
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	setSigningKey(key)
+
+	if path == "" {
+		log.Println("Generated a temporary token signing key; set AUTH_SIGNING_KEY_PATH to keep sessions across restarts")
+		return nil
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	log.Printf("Generated token signing key %s", path)
+	return nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS #1 or PKCS #8 RSA private key
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// setSigningKey installs the key that signs access tokens
+func setSigningKey(key *rsa.PrivateKey) {
+	authKeys.signingKey = key
+	authKeys.publicKey = rsaJSONWebKey(&key.PublicKey)
+}
+
+// signingKey returns the key that signs access tokens, generating a temporary
+// one if ConfigureAuth was not called
+func signingKey() (*rsa.PrivateKey, JSONWebKey) {
+	authKeys.once.Do(func() {
+		if err := loadSigningKey(""); err != nil {
+			log.Printf("Token signing: %v", err)
+		}
+	})
+	return authKeys.signingKey, authKeys.publicKey
+}
+
+// AuthService handles password login, access and refresh tokens, and users
+// signing in with an OIDC provider
+type AuthService struct {
+	db *gorm.DB
+}
+
+// NewAuthService creates a new auth service instance
+func NewAuthService() *AuthService {
+	return &AuthService{
+		db: database.GetDB(),
+	}
+}
+
+// Login checks a user's email and password and issues an access token and a
+// refresh token
+func (s *AuthService) Login(email, password string) (*models.TokenResponse, error) {
+	var user models.User
+	err := s.db.Where("email = ?", strings.ToLower(strings.TrimSpace(email))).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Compare against a dummy hash for unknown users so response times do not reveal which emails exist
+	hash := user.PasswordHash
+	if hash == "" {
+		hash = dummyPasswordHash()
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil || user.PasswordHash == "" {
+		return nil, fmt.Errorf("%w: invalid email or password", ErrUnauthorized)
+	}
+	if !user.Active {
+		return nil, fmt.Errorf("%w: user is inactive", ErrUnauthorized)
+	}
+
+	var tokens *models.TokenResponse
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		tokens, _, err = issueTokens(tx, &user)
+		return err
+	})
+	return tokens, err
+}
+
+// Refresh exchanges a refresh token for a new access token and refresh token.
+// Each refresh token can be used once; presenting a used token again revokes
+// every refresh token of its user, since it may have been stolen.
+func (s *AuthService) Refresh(plain string) (*models.TokenResponse, error) {
+	var token models.RefreshToken
+	if err := s.db.Preload("User").Where("hash = ?", hashAPIKey(plain)).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: invalid refresh token", ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+
+	now := time.Now()
+	switch {
+	case token.RevokedAt != nil && token.ReplacedBy != nil:
+		if err := s.revokeUserTokens(token.UserID); err != nil {
+			return nil, err
+		}
+		log.Printf("Refresh token %d was reused; revoked all refresh tokens of user %d", token.ID, token.UserID)
+		return nil, fmt.Errorf("%w: refresh token has already been used", ErrUnauthorized)
+	case token.RevokedAt != nil:
+		return nil, fmt.Errorf("%w: refresh token has been revoked", ErrUnauthorized)
+	case !token.Active(now):
+		return nil, fmt.Errorf("%w: refresh token has expired", ErrUnauthorized)
+	case token.User == nil || !token.User.Active:
+		return nil, fmt.Errorf("%w: user is inactive", ErrUnauthorized)
+	}
+
+	var tokens *models.TokenResponse
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		issued, replacement, err := issueTokens(tx, token.User)
+		if err != nil {
+			return err
+		}
+		// Only one concurrent refresh with the same token may succeed
+		result := tx.Model(&models.RefreshToken{}).Where("id = ? AND revoked_at IS NULL", token.ID).
+			Updates(map[string]interface{}{"revoked_at": now, "replaced_by": replacement.ID})
+		if result.Error != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: refresh token has already been used", ErrUnauthorized)
+		}
+		tokens = issued
+		return nil
+	})
+	return tokens, err
+}
+
+// Logout revokes a refresh token; unknown and revoked tokens are ignored
+func (s *AuthService) Logout(plain string) error {
+	if err := s.db.Model(&models.RefreshToken{}).Where("hash = ? AND revoked_at IS NULL", hashAPIKey(plain)).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+// ChangePassword replaces a user's password after checking the current one
+// and revokes their refresh tokens, signing out other sessions
+func (s *AuthService) ChangePassword(user *models.User, current, password string) error {
+	var stored models.User
+	if err := s.db.Select("id", "password_hash").First(&stored, user.ID).Error; err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if stored.PasswordHash == "" || bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte(current)) != nil {
+		return fmt.Errorf("%w: current password is incorrect", ErrInvalidPassword)
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Model(&models.User{}).Where("id = ?", user.ID).Update("password_hash", hash).Error; err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return s.revokeUserTokens(user.ID)
+}
+
+// GetKeySet returns the public key that verifies issued access tokens, so the
+// login endpoint can serve as an identity provider for other services
+func (s *AuthService) GetKeySet() JSONWebKeySet {
+	_, publicKey := signingKey()
+	return JSONWebKeySet{Keys: []JSONWebKey{publicKey}}
+}
+
+// Authenticate resolves a bearer JWT to its active user. Access tokens from
+// the login endpoint are verified with the signing key; tokens from the OIDC
+// provider with its key set, provisioning the user on first use if enabled.
+func (s *AuthService) Authenticate(token string) (*models.User, error) {
+	parsed, err := parseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch issuer := parsed.claims.string("iss"); {
+	case issuer == authSettings.Issuer:
+		return s.authenticateAccessToken(parsed)
+	case authKeys.oidc != nil && issuer == authSettings.OIDC.Issuer:
+		return s.authenticateOIDCToken(parsed)
+	default:
+		return nil, fmt.Errorf("%w: unknown token issuer %q", ErrUnauthorized, issuer)
+	}
+}
+
+// authenticateAccessToken verifies an access token issued by Login or Refresh
+func (s *AuthService) authenticateAccessToken(token *parsedJWT) (*models.User, error) {
+	key, publicKey := signingKey()
+	if token.header.Kid != publicKey.Kid {
+		return nil, fmt.Errorf("%w: token was signed with an unknown key", ErrUnauthorized)
+	}
+	if err := token.verify(&key.PublicKey, publicKey.Alg); err != nil {
+		return nil, err
+	}
+	if err := token.claims.validate(authSettings.Issuer, authSettings.Issuer, time.Now()); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseUint(token.claims.string("sub"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid token subject", ErrUnauthorized)
+	}
+	var user models.User
+	if err := s.db.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: user no longer exists", ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.Active {
+		return nil, fmt.Errorf("%w: user is inactive", ErrUnauthorized)
+	}
+	return &user, nil
+}
+
+// authenticateOIDCToken verifies a token from the OIDC provider and maps its
+// claims to a user
+func (s *AuthService) authenticateOIDCToken(token *parsedJWT) (*models.User, error) {
+	key, err := authKeys.oidc.key(token.header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := token.verify(key.public, key.alg); err != nil {
+		return nil, err
+	}
+	settings := authSettings.OIDC
+	if err := token.claims.validate(settings.Issuer, settings.Audience, time.Now()); err != nil {
+		return nil, err
+	}
+
+	subject := token.claims.string("sub")
+	if subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthorized)
+	}
+	email := strings.ToLower(strings.TrimSpace(token.claims.string(settings.EmailClaim)))
+	// Only an email the provider vouches for may link or create a user; a
+	// token without email_verified is treated as unverified
+	if verified, _ := token.claims["email_verified"].(bool); !verified {
+		email = ""
+	}
+	role, syncRole := mapOIDCRole(token.claims)
+
+	var user models.User
+	err = s.db.Where("oidc_subject = ?", subject).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) && email != "" {
+		// Link an existing user with the same verified email address
+		err = s.db.Where("email = ? AND oidc_subject IS NULL", email).First(&user).Error
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return s.provisionOIDCUser(token.claims, subject, email, role)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	case !user.Active:
+		return nil, fmt.Errorf("%w: user is inactive", ErrUnauthorized)
+	}
+
+	updates := map[string]interface{}{}
+	if user.OIDCSubject == nil {
+		updates["oidc_subject"] = subject
+		user.OIDCSubject = &subject
+	}
+	if syncRole && user.Role != role {
+		log.Printf("Changed role of %s from %s to %s to match the OIDC provider", user.Email, user.Role, role)
+		updates["role"] = role
+		user.Role = role
+	}
+	if len(updates) > 0 {
+		if err := s.db.Model(&user).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+	return &user, nil
+}
+
+// provisionOIDCUser creates a user for an OIDC identity seen for the first
+// time and adds them to OIDC_DEFAULT_WORKSPACE, if set
+func (s *AuthService) provisionOIDCUser(claims jwtClaims, subject, email, role string) (*models.User, error) {
+	if !authSettings.OIDC.AutoProvision {
+		return nil, fmt.Errorf("%w: no user exists for this identity", ErrUnauthorized)
+	}
+	if email == "" {
+		return nil, fmt.Errorf("%w: token has no verified %s claim", ErrUnauthorized, authSettings.OIDC.EmailClaim)
+	}
+
+	name := strings.TrimSpace(claims.string(authSettings.OIDC.NameClaim))
+	if name == "" {
+		name = email
+	}
+	user := models.User{
+		Name:        name,
+		Email:       email,
+		Role:        role,
+		Active:      true,
+		OIDCSubject: &subject,
+		CreatedAt:   time.Now(),
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.User{}).Where("email = ?", email).Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check for an existing user: %w", err)
+		}
+		if existing > 0 {
+			return fmt.Errorf("%w: email %s belongs to a user linked to another identity", ErrUnauthorized, email)
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if authSettings.OIDC.DefaultWorkspace == 0 {
+			return nil
+		}
+		return addWorkspaceMember(tx, authSettings.OIDC.DefaultWorkspace, &user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Created user %s with role %s from the OIDC provider", user.Email, user.Role)
+	return &user, nil
+}
+
+// revokeUserTokens revokes every active refresh token of a user
+func (s *AuthService) revokeUserTokens(userID uint) error {
+	if err := s.db.Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// mapOIDCRole returns the most privileged role granted by the role claim and
+// whether the user's role should follow it. Users whose claim maps to no
+// role get the default role.
+func mapOIDCRole(claims jwtClaims) (string, bool) {
+	settings := authSettings.OIDC
+	if settings.RoleClaim == "" {
+		return settings.DefaultRole, false
+	}
+
+	role := settings.DefaultRole
+	for _, value := range claims.strings(settings.RoleClaim) {
+		mapped := strings.ToLower(value)
+		if len(settings.RoleMapping) > 0 {
+			mapped = settings.RoleMapping[value]
+		}
+		if models.RoleRank(mapped) > models.RoleRank(role) {
+			role = mapped
+		}
+	}
+	return role, true
+}
+
+// issueTokens creates a refresh token for a user and signs an access token
+func issueTokens(tx *gorm.DB, user *models.User) (*models.TokenResponse, *models.RefreshToken, error) {
+	now := time.Now()
+	key, publicKey := signingKey()
+	accessToken, err := signJWT(key, publicKey.Kid, jwtClaims{
+		"iss":   authSettings.Issuer,
+		"aud":   authSettings.Issuer,
+		"sub":   strconv.FormatUint(uint64(user.ID), 10),
+		"email": user.Email,
+		"name":  user.Name,
+		"role":  user.Role,
+		"iat":   now.Unix(),
+		"exp":   now.Add(authSettings.AccessTokenTTL).Unix(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	plain := "wrt_" + secret
+	refreshToken := models.RefreshToken{
+		UserID:    user.ID,
+		Hash:      hashAPIKey(plain),
+		ExpiresAt: now.Add(authSettings.RefreshTokenTTL),
+		CreatedAt: now,
+	}
+	if err := tx.Create(&refreshToken).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return &models.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(authSettings.AccessTokenTTL.Seconds()),
+		RefreshToken:     plain,
+		RefreshExpiresIn: int(authSettings.RefreshTokenTTL.Seconds()),
+		User:             user,
+	}, &refreshToken, nil
+}
+
+// dummyPasswordHash is compared against when a login names an unknown user
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("website-analyzer"), bcrypt.DefaultCost)
+	return string(hash)
+})
+
+// hashPassword validates a new password and returns its bcrypt hash
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("%w: password must be at least %d characters", ErrInvalidPassword, minPasswordLength)
+	}
+	if len(password) > 72 {
+		return "", fmt.Errorf("%w: password must be at most 72 bytes", ErrInvalidPassword)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// jwksSource loads and caches the OIDC provider's key set from a file or URL
+type jwksSource struct {
+	location string
+	refresh  time.Duration
+
+	mu       sync.Mutex
+	keys     map[string]jwksKey
+	loadedAt time.Time
+}
+
+// jwksKey is a provider key and the algorithm it declares, if any
+type jwksKey struct {
+	public crypto.PublicKey
+	alg    string
+}
+
+// key returns the provider key with the given ID, reloading the key set when
+// it is stale or the ID is unknown (the provider may have rotated its keys)
+func (j *jwksSource) key(kid string) (jwksKey, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key, ok := j.lookup(kid)
+	stale := time.Since(j.loadedAt) >= j.refresh
+	if !ok && time.Since(j.loadedAt) >= jwksRetryInterval {
+		stale = true
+	}
+	if stale {
+		if err := j.load(); err != nil {
+			if !ok {
+				return jwksKey{}, fmt.Errorf("failed to load OIDC key set: %w", err)
+			}
+			log.Printf("Failed to reload OIDC key set, using cached keys: %v", err)
+		}
+		key, ok = j.lookup(kid)
+	}
+	if !ok {
+		return jwksKey{}, fmt.Errorf("%w: token was signed with an unknown key", ErrUnauthorized)
+	}
+	return key, nil
+}
+
+// reload loads the key set now
+func (j *jwksSource) reload() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.load()
+}
+
+// lookup finds a cached key; tokens without a key ID match a single-key set
+func (j *jwksSource) lookup(kid string) (jwksKey, bool) {
+	if kid == "" && len(j.keys) == 1 {
+		for _, key := range j.keys {
+			return key, true
+		}
+	}
+	key, ok := j.keys[kid]
+	return key, ok
+}
+
+// load reads the key set and replaces the cached keys. Keys of unsupported
+// types or for encryption are skipped.
+func (j *jwksSource) load() error {
+	j.loadedAt = time.Now()
+
+	var data []byte
+	var err error
+	if strings.HasPrefix(j.location, "http://") || strings.HasPrefix(j.location, "https://") {
+		data, err = fetchJWKS(j.location)
+	} else {
+		data, err = os.ReadFile(j.location)
+	}
+	if err != nil {
+		return err
+	}
+
+	var set JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("invalid key set: %w", err)
+	}
+	keys := make(map[string]jwksKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Printf("Skipping OIDC key %q: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = jwksKey{public: key, alg: jwk.Alg}
+	}
+	if len(keys) == 0 {
+		return errors.New("key set contains no usable signing keys")
+	}
+	j.keys = keys
+	return nil
+}
+
+// fetchJWKS downloads a key set
+func fetchJWKS(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidBudget is returned when a budget definition fails validation
+var ErrInvalidBudget = errors.New("invalid performance budget")
+
+// budgetMetrics maps the metrics a budget can limit to their value in an analysis result
+var budgetMetrics = map[string]func(result *SEOAnalysisResult) float64{
+	"load_time":            func(r *SEOAnalysisResult) float64 { return r.LoadTime },
+	"page_size":            func(r *SEOAnalysisResult) float64 { return float64(r.PageSize) },
+	"total_requests":       func(r *SEOAnalysisResult) float64 { return float64(r.TotalRequests) },
+	"third_party_requests": func(r *SEOAnalysisResult) float64 { return float64(r.ThirdPartyRequests) },
+	"total_links":          func(r *SEOAnalysisResult) float64 { return float64(r.TotalLinks) },
+	"external_links":       func(r *SEOAnalysisResult) float64 { return float64(r.ExternalLinks) },
+	"broken_links":         func(r *SEOAnalysisResult) float64 { return float64(len(r.BrokenLinks)) },
+	"image_count":          func(r *SEOAnalysisResult) float64 { return float64(r.ImageCount) },
+	"script_count":         func(r *SEOAnalysisResult) float64 { return float64(len(r.Scripts)) },
+}
+
+// BudgetService handles business logic for performance budgets
+type BudgetService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewBudgetService creates a new budget service instance
+func NewBudgetService() *BudgetService {
+	return &BudgetService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *BudgetService) ForWorkspace(workspaceID uint) *BudgetService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllBudgets retrieves all performance budgets
+func (s *BudgetService) GetAllBudgets() ([]models.PerformanceBudget, error) {
+	var budgets []models.PerformanceBudget
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tag").Order("name ASC").Find(&budgets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	return budgets, nil
+}
+
+// GetBudgetByID retrieves a performance budget by its ID
+func (s *BudgetService) GetBudgetByID(id uint) (*models.PerformanceBudget, error) {
+	var budget models.PerformanceBudget
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tag").First(&budget, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("budget not found")
+		}
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+	return &budget, nil
+}
+
+// CreateBudget validates and creates a performance budget
+func (s *BudgetService) CreateBudget(req models.BudgetRequest) (*models.PerformanceBudget, error) {
+	budget := models.PerformanceBudget{
+		WorkspaceID: s.workspaceID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.applyRequest(&budget, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Omit("Tag").Create(&budget).Error; err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	return s.GetBudgetByID(budget.ID)
+}
+
+// UpdateBudget validates and replaces a performance budget
+func (s *BudgetService) UpdateBudget(id uint, req models.BudgetRequest) (*models.PerformanceBudget, error) {
+	budget, err := s.GetBudgetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(budget, req); err != nil {
+		return nil, err
+	}
+
+	budget.Tag = nil
+	if err := s.db.Omit("Tag").Save(budget).Error; err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	return s.GetBudgetByID(id)
+}
+
+// DeleteBudget deletes a performance budget by ID
+func (s *BudgetService) DeleteBudget(id uint) error {
+	result := s.db.Scopes(inWorkspace(s.workspaceID)).Delete(&models.PerformanceBudget{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete budget: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("budget not found")
+	}
+	return nil
+}
+
+// applyRequest validates a budget request and copies it onto the model
+func (s *BudgetService) applyRequest(budget *models.PerformanceBudget, req models.BudgetRequest) error {
+	name := strings.TrimSpace(req.Name)
+	tag := strings.TrimSpace(req.Tag)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBudget)
+	}
+	if (req.URLID == nil) == (tag == "") {
+		return fmt.Errorf("%w: exactly one of url_id or tag is required", ErrInvalidBudget)
+	}
+
+	seen := make(map[string]bool)
+	for i, limit := range req.Limits {
+		req.Limits[i].Metric = strings.ToLower(strings.TrimSpace(limit.Metric))
+		if _, ok := budgetMetrics[req.Limits[i].Metric]; !ok {
+			return fmt.Errorf("%w: unknown metric %q (supported: %s)", ErrInvalidBudget, limit.Metric, strings.Join(BudgetMetricNames(), ", "))
+		}
+		if seen[req.Limits[i].Metric] {
+			return fmt.Errorf("%w: metric %q is limited more than once", ErrInvalidBudget, req.Limits[i].Metric)
+		}
+		seen[req.Limits[i].Metric] = true
+		if limit.Max < 0 {
+			return fmt.Errorf("%w: max for %q must not be negative", ErrInvalidBudget, req.Limits[i].Metric)
+		}
+	}
+
+	budget.URLID = nil
+	budget.TagID = nil
+	if req.URLID != nil {
+		var url models.URL
+		if err := s.db.Scopes(inWorkspace(budget.WorkspaceID)).First(&url, *req.URLID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("URL not found")
+			}
+			return fmt.Errorf("failed to find URL: %w", err)
+		}
+		budget.URLID = req.URLID
+	} else {
+		tags, err := findOrCreateTags(s.db, budget.WorkspaceID, []string{tag})
+		if err != nil {
+			return err
+		}
+		budget.TagID = &tags[0].ID
+	}
+
+	limitsJSON, _ := json.Marshal(req.Limits)
+	budget.Name = name
+	budget.Limits = string(limitsJSON)
+	budget.Enabled = true
+	if req.Enabled != nil {
+		budget.Enabled = *req.Enabled
+	}
+	budget.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GetApplicableBudgets retrieves the enabled budgets of a URL's workspace for the URL and its tags
+func (s *BudgetService) GetApplicableBudgets(url *models.URL) ([]models.PerformanceBudget, error) {
+	tagIDs := make([]uint, 0, len(url.Tags))
+	for _, tag := range url.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	var budgets []models.PerformanceBudget
+	query := s.db.Where("workspace_id = ? AND enabled = ?", url.WorkspaceID, true)
+	if len(tagIDs) > 0 {
+		query = query.Where("url_id = ? OR tag_id IN ?", url.ID, tagIDs)
+	} else {
+		query = query.Where("url_id = ?", url.ID)
+	}
+	if err := query.Order("id ASC").Find(&budgets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	return budgets, nil
+}
+
+// EvaluateBudgets compares an analysis result against budgets, returning one result per limit
+func EvaluateBudgets(budgets []models.PerformanceBudget, result *SEOAnalysisResult) []models.BudgetResult {
+	results := []models.BudgetResult{}
+	for _, budget := range budgets {
+		for _, limit := range budget.GetLimits() {
+			value, ok := budgetMetrics[limit.Metric]
+			if !ok {
+				continue
+			}
+			actual := value(result)
+			budgetResult := models.BudgetResult{
+				BudgetID:   budget.ID,
+				BudgetName: budget.Name,
+				Metric:     limit.Metric,
+				Max:        limit.Max,
+				Actual:     actual,
+				Passed:     actual <= limit.Max,
+			}
+			if !budgetResult.Passed {
+				budgetResult.Overage = math.Round((actual-limit.Max)*1000) / 1000
+			}
+			results = append(results, budgetResult)
+		}
+	}
+	return results
+}
+
+// BudgetMetricNames returns the metrics a budget can limit
+func BudgetMetricNames() []string {
+	names := make([]string, 0, len(budgetMetrics))
+	for name := range budgetMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+
+	"website-analyzer-backend/models"
+)
+
+func TestCreateBudgetStoresDisabled(t *testing.T) {
+	db := newTestDB(t, &models.URL{}, &models.PerformanceBudget{})
+	url := models.URL{WorkspaceID: 1, URL: "https://example.com"}
+	if err := db.Create(&url).Error; err != nil {
+		t.Fatalf("creating URL: %v", err)
+	}
+	service := (&BudgetService{db: db}).ForWorkspace(1)
+
+	disabled := false
+	created, err := service.CreateBudget(models.BudgetRequest{
+		Name:    "Fast home page",
+		URLID:   &url.ID,
+		Limits:  []models.BudgetLimit{{Metric: "load_time", Max: 2}},
+		Enabled: &disabled,
+	})
+	if err != nil {
+		t.Fatalf("CreateBudget: %v", err)
+	}
+
+	var stored models.PerformanceBudget
+	if err := db.First(&stored, created.ID).Error; err != nil {
+		t.Fatalf("reading the budget back: %v", err)
+	}
+	if stored.Enabled {
+		t.Error("budget created disabled was stored enabled")
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"website-analyzer-backend/models"
+)
+
+// Built-in check names
+const (
+	CheckHTMLVersion   = "html-version"
+	CheckMeta          = "meta"
+	CheckHeadings      = "headings"
+	CheckImages        = "images"
+	CheckLinks         = "links"
+	CheckForms         = "forms"
+	CheckAccessibility = "accessibility"
+	CheckMobile        = "mobile"
+	CheckTechnologies  = "technologies"
+	CheckScripts       = "scripts"
+	CheckResources     = "resources"
+	CheckPerformance   = "performance"
+	CheckAuditRules    = "audit-rules"
+	CheckScore         = "score"
+)
+
+// builtinCheckNames holds the reserved names of the built-in checks
+var builtinCheckNames = map[string]bool{
+	CheckHTMLVersion:   true,
+	CheckMeta:          true,
+	CheckHeadings:      true,
+	CheckImages:        true,
+	CheckLinks:         true,
+	CheckForms:         true,
+	CheckAccessibility: true,
+	CheckMobile:        true,
+	CheckTechnologies:  true,
+	CheckScripts:       true,
+	CheckResources:     true,
+	CheckPerformance:   true,
+	CheckAuditRules:    true,
+	CheckScore:         true,
+}
+
+// funcCheck adapts a function to the Check interface
+type funcCheck struct {
+	name         string
+	dependencies []string
+	run          func(ctx context.Context, page *Page) ([]models.Finding, Metrics)
+}
+
+func (c funcCheck) Name() string           { return c.name }
+func (c funcCheck) Dependencies() []string { return c.dependencies }
+func (c funcCheck) Run(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+	return c.run(ctx, page)
+}
+
+// NewCheck creates a check from a name, its dependencies and a run function
+func NewCheck(name string, dependencies []string, run func(ctx context.Context, page *Page) ([]models.Finding, Metrics)) Check {
+	return funcCheck{name: name, dependencies: dependencies, run: run}
+}
+
+// builtinChecks returns the analyzer's built-in checks in their default order.
+// They report the problems they find as findings and fill in the typed fields
+// of the analysis result that are stored on the URL for filtering and scoring.
+func (s *SEOAnalyzer) builtinChecks() []Check {
+	return []Check{
+		NewCheck(CheckHTMLVersion, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			page.Result.HTMLVersion = s.detectHTMLVersion(page.Doc)
+			return nil, nil
+		}),
+		NewCheck(CheckMeta, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			page.Result.MetaTitle = s.extractMetaTitle(page.Doc)
+			page.Result.MetaDescription = s.extractMetaDescription(page.Doc)
+			page.Result.Noindex = s.detectNoindex(page.Doc, page.Header)
+			return nil, Metrics{
+				"title_length":       float64(len([]rune(page.Result.MetaTitle))),
+				"description_length": float64(len([]rune(page.Result.MetaDescription))),
+				"noindex":            boolMetric(page.Result.Noindex),
+			}
+		}),
+		NewCheck(CheckHeadings, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeHeadingTags(page.Doc, page.Result)
+			return nil, Metrics{
+				"h1_count": float64(page.Result.H1Count),
+				"h2_count": float64(page.Result.H2Count),
+			}
+		}),
+		NewCheck(CheckImages, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			page.Result.ImageCount = page.Doc.Find("img").Length()
+			return nil, Metrics{"image_count": float64(page.Result.ImageCount)}
+		}),
+		NewCheck(CheckLinks, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeLinks(ctx, page.Doc, page.URL, page.Result)
+			return nil, Metrics{
+				"total_links":    float64(page.Result.TotalLinks),
+				"internal_links": float64(page.Result.InternalLinks),
+				"external_links": float64(page.Result.ExternalLinks),
+				"broken_links":   float64(len(page.Result.BrokenLinks)),
+			}
+		}),
+		NewCheck(CheckForms, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeForms(page.Doc, page.URL, page.Result)
+			return formFindings(page.Result.Forms), Metrics{
+				"form_count":          float64(page.Result.FormCount),
+				"high_severity_forms": float64(page.Result.HighSeverityForms),
+			}
+		}),
+		NewCheck(CheckAccessibility, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeAccessibility(page.Doc, page.Result)
+			return accessibilityFindings(page.Result.AccessibilityViolations), Metrics{"violation_count": float64(page.Result.AccessibilityViolationCount)}
+		}),
+		NewCheck(CheckMobile, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeMobile(page.Doc, page.Result)
+			return mobileFindings(page.Result.Mobile.Issues), Metrics{
+				"issue_count":     float64(page.Result.Mobile.IssueCount),
+				"mobile_friendly": boolMetric(page.Result.Mobile.MobileFriendly),
+			}
+		}),
+		NewCheck(CheckTechnologies, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			page.Result.Technologies = s.techDetector.Detect(page.Header, page.Cookies, page.Doc)
+			return nil, Metrics{"technology_count": float64(len(page.Result.Technologies))}
+		}),
+		NewCheck(CheckScripts, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeScripts(page.Doc, page.URL, page.Result)
+			vulnerabilities := 0
+			for _, library := range page.Result.JSLibraries {
+				vulnerabilities += len(library.Vulnerabilities)
+			}
+			return vulnerabilityFindings(page.Result.JSLibraries), Metrics{
+				"script_count":        float64(len(page.Result.Scripts)),
+				"library_count":       float64(len(page.Result.JSLibraries)),
+				"vulnerability_count": float64(vulnerabilities),
+			}
+		}),
+		NewCheck(CheckResources, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.analyzeResources(page.Doc, page.URL, page.Result)
+			return nil, Metrics{
+				"total_requests":       float64(page.Result.TotalRequests),
+				"third_party_requests": float64(page.Result.ThirdPartyRequests),
+			}
+		}),
+		NewCheck(CheckPerformance, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			return nil, Metrics{
+				"load_time": page.Result.LoadTime,
+				"page_size": float64(page.Result.PageSize),
+			}
+		}),
+		NewCheck(CheckAuditRules, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			s.evaluateAuditRules(page.Doc, page.URL.String(), page.WorkspaceID, page.Result)
+			return nil, Metrics{"violation_count": float64(len(page.Result.RuleViolations))}
+		}),
+		// The score has no hard dependencies so that disabling a check does not
+		// disable scoring; it runs last and evaluates whatever data is available.
+		NewCheck(CheckScore, nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+			page.Result.Score = s.scoreEngine.Evaluate(page.Result)
+			metrics := Metrics{"score": float64(page.Result.Score.Score)}
+			for category, score := range page.Result.Score.Categories {
+				metrics["category."+category] = float64(score)
+			}
+			return nil, metrics
+		}),
+	}
+}
+
+// accessibilitySeverities maps WCAG impact levels to finding severities
+var accessibilitySeverities = map[string]string{
+	"critical": "critical",
+	"serious":  "high",
+	"moderate": "medium",
+	"minor":    "low",
+}
+
+// accessibilityFindings reports each accessibility violation as a finding
+func accessibilityFindings(violations []models.AccessibilityViolation) []models.Finding {
+	findings := make([]models.Finding, 0, len(violations))
+	for _, violation := range violations {
+		details, _ := json.Marshal(map[string]string{
+			"rule":  violation.Rule,
+			"wcag":  violation.WCAG,
+			"level": violation.Level,
+			"html":  violation.HTML,
+		})
+		findings = append(findings, models.Finding{
+			Severity: accessibilitySeverities[violation.Severity],
+			Message:  violation.Message,
+			Selector: violation.Selector,
+			Details:  string(details),
+		})
+	}
+	return findings
+}
+
+// formFindings reports each issue found on a form as a finding
+func formFindings(forms []models.FormReport) []models.Finding {
+	var findings []models.Finding
+	for _, form := range forms {
+		for _, issue := range form.Issues {
+			findings = append(findings, models.Finding{
+				Severity: issue.Severity,
+				Message:  issue.Message,
+				Selector: form.Selector,
+			})
+		}
+	}
+	return findings
+}
+
+// mobileFindings reports each mobile-friendliness issue as a finding
+func mobileFindings(issues []models.MobileIssue) []models.Finding {
+	findings := make([]models.Finding, 0, len(issues))
+	for _, issue := range issues {
+		details, _ := json.Marshal(map[string]string{"check": issue.Check})
+		findings = append(findings, models.Finding{
+			Severity: issue.Severity,
+			Message:  issue.Message,
+			Selector: issue.Selector,
+			Details:  string(details),
+		})
+	}
+	return findings
+}
+
+// vulnerabilityFindings reports each known vulnerability of a detected
+// JavaScript library as a finding
+func vulnerabilityFindings(libraries []models.JSLibrary) []models.Finding {
+	var findings []models.Finding
+	for _, library := range libraries {
+		for _, vulnerability := range library.Vulnerabilities {
+			details, _ := json.Marshal(map[string]interface{}{
+				"library":     library.Name,
+				"version":     library.Version,
+				"source":      library.Source,
+				"identifiers": vulnerability.Identifiers,
+				"below":       vulnerability.Below,
+				"info":        vulnerability.Info,
+			})
+			findings = append(findings, models.Finding{
+				Severity: vulnerability.Severity,
+				Message:  fmt.Sprintf("%s %s: %s", library.Name, library.Version, vulnerability.Summary),
+				Details:  string(details),
+			})
+		}
+	}
+	return findings
+}
+
+// boolMetric converts a boolean to a 0/1 metric value
+func boolMetric(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
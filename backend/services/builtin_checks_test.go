@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+
+	"website-analyzer-backend/models"
+)
+
+func TestBuiltinCheckFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []models.Finding
+		want     []models.Finding
+	}{
+		{
+			name: "accessibility",
+			findings: accessibilityFindings([]models.AccessibilityViolation{
+				{Rule: "image-alt", WCAG: "1.1.1", Level: "A", Severity: "critical", Message: "No alt", Selector: "img", HTML: "<img>"},
+				{Rule: "duplicate-id", WCAG: "4.1.1", Level: "A", Severity: "minor", Message: "Duplicate id"},
+			}),
+			want: []models.Finding{
+				{Severity: "critical", Message: "No alt", Selector: "img", Details: `{"html":"\u003cimg\u003e","level":"A","rule":"image-alt","wcag":"1.1.1"}`},
+				{Severity: "low", Message: "Duplicate id", Details: `{"html":"","level":"A","rule":"duplicate-id","wcag":"4.1.1"}`},
+			},
+		},
+		{
+			name: "forms",
+			findings: formFindings([]models.FormReport{
+				{Selector: "form#login", Issues: []models.FormIssue{{Severity: "high", Message: "Submits over HTTP"}}},
+				{Selector: "form#search"},
+			}),
+			want: []models.Finding{
+				{Severity: "high", Message: "Submits over HTTP", Selector: "form#login"},
+			},
+		},
+		{
+			name: "mobile",
+			findings: mobileFindings([]models.MobileIssue{
+				{Check: "viewport", Severity: "high", Message: "No viewport"},
+			}),
+			want: []models.Finding{
+				{Severity: "high", Message: "No viewport", Details: `{"check":"viewport"}`},
+			},
+		},
+		{
+			name: "vulnerable libraries",
+			findings: vulnerabilityFindings([]models.JSLibrary{
+				{Name: "jquery", Version: "1.8.0", Source: "inline", Vulnerabilities: []models.JSVulnerability{
+					{Identifiers: []string{"CVE-2012-6708"}, Severity: "medium", Summary: "XSS", Below: "1.9.0"},
+				}},
+				{Name: "react", Version: "18.2.0"},
+			}),
+			want: []models.Finding{
+				{Severity: "medium", Message: "jquery 1.8.0: XSS",
+					Details: `{"below":"1.9.0","identifiers":["CVE-2012-6708"],"info":null,"library":"jquery","source":"inline","version":"1.8.0"}`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.findings) != len(tt.want) {
+				t.Fatalf("findings = %+v, want %+v", tt.findings, tt.want)
+			}
+			for i := range tt.want {
+				if tt.findings[i] != tt.want[i] {
+					t.Errorf("finding %d = %+v, want %+v", i, tt.findings[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
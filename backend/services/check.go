@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"website-analyzer-backend/models"
+)
+
+// Check run statuses
+const (
+	CheckStatusCompleted = "completed"
+	CheckStatusDisabled  = "disabled"
+	CheckStatusSkipped   = "skipped"
+	CheckStatusTimeout   = "timeout"
+	CheckStatusFailed    = "failed"
+)
+
+// Page is the fetched and parsed page handed to every check
+type Page struct {
+	URL        *url.URL
+	Doc        *goquery.Document
+	Header     http.Header
+	Cookies    []*http.Cookie
+	StatusCode int
+	LoadTime   float64
+
+	// WorkspaceID is the workspace of the analyzed URL, whose audit rules apply
+	WorkspaceID uint
+
+	// Result holds the output of the checks that have already run. Built-in
+	// checks fill in its typed fields; extension checks may read them.
+	Result *SEOAnalysisResult
+}
+
+// Metrics maps metric names to values reported by a check
+type Metrics map[string]float64
+
+// Check is a single, self-contained analysis step
+//
+// Run should return promptly once ctx is done. The pipeline does not wait for
+// a check past its timeout: it records it as timed out, discards whatever it
+// returns later and skips the checks that depend on it. Findings need only
+// Severity, Message and optionally Selector and Details; the pipeline fills in
+// the rest.
+type Check interface {
+	Name() string
+	Dependencies() []string
+	Run(ctx context.Context, page *Page) ([]models.Finding, Metrics)
+}
+
+// CheckSettings controls which checks run and how long they may take
+type CheckSettings struct {
+	Disabled []string                 // names of checks that are not run
+	Timeout  time.Duration            // default per-check timeout (0 disables it)
+	Timeouts map[string]time.Duration // per-check overrides
+}
+
+// CheckInfo describes a check and its effective settings
+type CheckInfo struct {
+	Name         string   `json:"name"`
+	Dependencies []string `json:"dependencies"`
+	Builtin      bool     `json:"builtin"`
+	Enabled      bool     `json:"enabled"`
+	Timeout      string   `json:"timeout"`
+}
+
+// CheckRegistry holds extension checks and the settings applied to all checks
+type CheckRegistry struct {
+	mu       sync.RWMutex
+	checks   []Check
+	settings CheckSettings
+}
+
+var checkRegistry = &CheckRegistry{
+	settings: CheckSettings{Timeout: 30 * time.Second},
+}
+
+// RegisterCheck adds an extension check to the pipeline. It is meant to be
+// called from init functions and panics on a nil check or a duplicate name.
+func RegisterCheck(check Check) {
+	checkRegistry.mu.Lock()
+	defer checkRegistry.mu.Unlock()
+
+	if check == nil {
+		panic("services: RegisterCheck check is nil")
+	}
+	if builtinCheckNames[check.Name()] {
+		panic("services: RegisterCheck called with built-in check name " + check.Name())
+	}
+	for _, existing := range checkRegistry.checks {
+		if existing.Name() == check.Name() {
+			panic("services: RegisterCheck called twice for check " + check.Name())
+		}
+	}
+	checkRegistry.checks = append(checkRegistry.checks, check)
+}
+
+// ConfigureChecks sets which checks are disabled and their timeouts
+func ConfigureChecks(settings CheckSettings) {
+	checkRegistry.mu.Lock()
+	defer checkRegistry.mu.Unlock()
+
+	checkRegistry.settings = settings
+}
+
+// registeredChecks returns the extension checks and the current settings
+func (r *CheckRegistry) registeredChecks() ([]Check, CheckSettings) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := make([]Check, len(r.checks))
+	copy(checks, r.checks)
+	return checks, r.settings
+}
+
+// enabled reports whether a check is enabled
+func (cs CheckSettings) enabled(name string) bool {
+	for _, disabled := range cs.Disabled {
+		if strings.EqualFold(strings.TrimSpace(disabled), name) {
+			return false
+		}
+	}
+	return true
+}
+
+// timeout returns the effective timeout for a check
+func (cs CheckSettings) timeout(name string) time.Duration {
+	if timeout, ok := cs.Timeouts[name]; ok {
+		return timeout
+	}
+	return cs.Timeout
+}
+
+// Checks describes the full pipeline, built-in checks first
+func (s *SEOAnalyzer) Checks() []CheckInfo {
+	builtins := s.builtinChecks()
+	extensions, settings := checkRegistry.registeredChecks()
+
+	infos := make([]CheckInfo, 0, len(builtins)+len(extensions))
+	for _, check := range orderChecks(append(builtins, extensions...)) {
+		info := CheckInfo{
+			Name:         check.Name(),
+			Dependencies: check.Dependencies(),
+			Enabled:      settings.enabled(check.Name()),
+			Timeout:      "none",
+		}
+		if info.Dependencies == nil {
+			info.Dependencies = []string{}
+		}
+		for _, builtin := range builtins {
+			if builtin.Name() == check.Name() {
+				info.Builtin = true
+				break
+			}
+		}
+		if timeout := settings.timeout(check.Name()); timeout > 0 {
+			info.Timeout = timeout.String()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// runChecks runs the built-in and extension checks against a page in dependency order
+func (s *SEOAnalyzer) runChecks(ctx context.Context, page *Page) {
+	extensions, settings := checkRegistry.registeredChecks()
+	checks := orderChecks(append(s.builtinChecks(), extensions...))
+
+	result := page.Result
+	result.Findings = []models.Finding{}
+	result.Metrics = []models.Metric{}
+	result.CheckResults = make([]models.CheckResult, 0, len(checks))
+	ran := make(map[string]bool)
+
+	for _, check := range checks {
+		name := check.Name()
+		checkResult := models.CheckResult{Name: name}
+
+		if !settings.enabled(name) {
+			checkResult.Status = CheckStatusDisabled
+			result.CheckResults = append(result.CheckResults, checkResult)
+			continue
+		}
+
+		var missing []string
+		for _, dependency := range check.Dependencies() {
+			if !ran[dependency] {
+				missing = append(missing, dependency)
+			}
+		}
+		if len(missing) > 0 {
+			checkResult.Status = CheckStatusSkipped
+			checkResult.Message = "Dependencies did not run: " + strings.Join(missing, ", ")
+			result.CheckResults = append(result.CheckResults, checkResult)
+			continue
+		}
+
+		startTime := time.Now()
+		findings, metrics, err := runCheck(ctx, check, page, settings.timeout(name))
+		checkResult.DurationMs = time.Since(startTime).Milliseconds()
+
+		switch {
+		case err == context.DeadlineExceeded:
+			checkResult.Status = CheckStatusTimeout
+			checkResult.Message = fmt.Sprintf("Check did not finish within %s", settings.timeout(name))
+		case err != nil:
+			checkResult.Status = CheckStatusFailed
+			checkResult.Message = err.Error()
+			log.Printf("Check %s failed: %v", name, err)
+		default:
+			checkResult.Status = CheckStatusCompleted
+			ran[name] = true
+		}
+
+		for _, finding := range findings {
+			finding.Check = name
+			if finding.Severity == "" {
+				finding.Severity = "medium"
+			}
+			result.Findings = append(result.Findings, finding)
+		}
+		checkResult.FindingCount = len(findings)
+
+		metricNames := make([]string, 0, len(metrics))
+		for metricName := range metrics {
+			metricNames = append(metricNames, metricName)
+		}
+		sort.Strings(metricNames)
+		for _, metricName := range metricNames {
+			result.Metrics = append(result.Metrics, models.Metric{
+				Check: name,
+				Name:  metricName,
+				Value: metrics[metricName],
+			})
+		}
+
+		result.CheckResults = append(result.CheckResults, checkResult)
+	}
+}
+
+// checkOutcome is what a check returned, or the panic it raised
+type checkOutcome struct {
+	findings []models.Finding
+	metrics  Metrics
+	err      error
+}
+
+// runCheck runs a single check with its timeout, converting panics into errors.
+// The check runs in its own goroutine against a copy of the page result, which
+// is only written back if it finishes in time; a check that overruns keeps
+// running in the background but can no longer change the analysis.
+func runCheck(parent context.Context, check Check, page *Page, timeout time.Duration) ([]models.Finding, Metrics, error) {
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+
+	result := *page.Result
+	checkPage := *page
+	checkPage.Result = &result
+
+	done := make(chan checkOutcome, 1)
+	go func() {
+		var outcome checkOutcome
+		defer func() {
+			if r := recover(); r != nil {
+				outcome = checkOutcome{err: fmt.Errorf("check panicked: %v", r)}
+			}
+			done <- outcome
+		}()
+		outcome.findings, outcome.metrics = check.Run(ctx, &checkPage)
+	}()
+
+	select {
+	case outcome := <-done:
+		if outcome.err != nil {
+			return nil, nil, outcome.err
+		}
+		// A check that gave up because its time ran out has not completed
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		*page.Result = result
+		return outcome.findings, outcome.metrics, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// orderChecks orders checks so that each runs after its dependencies, keeping
+// the given order otherwise. Checks in a dependency cycle keep their relative
+// order and are skipped at run time.
+func orderChecks(checks []Check) []Check {
+	byName := make(map[string]Check, len(checks))
+	for _, check := range checks {
+		byName[check.Name()] = check
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(checks))
+	ordered := make([]Check, 0, len(checks))
+
+	var visit func(check Check)
+	visit = func(check Check) {
+		if state[check.Name()] != 0 {
+			return
+		}
+		state[check.Name()] = visiting
+		for _, dependency := range check.Dependencies() {
+			if dep, ok := byName[dependency]; ok {
+				visit(dep)
+			}
+		}
+		state[check.Name()] = visited
+		ordered = append(ordered, check)
+	}
+
+	for _, check := range checks {
+		visit(check)
+	}
+	return ordered
+}
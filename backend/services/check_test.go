@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"website-analyzer-backend/models"
+)
+
+func TestRunCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	tests := []struct {
+		name      string
+		run       func(ctx context.Context, page *Page) ([]models.Finding, Metrics)
+		wantErr   string
+		wantTitle string
+	}{
+		{
+			name: "completed",
+			run: func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+				page.Result.MetaTitle = "set"
+				return []models.Finding{{Message: "found"}}, nil
+			},
+			wantTitle: "set",
+		},
+		{
+			// The pipeline moves on without waiting, and the late write is lost
+			name: "ignores its timeout",
+			run: func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+				<-release
+				page.Result.MetaTitle = "late"
+				return []models.Finding{{Message: "late"}}, nil
+			},
+			wantErr:   context.DeadlineExceeded.Error(),
+			wantTitle: "original",
+		},
+		{
+			name: "gives up at its timeout",
+			run: func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+				page.Result.MetaTitle = "partial"
+				<-ctx.Done()
+				return []models.Finding{{Message: "partial"}}, nil
+			},
+			wantErr:   context.DeadlineExceeded.Error(),
+			wantTitle: "original",
+		},
+		{
+			name: "panics",
+			run: func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+				page.Result.MetaTitle = "panicked"
+				panic("boom")
+			},
+			wantErr:   "check panicked: boom",
+			wantTitle: "original",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &Page{Result: &SEOAnalysisResult{MetaTitle: "original"}}
+			findings, _, err := runCheck(context.Background(), NewCheck("test", nil, tt.run), page, 20*time.Millisecond)
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("runCheck: %v", err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runCheck = %v, want %q", err, tt.wantErr)
+				}
+				if findings != nil {
+					t.Errorf("findings = %v, want none after %v", findings, err)
+				}
+			}
+			if page.Result.MetaTitle != tt.wantTitle {
+				t.Errorf("MetaTitle = %q, want %q", page.Result.MetaTitle, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestRunCheckCanceled(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	check := NewCheck("test", nil, func(ctx context.Context, page *Page) ([]models.Finding, Metrics) {
+		<-ctx.Done()
+		return nil, nil
+	})
+	_, _, err := runCheck(parent, check, &Page{Result: &SEOAnalysisResult{}}, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("runCheck = %v, want context.Canceled", err)
+	}
+}
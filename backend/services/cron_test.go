@@ -0,0 +1,145 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// Sunday, 1 March 2026, 10:30
+	from := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		want       []time.Time // the next runs, in order
+	}{
+		{
+			name:       "every 15 minutes",
+			expression: "*/15 * * * *",
+			want: []time.Time{
+				time.Date(2026, 3, 1, 10, 45, 0, 0, time.UTC),
+				time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "step within a range",
+			expression: "0 8-17/4 * * *",
+			want: []time.Time{
+				time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "step from a start value",
+			expression: "50/5 10 * * *",
+			want: []time.Time{
+				time.Date(2026, 3, 1, 10, 50, 0, 0, time.UTC),
+				time.Date(2026, 3, 1, 10, 55, 0, 0, time.UTC),
+				time.Date(2026, 3, 2, 10, 50, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "list and names",
+			expression: "0 9 * jan,mar mon-wed",
+			want: []time.Time{
+				time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "sunday as 7",
+			expression: "0 6 * * 7",
+			want: []time.Time{
+				time.Date(2026, 3, 8, 6, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 15, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "weekday range ending on 7",
+			expression: "0 6 * * 5-7",
+			want: []time.Time{
+				time.Date(2026, 3, 6, 6, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 7, 6, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 8, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// With both day fields restricted, either one matching is enough
+			name:       "day of month or day of week",
+			expression: "0 0 13 * fri",
+			want: []time.Time{
+				time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "day that only some months have",
+			expression: "0 0 31 * *",
+			want: []time.Time{
+				time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "macro in any case",
+			expression: " @Monthly ",
+			want: []time.Time{
+				time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 feb *",
+			want:       []time.Time{time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:       "never",
+			expression: "0 0 30 feb *",
+			want:       []time.Time{{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expression, err)
+			}
+			next := from
+			for i, want := range tt.want {
+				next = schedule.Next(next)
+				if !next.Equal(want) {
+					t.Fatalf("run %d = %v, want %v", i+1, next, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"* * * * monday",
+		"@reboot",
+	} {
+		if _, err := ParseCron(expression); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expression)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidFolder is returned when a folder definition fails validation
+var ErrInvalidFolder = errors.New("invalid folder")
+
+// maxFolderNameLength matches the size of the folders.name column
+const maxFolderNameLength = 100
+
+// FolderService handles business logic for the folder hierarchy
+type FolderService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewFolderService creates a new folder service instance
+func NewFolderService() *FolderService {
+	return &FolderService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *FolderService) ForWorkspace(workspaceID uint) *FolderService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllFolders retrieves every folder in the workspace, ordered by path
+func (s *FolderService) GetAllFolders() ([]models.FolderResponse, error) {
+	tree, err := loadFolderTree(s.db, s.workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.describe(tree, tree.sorted())
+}
+
+// GetFolderByID retrieves a folder by its ID
+func (s *FolderService) GetFolderByID(id uint) (*models.FolderResponse, error) {
+	tree, err := loadFolderTree(s.db, s.workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	folder, ok := tree.folders[id]
+	if !ok {
+		return nil, errors.New("folder not found")
+	}
+
+	responses, err := s.describe(tree, []models.Folder{folder})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// CreateFolder validates and creates a folder
+func (s *FolderService) CreateFolder(req models.FolderRequest) (*models.FolderResponse, error) {
+	folder := models.Folder{
+		WorkspaceID: s.workspaceID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.applyRequest(&folder, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&folder).Error; err != nil {
+		return nil, fmt.Errorf("failed to create folder: %w", err)
+	}
+
+	return s.GetFolderByID(folder.ID)
+}
+
+// UpdateFolder validates and replaces a folder, which may move it and its
+// contents under another parent
+func (s *FolderService) UpdateFolder(id uint, req models.FolderRequest) (*models.FolderResponse, error) {
+	var folder models.Folder
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&folder, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("folder not found")
+		}
+		return nil, fmt.Errorf("failed to get folder: %w", err)
+	}
+	if err := s.applyRequest(&folder, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(&folder).Error; err != nil {
+		return nil, fmt.Errorf("failed to update folder: %w", err)
+	}
+
+	return s.GetFolderByID(id)
+}
+
+// DeleteFolder deletes a folder. Its URLs and sub-folders move up to its
+// parent, or to the top level.
+func (s *FolderService) DeleteFolder(id uint) error {
+	var folder models.Folder
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&folder, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("folder not found")
+		}
+		return fmt.Errorf("failed to get folder: %w", err)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// URLs in the trash move too, so restoring them never refers to a deleted folder
+		if err := tx.Unscoped().Model(&models.URL{}).Where("folder_id = ?", folder.ID).Update("folder_id", folder.ParentID).Error; err != nil {
+			return fmt.Errorf("failed to move URLs out of folder: %w", err)
+		}
+		if err := tx.Model(&models.Folder{}).Where("parent_id = ?", folder.ID).Update("parent_id", folder.ParentID).Error; err != nil {
+			return fmt.Errorf("failed to move sub-folders out of folder: %w", err)
+		}
+		if err := tx.Delete(&models.Folder{}, folder.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete folder: %w", err)
+		}
+		return nil
+	})
+}
+
+// applyRequest validates a folder request and copies it onto the model
+func (s *FolderService) applyRequest(folder *models.Folder, req models.FolderRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidFolder)
+	}
+	if len(name) > maxFolderNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidFolder, maxFolderNameLength)
+	}
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("%w: name cannot contain '/'", ErrInvalidFolder)
+	}
+
+	tree, err := loadFolderTree(s.db, folder.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	var parentID *uint
+	if req.ParentID != nil && *req.ParentID != 0 {
+		if _, ok := tree.folders[*req.ParentID]; !ok {
+			return errors.New("parent folder not found")
+		}
+		if folder.ID != 0 && tree.contains(folder.ID, *req.ParentID) {
+			return fmt.Errorf("%w: a folder cannot be moved into itself or one of its sub-folders", ErrInvalidFolder)
+		}
+		parentID = req.ParentID
+	}
+
+	for _, sibling := range tree.children[parentKey(parentID)] {
+		if sibling.ID != folder.ID && strings.EqualFold(sibling.Name, name) {
+			return errors.New("folder already exists")
+		}
+	}
+
+	folder.Name = name
+	folder.ParentID = parentID
+	folder.UpdatedAt = time.Now()
+	return nil
+}
+
+// describe converts folders to responses with their path and URL count
+func (s *FolderService) describe(tree *folderTree, folders []models.Folder) ([]models.FolderResponse, error) {
+	ids := make([]uint, 0, len(folders))
+	for _, folder := range folders {
+		ids = append(ids, folder.ID)
+	}
+
+	counts := make(map[uint]int64, len(ids))
+	if len(ids) > 0 {
+		var rows []struct {
+			FolderID uint
+			URLCount int64
+		}
+		if err := s.db.Model(&models.URL{}).
+			Select("folder_id, COUNT(*) AS url_count").
+			Where("folder_id IN ?", ids).
+			Group("folder_id").
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to count URLs in folders: %w", err)
+		}
+		for _, row := range rows {
+			counts[row.FolderID] = row.URLCount
+		}
+	}
+
+	responses := make([]models.FolderResponse, 0, len(folders))
+	for _, folder := range folders {
+		responses = append(responses, models.FolderResponse{
+			ID:          folder.ID,
+			WorkspaceID: folder.WorkspaceID,
+			ParentID:    folder.ParentID,
+			Name:        folder.Name,
+			Path:        tree.path(folder.ID),
+			URLCount:    counts[folder.ID],
+			CreatedAt:   folder.CreatedAt,
+			UpdatedAt:   folder.UpdatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// folderTree is a workspace's folder hierarchy held in memory; workspaces have
+// few enough folders that walking it is cheaper than recursive queries
+type folderTree struct {
+	folders  map[uint]models.Folder
+	children map[uint][]models.Folder // keyed by parent ID, 0 for the top level
+}
+
+// loadFolderTree loads every folder in a workspace
+func loadFolderTree(db *gorm.DB, workspaceID uint) (*folderTree, error) {
+	var folders []models.Folder
+	if err := db.Scopes(inWorkspace(workspaceID)).Order("name ASC").Find(&folders).Error; err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+
+	tree := &folderTree{
+		folders:  make(map[uint]models.Folder, len(folders)),
+		children: make(map[uint][]models.Folder),
+	}
+	for _, folder := range folders {
+		tree.folders[folder.ID] = folder
+		key := parentKey(folder.ParentID)
+		tree.children[key] = append(tree.children[key], folder)
+	}
+	return tree, nil
+}
+
+// parentKey returns the children key of a parent ID
+func parentKey(parentID *uint) uint {
+	if parentID == nil {
+		return 0
+	}
+	return *parentID
+}
+
+// path returns the names from the top level down to a folder, joined by "/"
+func (t *folderTree) path(id uint) string {
+	var names []string
+	seen := make(map[uint]bool)
+	for folder, ok := t.folders[id]; ok && !seen[folder.ID]; folder, ok = t.folders[parentKey(folder.ParentID)] {
+		seen[folder.ID] = true
+		names = append([]string{folder.Name}, names...)
+	}
+	return strings.Join(names, "/")
+}
+
+// contains reports whether a folder is ancestor or one of its descendants
+func (t *folderTree) contains(ancestor, id uint) bool {
+	for _, descendant := range t.descendants(ancestor) {
+		if descendant == id {
+			return true
+		}
+	}
+	return false
+}
+
+// descendants returns a folder's ID followed by the IDs of all folders below it
+func (t *folderTree) descendants(id uint) []uint {
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			ids = append(ids, child.ID)
+		}
+	}
+	return ids
+}
+
+// sorted returns every folder depth-first, each level ordered by name
+func (t *folderTree) sorted() []models.Folder {
+	folders := make([]models.Folder, 0, len(t.folders))
+	var walk func(parent uint)
+	walk = func(parent uint) {
+		for _, child := range t.children[parent] {
+			folders = append(folders, child)
+			walk(child.ID)
+		}
+	}
+	walk(0)
+	return folders
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"website-analyzer-backend/models"
+)
+
+// Form classifications
+const (
+	FormTypeLogin      = "login"
+	FormTypeSignup     = "signup"
+	FormTypeSearch     = "search"
+	FormTypeNewsletter = "newsletter"
+	FormTypeContact    = "contact"
+	FormTypeOther      = "other"
+)
+
+var (
+	csrfFieldPattern    = regexp.MustCompile(`(?i)(csrf|xsrf|authenticity_token|requestverificationtoken|_token$|^token$|nonce|form_key|formkey)`)
+	signupKeywords      = []string{"register", "signup", "sign-up", "sign_up", "create-account", "createaccount", "join"}
+	searchKeywords      = []string{"search", "query"}
+	newsletterKeywords  = []string{"newsletter", "subscribe", "subscription", "mailing", "mc-embedded"}
+	contactKeywords     = []string{"contact", "enquiry", "inquiry", "feedback", "message", "support"}
+	searchFieldNames    = map[string]bool{"q": true, "s": true, "query": true, "search": true, "keyword": true, "keywords": true}
+	captchaProviderHint = []struct {
+		selector string
+		provider string
+	}{
+		{".g-recaptcha, [name='g-recaptcha-response']", "reCAPTCHA"},
+		{".h-captcha, [name='h-captcha-response']", "hCaptcha"},
+		{".cf-turnstile, [name='cf-turnstile-response']", "Cloudflare Turnstile"},
+		{".frc-captcha", "Friendly Captcha"},
+		{"[data-sitekey]", "unknown"},
+		{"input[name*='captcha'], img[src*='captcha']", "custom"},
+	}
+)
+
+// buildFormReport builds the detailed report for a single form
+func (s *SEOAnalyzer) buildFormReport(form *goquery.Selection, baseURL *url.URL, isLoginForm bool) models.FormReport {
+	report := models.FormReport{
+		Selector: cssSelectorPath(form),
+		Method:   strings.ToUpper(strings.TrimSpace(form.AttrOr("method", "GET"))),
+		Fields:   []models.FormField{},
+		Issues:   []models.FormIssue{},
+	}
+	if report.Method == "" {
+		report.Method = "GET"
+	}
+
+	// Resolve the action against the page URL (an empty action submits to the page itself)
+	actionURL := baseURL
+	if action := strings.TrimSpace(form.AttrOr("action", "")); action != "" {
+		if parsed, err := url.Parse(action); err == nil {
+			actionURL = baseURL.ResolveReference(parsed)
+		}
+	}
+	report.Action = actionURL.String()
+	report.CrossOrigin = actionURL.Host != "" && !sameOrigin(actionURL, baseURL)
+	report.InsecureAction = strings.EqualFold(actionURL.Scheme, "http")
+
+	// Field inventory
+	passwordFields := 0
+	var hasEmail, hasTextarea, hasSearchField bool
+	form.Find("input, select, textarea").Each(func(i int, sel *goquery.Selection) {
+		tag := goquery.NodeName(sel)
+		field := models.FormField{
+			Tag:          tag,
+			Type:         tag,
+			Name:         sel.AttrOr("name", ""),
+			Autocomplete: sel.AttrOr("autocomplete", ""),
+		}
+		_, field.Required = sel.Attr("required")
+		if tag == "input" {
+			field.Type = inputType(sel)
+		}
+
+		switch field.Type {
+		case "password":
+			passwordFields++
+			if field.Autocomplete == "" {
+				addFormIssue(&report, "low", "Password field '"+field.Name+"' has no autocomplete attribute (use current-password or new-password)")
+			}
+		case "email":
+			hasEmail = true
+		case "search":
+			hasSearchField = true
+		case "textarea":
+			hasTextarea = true
+		case "hidden":
+			if !report.HasCSRFToken && csrfFieldPattern.MatchString(field.Name) && sel.AttrOr("value", "") != "" {
+				report.HasCSRFToken = true
+				report.CSRFTokenName = field.Name
+			}
+		}
+		if searchFieldNames[strings.ToLower(field.Name)] {
+			hasSearchField = true
+		}
+		if strings.Contains(strings.ToLower(field.Name), "email") {
+			hasEmail = true
+		}
+
+		report.Fields = append(report.Fields, field)
+	})
+	report.HasPassword = passwordFields > 0
+
+	// CAPTCHA presence
+	for _, hint := range captchaProviderHint {
+		if form.Find(hint.selector).Length() > 0 {
+			report.HasCaptcha = true
+			report.CaptchaProvider = hint.provider
+			break
+		}
+	}
+
+	// Classification
+	hints := strings.ToLower(strings.Join([]string{
+		form.AttrOr("id", ""),
+		form.AttrOr("class", ""),
+		form.AttrOr("name", ""),
+		form.AttrOr("action", ""),
+		form.AttrOr("role", ""),
+		form.Find("button, input[type='submit']").Text(),
+		form.Find("input[type='submit']").AttrOr("value", ""),
+	}, " "))
+
+	visibleFields := 0
+	for _, field := range report.Fields {
+		switch field.Type {
+		case "hidden", "submit", "button", "reset", "image":
+		default:
+			visibleFields++
+		}
+	}
+
+	switch {
+	case passwordFields >= 2 || (passwordFields == 1 && containsAny(hints, signupKeywords)):
+		report.Type = FormTypeSignup
+	case isLoginForm || passwordFields == 1:
+		report.Type = FormTypeLogin
+	case hasSearchField || containsAny(hints, searchKeywords):
+		report.Type = FormTypeSearch
+	case hasEmail && !hasTextarea && (visibleFields <= 2 || containsAny(hints, newsletterKeywords)):
+		report.Type = FormTypeNewsletter
+	case hasTextarea || containsAny(hints, contactKeywords):
+		report.Type = FormTypeContact
+	default:
+		report.Type = FormTypeOther
+	}
+
+	// Security checks
+	if report.HasPassword {
+		if report.InsecureAction {
+			addFormIssue(&report, "high", "Credentials are submitted over plain HTTP")
+		}
+		if report.CrossOrigin && !strings.EqualFold(actionURL.Host, baseURL.Host) {
+			addFormIssue(&report, "high", "Credentials are submitted to a third-party origin ("+actionURL.Host+")")
+		}
+		if report.Method == "GET" {
+			addFormIssue(&report, "medium", "Credentials are submitted with GET and will appear in URLs and logs")
+		}
+	} else if report.InsecureAction && report.Type != FormTypeSearch {
+		addFormIssue(&report, "medium", "Form data is submitted over plain HTTP")
+	}
+
+	if report.Method == "POST" && report.Type != FormTypeSearch && !report.HasCSRFToken {
+		addFormIssue(&report, "low", "No CSRF-like hidden token found on a POST form")
+	}
+
+	if (report.Type == FormTypeSignup || report.Type == FormTypeContact || report.Type == FormTypeNewsletter) && !report.HasCaptcha {
+		addFormIssue(&report, "low", "No CAPTCHA detected on a "+report.Type+" form")
+	}
+
+	return report
+}
+
+// addFormIssue records an issue and tracks the highest severity on the report
+func addFormIssue(report *models.FormReport, severity, message string) {
+	report.Issues = append(report.Issues, models.FormIssue{
+		Severity: severity,
+		Message:  message,
+	})
+	report.Severity = HighestSeverity(report.Severity, severity)
+}
+
+// sameOrigin reports whether two URLs share scheme, host and port
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// containsAny reports whether value contains any of the keywords
+func containsAny(value string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(value, keyword) {
+			return true
+		}
+	}
+	return false
+}
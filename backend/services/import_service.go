@@ -70,8 +70,8 @@ func (s *ImportService) parseCSVFile(reader io.Reader) (*ImportResult, error) {
 		Errors: make([]string, 0),
 	}
 
-	// Find column indices for title and url
-	titleCol, urlCol, err := s.findCSVColumns(records[0])
+	// Find column indices for title, url and the optional tags column
+	titleCol, urlCol, tagsCol, err := s.findCSVColumns(records[0])
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +103,7 @@ func (s *ImportService) parseCSVFile(reader io.Reader) (*ImportResult, error) {
 		result.URLs = append(result.URLs, models.URLCreateRequest{
 			URL:   urlStr,
 			Title: title,
+			Tags:  splitTagsCell(record, tagsCol),
 		})
 	}
 
@@ -139,8 +140,8 @@ func (s *ImportService) parseExcelFile(reader io.Reader, size int64) (*ImportRes
 		Errors: make([]string, 0),
 	}
 
-	// Find column indices for title and url
-	titleCol, urlCol, err := s.findCSVColumns(rows[0])
+	// Find column indices for title, url and the optional tags column
+	titleCol, urlCol, tagsCol, err := s.findCSVColumns(rows[0])
 	if err != nil {
 		return nil, err
 	}
@@ -172,16 +173,19 @@ func (s *ImportService) parseExcelFile(reader io.Reader, size int64) (*ImportRes
 		result.URLs = append(result.URLs, models.URLCreateRequest{
 			URL:   urlStr,
 			Title: title,
+			Tags:  splitTagsCell(row, tagsCol),
 		})
 	}
 
 	return result, nil
 }
 
-// findCSVColumns finds the column indices for title and url in the header row
-func (s *ImportService) findCSVColumns(header []string) (titleCol, urlCol int, err error) {
+// findCSVColumns finds the column indices for title, url and tags in the header
+// row. The tags column is optional; tagsCol is -1 when there is none.
+func (s *ImportService) findCSVColumns(header []string) (titleCol, urlCol, tagsCol int, err error) {
 	titleCol = -1
 	urlCol = -1
+	tagsCol = -1
 
 	for i, col := range header {
 		colLower := strings.ToLower(strings.TrimSpace(col))
@@ -193,17 +197,32 @@ func (s *ImportService) findCSVColumns(header []string) (titleCol, urlCol int, e
 		if urlCol == -1 && (colLower == "url" || colLower == "link" || colLower == "website" || colLower == "website url") {
 			urlCol = i
 		}
+
+		if tagsCol == -1 && (colLower == "tags" || colLower == "tag" || colLower == "labels") {
+			tagsCol = i
+		}
 	}
 
 	if urlCol == -1 {
-		return 0, 0, errors.New("URL column not found. Expected column names: 'url', 'link', 'website', or 'website url'")
+		return 0, 0, -1, errors.New("URL column not found. Expected column names: 'url', 'link', 'website', or 'website url'")
 	}
 
 	if titleCol == -1 {
-		return 0, 0, errors.New("Title column not found. Expected column names: 'title', 'name', or 'website title'")
+		return 0, 0, -1, errors.New("Title column not found. Expected column names: 'title', 'name', or 'website title'")
 	}
 
-	return titleCol, urlCol, nil
+	return titleCol, urlCol, tagsCol, nil
+}
+
+// splitTagsCell returns the tag names in a row's tags cell, separated by commas
+// or semicolons. Rows without the cell have no tags.
+func splitTagsCell(row []string, tagsCol int) []string {
+	if tagsCol < 0 || tagsCol >= len(row) {
+		return nil
+	}
+	return normalizeTagNames(strings.FieldsFunc(row[tagsCol], func(r rune) bool {
+		return r == ',' || r == ';'
+	}))
 }
 
 // isValidURL validates if a string is a valid URL
@@ -0,0 +1,390 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"website-analyzer-backend/models"
+)
+
+//go:embed data/js_libraries.json
+var bundledJSLibraries []byte
+
+// versionPlaceholder is replaced by versionPattern in extractor patterns (retire.js convention)
+const versionPlaceholder = "§§version§§"
+
+// versionPattern matches dotted versions with an optional pre-release suffix
+const versionPattern = `\d+(?:\.\d+)+(?:-(?:alpha|beta|rc)\.?\d*)?`
+
+// Extractor kinds, in the order of confidence
+const (
+	extractorFilename    = "filename"
+	extractorURI         = "uri"
+	extractorFileContent = "filecontent"
+	extractorGlobals     = "globals"
+)
+
+// severityRank orders vulnerability severities from least to most severe
+var severityRank = map[string]int{
+	"":         0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// JSLibraryFeed is the on-disk format of the library and vulnerability dataset
+type JSLibraryFeed struct {
+	Version   string                        `json:"version"`
+	Libraries map[string]JSLibraryFeedEntry `json:"libraries"`
+}
+
+// JSLibraryFeedEntry describes how to detect a library and which versions are vulnerable
+type JSLibraryFeedEntry struct {
+	Name       string `json:"name"`
+	Extractors struct {
+		Filename    []string `json:"filename"`
+		URI         []string `json:"uri"`
+		FileContent []string `json:"filecontent"`
+		Globals     []string `json:"globals"`
+	} `json:"extractors"`
+	Vulnerabilities []JSLibraryFeedVulnerability `json:"vulnerabilities"`
+}
+
+// JSLibraryFeedVulnerability describes a vulnerable version range of a library
+type JSLibraryFeedVulnerability struct {
+	AtOrAbove   string   `json:"atOrAbove,omitempty"`
+	Below       string   `json:"below"`
+	Severity    string   `json:"severity"`
+	Identifiers []string `json:"identifiers"`
+	Summary     string   `json:"summary"`
+	Info        []string `json:"info,omitempty"`
+}
+
+// JSLibraryFeedInfo summarizes the currently loaded dataset
+type JSLibraryFeedInfo struct {
+	Version            string    `json:"version"`
+	Source             string    `json:"source"`
+	LibraryCount       int       `json:"library_count"`
+	VulnerabilityCount int       `json:"vulnerability_count"`
+	LoadedAt           time.Time `json:"loaded_at"`
+}
+
+// compiledLibrary holds the compiled extractors of one library
+type compiledLibrary struct {
+	id              string
+	name            string
+	extractors      map[string][]*regexp.Regexp
+	vulnerabilities []JSLibraryFeedVulnerability
+}
+
+// JSLibraryDatabase detects JavaScript libraries and matches them against known vulnerabilities
+type JSLibraryDatabase struct {
+	mu        sync.RWMutex
+	path      string
+	info      JSLibraryFeedInfo
+	libraries []*compiledLibrary
+}
+
+var (
+	jsLibraryDatabase     = &JSLibraryDatabase{}
+	jsLibraryDatabaseOnce sync.Once
+)
+
+// InitJSLibraryDatabase loads the vulnerability feed from path, falling back to the
+// bundled feed when path is empty or cannot be loaded. Imported feeds are persisted to path.
+func InitJSLibraryDatabase(path string) error {
+	var initErr error
+
+	jsLibraryDatabaseOnce.Do(func() {
+		jsLibraryDatabase.path = path
+
+		if path != "" {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				if err = jsLibraryDatabase.load(data, path); err == nil {
+					return
+				}
+			}
+			// A missing file is expected until the first feed is imported
+			if !errors.Is(err, os.ErrNotExist) {
+				initErr = fmt.Errorf("failed to load vulnerability feed from %s: %w", path, err)
+			}
+		}
+
+		if err := jsLibraryDatabase.load(bundledJSLibraries, "bundled"); err != nil {
+			initErr = fmt.Errorf("failed to load bundled vulnerability feed: %w", err)
+		}
+	})
+
+	return initErr
+}
+
+// GetJSLibraryDatabase returns the shared library database, loading the bundled feed if needed
+func GetJSLibraryDatabase() *JSLibraryDatabase {
+	if err := InitJSLibraryDatabase(""); err != nil {
+		log.Printf("JavaScript library database: %v", err)
+	}
+	return jsLibraryDatabase
+}
+
+// Info returns a summary of the loaded dataset
+func (db *JSLibraryDatabase) Info() JSLibraryFeedInfo {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.info
+}
+
+// Import validates and activates a new feed, persisting it to the configured path
+func (db *JSLibraryDatabase) Import(data []byte) (*JSLibraryFeedInfo, error) {
+	source := "imported"
+	if db.path != "" {
+		source = db.path
+	}
+
+	if err := db.load(data, source); err != nil {
+		return nil, err
+	}
+
+	if db.path != "" {
+		if err := os.WriteFile(db.path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("feed activated but could not be persisted: %w", err)
+		}
+	}
+
+	info := db.Info()
+	return &info, nil
+}
+
+// load parses and compiles a feed and swaps it in atomically
+func (db *JSLibraryDatabase) load(data []byte, source string) error {
+	var feed JSLibraryFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return fmt.Errorf("invalid feed JSON: %w", err)
+	}
+	if len(feed.Libraries) == 0 {
+		return errors.New("feed contains no libraries")
+	}
+
+	libraries := make([]*compiledLibrary, 0, len(feed.Libraries))
+	vulnerabilityCount := 0
+
+	for id, entry := range feed.Libraries {
+		library := &compiledLibrary{
+			id:              id,
+			name:            entry.Name,
+			extractors:      make(map[string][]*regexp.Regexp),
+			vulnerabilities: entry.Vulnerabilities,
+		}
+		if library.name == "" {
+			library.name = id
+		}
+
+		patterns := map[string][]string{
+			extractorFilename:    entry.Extractors.Filename,
+			extractorURI:         entry.Extractors.URI,
+			extractorFileContent: entry.Extractors.FileContent,
+			extractorGlobals:     entry.Extractors.Globals,
+		}
+		for kind, list := range patterns {
+			for _, raw := range list {
+				regex, err := regexp.Compile(strings.ReplaceAll(raw, versionPlaceholder, versionPattern))
+				if err != nil {
+					return fmt.Errorf("library %s: invalid %s extractor %q: %w", id, kind, raw, err)
+				}
+				library.extractors[kind] = append(library.extractors[kind], regex)
+			}
+		}
+
+		for i, vuln := range entry.Vulnerabilities {
+			if vuln.Below == "" {
+				return fmt.Errorf("library %s: vulnerability %d has no 'below' version", id, i+1)
+			}
+			if _, known := severityRank[vuln.Severity]; !known || vuln.Severity == "" {
+				return fmt.Errorf("library %s: vulnerability %d has invalid severity %q", id, i+1, vuln.Severity)
+			}
+		}
+
+		vulnerabilityCount += len(entry.Vulnerabilities)
+		libraries = append(libraries, library)
+	}
+
+	sort.Slice(libraries, func(i, j int) bool {
+		return libraries[i].id < libraries[j].id
+	})
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.libraries = libraries
+	db.info = JSLibraryFeedInfo{
+		Version:            feed.Version,
+		Source:             source,
+		LibraryCount:       len(libraries),
+		VulnerabilityCount: vulnerabilityCount,
+		LoadedAt:           time.Now(),
+	}
+
+	log.Printf("Loaded JavaScript library feed %q from %s (%d libraries, %d vulnerabilities)",
+		feed.Version, source, len(libraries), vulnerabilityCount)
+	return nil
+}
+
+// Detect finds known libraries in the script inventory and inline script contents
+// and reports the vulnerabilities affecting each detected version
+func (db *JSLibraryDatabase) Detect(scripts []models.ScriptInfo, inlineScripts []string) []models.JSLibrary {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	found := make(map[string]*models.JSLibrary)
+	owners := make(map[string]*compiledLibrary)
+	var order []string
+
+	record := func(library *compiledLibrary, version, detectedBy, source string) {
+		key := library.id + "@" + version
+		if _, exists := found[key]; exists {
+			return
+		}
+		found[key] = &models.JSLibrary{
+			Name:       library.name,
+			Version:    version,
+			DetectedBy: detectedBy,
+			Source:     source,
+		}
+		owners[key] = library
+		order = append(order, key)
+	}
+
+	for _, library := range db.libraries {
+		// External scripts: file name and full URI
+		for _, script := range scripts {
+			if script.Inline || script.Src == "" {
+				continue
+			}
+
+			fileName := script.Src
+			if parsed, err := url.Parse(script.Src); err == nil {
+				fileName = path.Base(parsed.Path)
+			}
+
+			if version := matchVersion(library.extractors[extractorFilename], fileName); version != "" {
+				record(library, version, extractorFilename, script.Src)
+				continue
+			}
+			if version := matchVersion(library.extractors[extractorURI], script.Src); version != "" {
+				record(library, version, extractorURI, script.Src)
+			}
+		}
+
+		// Inline scripts: license banners and version globals
+		for _, content := range inlineScripts {
+			if version := matchVersion(library.extractors[extractorFileContent], content); version != "" {
+				record(library, version, extractorFileContent, "inline")
+				continue
+			}
+			if version := matchVersion(library.extractors[extractorGlobals], content); version != "" {
+				record(library, version, extractorGlobals, "inline")
+			}
+		}
+	}
+
+	libraries := make([]models.JSLibrary, 0, len(order))
+	for _, key := range order {
+		library := found[key]
+		for _, vuln := range owners[key].vulnerabilities {
+			if !versionInRange(library.Version, vuln.AtOrAbove, vuln.Below) {
+				continue
+			}
+			library.Vulnerabilities = append(library.Vulnerabilities, models.JSVulnerability{
+				Identifiers: vuln.Identifiers,
+				Severity:    vuln.Severity,
+				Summary:     vuln.Summary,
+				AtOrAbove:   vuln.AtOrAbove,
+				Below:       vuln.Below,
+				Info:        vuln.Info,
+			})
+			library.Severity = HighestSeverity(library.Severity, vuln.Severity)
+		}
+		libraries = append(libraries, *library)
+	}
+
+	return libraries
+}
+
+// matchVersion returns the version captured by the first matching pattern
+func matchVersion(patterns []*regexp.Regexp, value string) string {
+	for _, pattern := range patterns {
+		groups := pattern.FindStringSubmatch(value)
+		if len(groups) > 1 && groups[1] != "" {
+			return groups[1]
+		}
+	}
+	return ""
+}
+
+// versionInRange reports whether atOrAbove <= version < below
+func versionInRange(version, atOrAbove, below string) bool {
+	if atOrAbove != "" && CompareVersions(version, atOrAbove) < 0 {
+		return false
+	}
+	return CompareVersions(version, below) < 0
+}
+
+// CompareVersions compares two dotted versions, returning -1, 0 or 1.
+// Pre-release versions (e.g. 3.0.0-rc1) sort before their release.
+func CompareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(a, "-")
+	bCore, bPre, _ := strings.Cut(b, "-")
+
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		if aNum != bNum {
+			if aNum < bNum {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	case aPre < bPre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// HighestSeverity returns the more severe of two severities
+func HighestSeverity(a, b string) string {
+	if severityRank[b] > severityRank[a] {
+		return b
+	}
+	return a
+}
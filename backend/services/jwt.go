@@ -0,0 +1,307 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// jwtLeeway tolerates clock skew between the token issuer and this server
+const jwtLeeway = time.Minute
+
+// jwtHeader is the JOSE header of a signed JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// jwtClaims is the payload of a JWT
+type jwtClaims map[string]interface{}
+
+// JSONWebKey is a public key in JWK format (RFC 7517). Only RSA and EC keys are supported.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet is a set of public keys, as served by an identity provider's jwks_uri
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// parsedJWT is a decoded but not yet verified JWT
+type parsedJWT struct {
+	header       jwtHeader
+	claims       jwtClaims
+	signingInput []byte
+	signature    []byte
+}
+
+// IsJWT reports whether a bearer token looks like a compact JWT rather than an API key
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2 && strings.HasPrefix(token, "eyJ")
+}
+
+// parseJWT decodes a compact JWT without verifying its signature
+func parseJWT(token string) (*parsedJWT, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrUnauthorized)
+	}
+
+	var parsed parsedJWT
+	if err := decodeJWTSegment(parts[0], &parsed.header); err != nil {
+		return nil, fmt.Errorf("%w: malformed token header", ErrUnauthorized)
+	}
+	if err := decodeJWTSegment(parts[1], &parsed.claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed token claims", ErrUnauthorized)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token signature", ErrUnauthorized)
+	}
+	parsed.signingInput = []byte(parts[0] + "." + parts[1])
+	parsed.signature = signature
+	return &parsed, nil
+}
+
+// decodeJWTSegment decodes a base64url JSON segment of a JWT
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verify checks the token signature with key. A key that declares an
+// algorithm only verifies tokens signed with that algorithm.
+func (t *parsedJWT) verify(key crypto.PublicKey, keyAlg string) error {
+	alg := t.header.Alg
+	if keyAlg != "" && keyAlg != alg {
+		return fmt.Errorf("%w: token algorithm %q does not match its key's %q", ErrUnauthorized, alg, keyAlg)
+	}
+	if len(alg) != 5 {
+		return fmt.Errorf("%w: unsupported signing algorithm %q", ErrUnauthorized, alg)
+	}
+	hash, ok := jwtHashes[alg[2:]]
+	if !ok {
+		return fmt.Errorf("%w: unsupported signing algorithm %q", ErrUnauthorized, alg)
+	}
+	hasher := hash.New()
+	hasher.Write(t.signingInput)
+	digest := hasher.Sum(nil)
+
+	var err error
+	switch alg[:2] {
+	case "RS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: key does not match algorithm %s", ErrUnauthorized, alg)
+		}
+		err = rsa.VerifyPKCS1v15(rsaKey, hash, digest, t.signature)
+	case "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: key does not match algorithm %s", ErrUnauthorized, alg)
+		}
+		err = rsa.VerifyPSS(rsaKey, hash, digest, t.signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case "ES":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: key does not match algorithm %s", ErrUnauthorized, alg)
+		}
+		// ES signatures are the fixed-size concatenation of r and s
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(t.signature) != 2*size {
+			return fmt.Errorf("%w: invalid token signature", ErrUnauthorized)
+		}
+		r := new(big.Int).SetBytes(t.signature[:size])
+		s := new(big.Int).SetBytes(t.signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			err = errors.New("verification failed")
+		}
+	default:
+		return fmt.Errorf("%w: unsupported signing algorithm %q", ErrUnauthorized, alg)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: invalid token signature", ErrUnauthorized)
+	}
+	return nil
+}
+
+// jwtHashes maps the size suffix of a JWS algorithm (RS256, ES384, ...) to its hash
+var jwtHashes = map[string]crypto.Hash{
+	"256": crypto.SHA256,
+	"384": crypto.SHA384,
+	"512": crypto.SHA512,
+}
+
+// validate checks the time-based claims, the issuer and the audience. Tokens
+// with an authorized party (azp), or with several audiences, must have been
+// issued to the audience as well.
+func (c jwtClaims) validate(issuer, audience string, now time.Time) error {
+	if c.string("iss") != issuer {
+		return fmt.Errorf("%w: unexpected token issuer %q", ErrUnauthorized, c.string("iss"))
+	}
+	exp, ok := c.time("exp")
+	if !ok {
+		return fmt.Errorf("%w: token has no expiry", ErrUnauthorized)
+	}
+	if now.After(exp.Add(jwtLeeway)) {
+		return fmt.Errorf("%w: token has expired", ErrUnauthorized)
+	}
+	if nbf, ok := c.time("nbf"); ok && now.Add(jwtLeeway).Before(nbf) {
+		return fmt.Errorf("%w: token is not valid yet", ErrUnauthorized)
+	}
+	audiences := c.strings("aud")
+	if audience == "" || !containsString(audiences, audience) {
+		return fmt.Errorf("%w: token is not intended for this audience", ErrUnauthorized)
+	}
+	if azp, ok := c["azp"]; (ok || len(audiences) > 1) && azp != audience {
+		return fmt.Errorf("%w: token was issued to another client", ErrUnauthorized)
+	}
+	return nil
+}
+
+// string returns a string claim, or "" if it is missing or not a string
+func (c jwtClaims) string(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// strings returns a claim that is a string or an array of strings
+func (c jwtClaims) strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// time returns a NumericDate claim
+func (c jwtClaims) time(name string) (time.Time, bool) {
+	value, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0), true
+}
+
+// signJWT signs claims with an RSA key using RS256
+func signJWT(key *rsa.PrivateKey, keyID string, claims jwtClaims) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: "RS256", Kid: keyID, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// rsaJSONWebKey returns the JWK of an RSA public key, identified by its
+// RFC 7638 thumbprint
+func rsaJSONWebKey(key *rsa.PublicKey) JSONWebKey {
+	jwk := JSONWebKey{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+	thumbprint := sha256.Sum256([]byte(fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk.E, jwk.N)))
+	jwk.Kid = base64.RawURLEncoding.EncodeToString(thumbprint[:])
+	return jwk
+}
+
+// publicKey converts a JWK to an RSA or ECDSA public key
+func (k JSONWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("invalid EC key")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
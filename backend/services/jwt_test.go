@@ -0,0 +1,66 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJWTClaimsValidate(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	exp := float64(now.Add(time.Hour).Unix())
+
+	tests := []struct {
+		name     string
+		audience string
+		claims   jwtClaims
+		wantErr  bool
+	}{
+		{"single audience", "analyzer", jwtClaims{"iss": "idp", "exp": exp, "aud": "analyzer"}, false},
+		{"audience list with azp", "analyzer", jwtClaims{"iss": "idp", "exp": exp, "aud": []interface{}{"analyzer", "other"}, "azp": "analyzer"}, false},
+		{"no expected audience", "", jwtClaims{"iss": "idp", "exp": exp, "aud": "analyzer"}, true},
+		{"missing aud", "analyzer", jwtClaims{"iss": "idp", "exp": exp}, true},
+		{"other audience", "analyzer", jwtClaims{"iss": "idp", "exp": exp, "aud": "other"}, true},
+		{"issued to another client", "analyzer", jwtClaims{"iss": "idp", "exp": exp, "aud": "analyzer", "azp": "other"}, true},
+		{"audience list without azp", "analyzer", jwtClaims{"iss": "idp", "exp": exp, "aud": []interface{}{"analyzer", "other"}}, true},
+		{"other issuer", "analyzer", jwtClaims{"iss": "evil", "exp": exp, "aud": "analyzer"}, true},
+		{"expired", "analyzer", jwtClaims{"iss": "idp", "exp": float64(now.Add(-time.Hour).Unix()), "aud": "analyzer"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.claims.validate("idp", tt.audience, now)
+			if tt.wantErr && !errors.Is(err, ErrUnauthorized) {
+				t.Errorf("validate = %v, want ErrUnauthorized", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTVerifyKeyAlgorithm(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := signJWT(key, "k1", jwtClaims{"sub": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := parseJWT(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, keyAlg := range []string{"", "RS256"} {
+		if err := token.verify(&key.PublicKey, keyAlg); err != nil {
+			t.Errorf("verify with key alg %q: %v", keyAlg, err)
+		}
+	}
+	if err := token.verify(&key.PublicKey, "PS256"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("verify with a PS256 key = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// SMTP encryption modes
+const (
+	SMTPEncryptionNone     = "none"
+	SMTPEncryptionSTARTTLS = "starttls"
+	SMTPEncryptionTLS      = "tls"
+)
+
+// smtpTimeout bounds connecting to and talking with the SMTP server
+const smtpTimeout = 30 * time.Second
+
+// ErrSMTPNotConfigured is returned when mail is sent without an SMTP host
+var ErrSMTPNotConfigured = errors.New("SMTP is not configured")
+
+// MailSettings configures the SMTP server used for outgoing mail
+type MailSettings struct {
+	Host       string
+	Port       string
+	Username   string
+	Password   string
+	From       string
+	Encryption string // none, starttls or tls
+}
+
+// Mail is a multipart message with plain-text and HTML bodies
+type Mail struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer sends mail through an SMTP server
+type Mailer struct {
+	settings MailSettings
+}
+
+// NewMailer creates a mailer for the given SMTP settings
+func NewMailer(settings MailSettings) *Mailer {
+	if settings.Port == "" {
+		settings.Port = "25"
+	}
+	if settings.Encryption == "" {
+		settings.Encryption = SMTPEncryptionNone
+	}
+	return &Mailer{settings: settings}
+}
+
+// Configured reports whether an SMTP host is set
+func (m *Mailer) Configured() bool {
+	return m.settings.Host != ""
+}
+
+// Send delivers a message to a single recipient
+func (m *Mailer) Send(message Mail) error {
+	if !m.Configured() {
+		return ErrSMTPNotConfigured
+	}
+
+	from, err := mail.ParseAddress(m.settings.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", m.settings.From, err)
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %q: %w", message.To, err)
+	}
+
+	body, err := buildMail(from, to, message)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.settings.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.settings.Username, m.settings.Password, m.settings.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP RCPT TO failed: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the SMTP server, negotiating TLS as configured
+func (m *Mailer) dial() (*smtp.Client, error) {
+	address := net.JoinHostPort(m.settings.Host, m.settings.Port)
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	tlsConfig := &tls.Config{ServerName: m.settings.Host}
+
+	var conn net.Conn
+	var err error
+	if m.settings.Encryption == SMTPEncryptionTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server %s: %w", address, err)
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, m.settings.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+
+	if m.settings.Encryption == SMTPEncryptionSTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// buildMail renders a multipart/alternative message with quoted-printable bodies
+func buildMail(from, to *mail.Address, message Mail) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.Text},
+		{"text/html; charset=UTF-8", message.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build message: %w", err)
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to build message: %w", err)
+		}
+		encoder.Close()
+	}
+	parts.Close()
+
+	var header strings.Builder
+	fmt.Fprintf(&header, "From: %s\r\n", from.String())
+	fmt.Fprintf(&header, "To: %s\r\n", to.String())
+	fmt.Fprintf(&header, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", message.Subject))
+	fmt.Fprintf(&header, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	if messageID, err := randomHex(16); err == nil {
+		domain := "website-analyzer.local"
+		if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+			domain = from.Address[at+1:]
+		}
+		fmt.Fprintf(&header, "Message-ID: <%s@%s>\r\n", messageID, domain)
+	}
+	header.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&header, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+
+	return append([]byte(header.String()), body.Bytes()...), nil
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"website-analyzer-backend/models"
+)
+
+// Thresholds for the static mobile-friendliness heuristics
+const (
+	maxFixedWidthPx     = 480  // wider fixed widths overflow small viewports
+	minFontSizePx       = 12.0 // smaller text is hard to read without zooming
+	minFontSizePt       = 9.0
+	minFontSizeEm       = 0.75
+	maxMobileIssueItems = 20 // selectors reported per check
+)
+
+var (
+	fixedWidthPattern = regexp.MustCompile(`(?i)(?:^|[;{\s])(min-width|width)\s*:\s*(\d+(?:\.\d+)?)px`)
+	fontSizePattern   = regexp.MustCompile(`(?i)font-size\s*:\s*(\d*\.?\d+)(px|pt|em|rem)`)
+	linkSeparators    = regexp.MustCompile(`^[\s|·•/,\-–—]*$`)
+)
+
+// analyzeMobile runs static mobile-friendliness checks against the document
+func (s *SEOAnalyzer) analyzeMobile(doc *goquery.Document, result *SEOAnalysisResult) {
+	mobile := models.MobileAnalysis{
+		Issues: []models.MobileIssue{},
+	}
+	counts := make(map[string]int)
+
+	addIssue := func(check, severity, message string, sel *goquery.Selection) {
+		counts[check]++
+		if counts[check] > maxMobileIssueItems {
+			return
+		}
+		issue := models.MobileIssue{
+			Check:    check,
+			Severity: severity,
+			Message:  message,
+		}
+		if sel != nil {
+			issue.Selector = cssSelectorPath(sel)
+		}
+		mobile.Issues = append(mobile.Issues, issue)
+	}
+
+	// Viewport meta tag
+	viewport := doc.Find("meta[name='viewport']").First()
+	if viewport.Length() == 0 {
+		addIssue("viewport", "high", "No viewport meta tag; the page renders at desktop width on mobile devices", nil)
+	} else {
+		mobile.HasViewport = true
+		mobile.Viewport = strings.TrimSpace(viewport.AttrOr("content", ""))
+		settings := parseViewport(mobile.Viewport)
+
+		mobile.DeviceWidth = settings["width"] == "device-width"
+		if !mobile.DeviceWidth {
+			addIssue("viewport", "medium", "Viewport does not set width=device-width", viewport)
+		}
+
+		userScalable := settings["user-scalable"]
+		maximumScale, hasMaximumScale := settings["maximum-scale"]
+		scale, _ := strconv.ParseFloat(maximumScale, 64)
+		if userScalable == "no" || userScalable == "0" || (hasMaximumScale && scale > 0 && scale < 2) {
+			mobile.ZoomDisabled = true
+			addIssue("viewport", "medium", "Viewport prevents users from zooming (user-scalable=no or maximum-scale below 2)", viewport)
+		}
+	}
+
+	// Inline style attributes and <style> blocks
+	doc.Find("[style]").Each(func(i int, sel *goquery.Selection) {
+		style := sel.AttrOr("style", "")
+		if width, ok := fixedWidth(style); ok {
+			mobile.FixedWidthElements++
+			addIssue("fixed-width", "medium", fmt.Sprintf("Fixed width of %dpx in inline style", width), sel)
+		}
+		if size, ok := tinyFontSize(style); ok {
+			mobile.SmallFontDeclarations++
+			addIssue("font-size", "low", fmt.Sprintf("Font size %s is too small to read on mobile", size), sel)
+		}
+	})
+	doc.Find("style").Each(func(i int, sel *goquery.Selection) {
+		css := sel.Text()
+		for _, match := range fixedWidthPattern.FindAllStringSubmatch(css, -1) {
+			if width, _ := strconv.ParseFloat(match[2], 64); width > maxFixedWidthPx {
+				mobile.FixedWidthElements++
+				addIssue("fixed-width", "medium", fmt.Sprintf("Fixed %s of %.0fpx in <style> block", strings.ToLower(match[1]), width), sel)
+			}
+		}
+		for _, match := range fontSizePattern.FindAllStringSubmatch(css, -1) {
+			if isTinyFont(match[1], match[2]) {
+				mobile.SmallFontDeclarations++
+				addIssue("font-size", "low", fmt.Sprintf("Font size %s%s in <style> block is too small to read on mobile", match[1], match[2]), sel)
+			}
+		}
+	})
+	doc.Find("table[width], div[width]").Each(func(i int, sel *goquery.Selection) {
+		if width, err := strconv.Atoi(strings.TrimSuffix(sel.AttrOr("width", ""), "px")); err == nil && width > maxFixedWidthPx {
+			mobile.FixedWidthElements++
+			addIssue("fixed-width", "medium", fmt.Sprintf("Fixed width attribute of %dpx", width), sel)
+		}
+	})
+
+	// Plugin content (Flash, Java applets, other embeds)
+	doc.Find("object, embed, applet").Each(func(i int, sel *goquery.Selection) {
+		mobile.PluginElements++
+		kind := strings.ToLower(sel.AttrOr("type", "") + " " + sel.AttrOr("data", "") + " " + sel.AttrOr("src", "") + " " + sel.AttrOr("classid", ""))
+		message := fmt.Sprintf("<%s> plugin content is not supported on most mobile browsers", goquery.NodeName(sel))
+		if strings.Contains(kind, "flash") || strings.Contains(kind, "shockwave") || strings.Contains(kind, ".swf") ||
+			strings.Contains(kind, "d27cdb6e-ae6d-11cf-96b8-444553540000") {
+			mobile.UsesFlash = true
+			message = "Flash content is not supported on mobile browsers"
+		}
+		addIssue("plugins", "high", message, sel)
+	})
+
+	// Touch targets: links placed directly next to each other with nothing but separators between
+	doc.Find("a[href]").Each(func(i int, sel *goquery.Selection) {
+		next := sel.Next()
+		if next.Length() == 0 || goquery.NodeName(next) != "a" {
+			return
+		}
+		if !linkSeparators.MatchString(textBetween(sel, next)) {
+			return
+		}
+		// List-based menus are normally padded by CSS; only flag bare inline links
+		if sel.Parent().Is("li") {
+			return
+		}
+		mobile.CrowdedLinks++
+		addIssue("touch-targets", "low", "Links are placed too close together to be tapped reliably", sel)
+	})
+
+	// Summarize
+	for _, count := range counts {
+		mobile.IssueCount += count
+	}
+	mobile.MobileFriendly = mobile.HasViewport && mobile.DeviceWidth && !mobile.ZoomDisabled &&
+		mobile.PluginElements == 0 && mobile.FixedWidthElements == 0
+
+	result.Mobile = mobile
+}
+
+// parseViewport parses the content of a viewport meta tag into lower-cased key/value pairs
+func parseViewport(content string) map[string]string {
+	settings := make(map[string]string)
+	for _, part := range strings.FieldsFunc(content, func(r rune) bool { return r == ',' || r == ';' }) {
+		key, value, _ := strings.Cut(part, "=")
+		settings[strings.ToLower(strings.TrimSpace(key))] = strings.ToLower(strings.TrimSpace(value))
+	}
+	return settings
+}
+
+// fixedWidth returns the first fixed pixel width wider than a small viewport in a style declaration
+func fixedWidth(style string) (int, bool) {
+	for _, match := range fixedWidthPattern.FindAllStringSubmatch(";"+style, -1) {
+		if width, err := strconv.ParseFloat(match[2], 64); err == nil && width > maxFixedWidthPx {
+			return int(width), true
+		}
+	}
+	return 0, false
+}
+
+// tinyFontSize returns the first font size below the readable minimum in a style declaration
+func tinyFontSize(style string) (string, bool) {
+	for _, match := range fontSizePattern.FindAllStringSubmatch(style, -1) {
+		if isTinyFont(match[1], match[2]) {
+			return match[1] + match[2], true
+		}
+	}
+	return "", false
+}
+
+// isTinyFont reports whether a font size is below the readable minimum for its unit
+func isTinyFont(value, unit string) bool {
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size == 0 {
+		return false
+	}
+	switch strings.ToLower(unit) {
+	case "px":
+		return size < minFontSizePx
+	case "pt":
+		return size < minFontSizePt
+	case "em", "rem":
+		return size < minFontSizeEm
+	}
+	return false
+}
+
+// textBetween returns the text between two adjacent sibling elements
+func textBetween(first, second *goquery.Selection) string {
+	var builder strings.Builder
+	target := second.Get(0)
+	for node := first.Get(0).NextSibling; node != nil && node != target; node = node.NextSibling {
+		if node.Type == html.TextNode {
+			builder.WriteString(node.Data)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,602 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidNotificationPreference is returned when notification preferences fail validation
+var ErrInvalidNotificationPreference = errors.New("invalid notification preference")
+
+// maxDigestLinks caps how many broken links are listed per URL in a digest
+const maxDigestLinks = 10
+
+//go:embed templates/email/*.tmpl
+var emailTemplateFiles embed.FS
+
+var (
+	emailFuncs = map[string]interface{}{
+		"upper": strings.ToUpper,
+		"signed": func(value int) string {
+			if value > 0 {
+				return "+" + strconv.Itoa(value)
+			}
+			return strconv.Itoa(value)
+		},
+		"formatTime": func(value interface{}) string {
+			switch t := value.(type) {
+			case time.Time:
+				return t.Format("Jan 2, 2006 15:04 MST")
+			case *time.Time:
+				if t != nil {
+					return t.Format("Jan 2, 2006 15:04 MST")
+				}
+			}
+			return ""
+		},
+	}
+	htmlEmailTemplates = htmltemplate.Must(htmltemplate.New("email").Funcs(emailFuncs).ParseFS(emailTemplateFiles, "templates/email/*.html.tmpl"))
+	textEmailTemplates = texttemplate.Must(texttemplate.New("email").Funcs(emailFuncs).ParseFS(emailTemplateFiles, "templates/email/*.txt.tmpl"))
+)
+
+// NotificationSettings configures email notifications
+type NotificationSettings struct {
+	Mail          MailSettings
+	DigestHour    int          // local hour at which digests are sent
+	DigestWeekday time.Weekday // day on which weekly digests are sent
+}
+
+// notificationSettings holds the settings applied by ConfigureNotifications
+var notificationSettings = NotificationSettings{
+	DigestHour:    8,
+	DigestWeekday: time.Monday,
+}
+
+// ConfigureNotifications applies SMTP and digest settings. It must be called
+// at startup, before notifications are sent.
+func ConfigureNotifications(settings NotificationSettings) {
+	if settings.DigestHour < 0 || settings.DigestHour > 23 {
+		log.Printf("Invalid digest hour %d, using %d", settings.DigestHour, notificationSettings.DigestHour)
+		settings.DigestHour = notificationSettings.DigestHour
+	}
+	switch settings.Mail.Encryption {
+	case "", SMTPEncryptionNone, SMTPEncryptionSTARTTLS, SMTPEncryptionTLS:
+	default:
+		log.Printf("Invalid SMTP encryption %q, using %q", settings.Mail.Encryption, SMTPEncryptionNone)
+		settings.Mail.Encryption = SMTPEncryptionNone
+	}
+	notificationSettings = settings
+}
+
+// NotificationService handles users' notification preferences and sends alert mails and digests
+type NotificationService struct {
+	db          *gorm.DB
+	mailer      *Mailer
+	workspaceID uint
+}
+
+// NewNotificationService creates a new notification service instance
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		db:     database.GetDB(),
+		mailer: NewMailer(notificationSettings.Mail),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *NotificationService) ForWorkspace(workspaceID uint) *NotificationService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllPreferences retrieves the notification preferences of every user in the workspace
+func (s *NotificationService) GetAllPreferences() ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Order("user_id ASC").Find(&preferences).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+// GetPreferenceByID retrieves notification preferences by ID
+func (s *NotificationService) GetPreferenceByID(id uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("User").First(&preference, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification preference not found")
+		}
+		return nil, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+	return &preference, nil
+}
+
+// GetUserPreference retrieves a user's notification preferences. A user who
+// has never saved any gets everything turned off, which is what they receive.
+func (s *NotificationService) GetUserPreference(userID uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := s.db.Scopes(inWorkspace(s.workspaceID)).Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.NotificationPreference{WorkspaceID: s.workspaceID, UserID: userID, Digest: models.DigestNone}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+	return &preference, nil
+}
+
+// CreatePreference validates and creates notification preferences for a
+// member of the workspace
+func (s *NotificationService) CreatePreference(req models.NotificationPreferenceRequest) (*models.NotificationPreference, error) {
+	if req.UserID == 0 {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidNotificationPreference)
+	}
+	if err := s.ensureRecipient(req.UserID); err != nil {
+		return nil, err
+	}
+	var existing int64
+	if err := s.db.Model(&models.NotificationPreference{}).Scopes(inWorkspace(s.workspaceID)).
+		Where("user_id = ?", req.UserID).Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to check notification preferences: %w", err)
+	}
+	if existing > 0 {
+		return nil, errors.New("notification preference already exists")
+	}
+
+	preference := models.NotificationPreference{
+		WorkspaceID: s.workspaceID,
+		UserID:      req.UserID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.applyRequest(&preference, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&preference).Error; err != nil {
+		return nil, fmt.Errorf("failed to create notification preference: %w", err)
+	}
+	return &preference, nil
+}
+
+// SaveUserPreference creates or replaces a user's own notification preferences
+func (s *NotificationService) SaveUserPreference(userID uint, req models.NotificationPreferenceRequest) (*models.NotificationPreference, error) {
+	preference, err := s.GetUserPreference(userID)
+	if err != nil {
+		return nil, err
+	}
+	if preference.ID == 0 {
+		preference.CreatedAt = time.Now()
+	}
+	if err := s.applyRequest(preference, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(preference).Error; err != nil {
+		return nil, fmt.Errorf("failed to save notification preference: %w", err)
+	}
+	return preference, nil
+}
+
+// UpdatePreference validates and replaces notification preferences
+func (s *NotificationService) UpdatePreference(id uint, req models.NotificationPreferenceRequest) (*models.NotificationPreference, error) {
+	preference, err := s.GetPreferenceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(preference, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(preference).Error; err != nil {
+		return nil, fmt.Errorf("failed to update notification preference: %w", err)
+	}
+	return preference, nil
+}
+
+// DeletePreference deletes notification preferences by ID
+func (s *NotificationService) DeletePreference(id uint) error {
+	result := s.db.Scopes(inWorkspace(s.workspaceID)).Delete(&models.NotificationPreference{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification preference: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("notification preference not found")
+	}
+	return nil
+}
+
+// ensureRecipient checks that a user may receive the workspace's notifications:
+// they must be a member of it or an instance admin
+func (s *NotificationService) ensureRecipient(userID uint) error {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: user %d does not exist", ErrInvalidNotificationPreference, userID)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role == models.RoleAdmin {
+		return nil
+	}
+
+	var members int64
+	if err := s.db.Model(&models.WorkspaceMember{}).Where("workspace_id = ? AND user_id = ?", s.workspaceID, userID).
+		Count(&members).Error; err != nil {
+		return fmt.Errorf("failed to get workspace membership: %w", err)
+	}
+	if members == 0 {
+		return fmt.Errorf("%w: user %d is not a member of this workspace", ErrInvalidNotificationPreference, userID)
+	}
+	return nil
+}
+
+// applyRequest validates a notification preference request and copies it onto the model
+func (s *NotificationService) applyRequest(preference *models.NotificationPreference, req models.NotificationPreferenceRequest) error {
+	digest := strings.ToLower(strings.TrimSpace(req.Digest))
+	if digest == "" {
+		digest = models.DigestNone
+	}
+	if digest != models.DigestNone && digest != models.DigestDaily && digest != models.DigestWeekly {
+		return fmt.Errorf("%w: digest must be %q, %q or %q", ErrInvalidNotificationPreference, models.DigestNone, models.DigestDaily, models.DigestWeekly)
+	}
+
+	digestChanged := preference.Digest != digest
+	preference.Digest = digest
+	preference.CriticalAlerts = true
+	if req.CriticalAlerts != nil {
+		preference.CriticalAlerts = *req.CriticalAlerts
+	}
+	preference.Enabled = true
+	if req.Enabled != nil {
+		preference.Enabled = *req.Enabled
+	}
+	preference.UpdatedAt = time.Now()
+
+	if digest == models.DigestNone {
+		preference.NextDigestAt = nil
+	} else if digestChanged || preference.NextDigestAt == nil {
+		next := nextDigestTime(digest, time.Now())
+		preference.NextDigestAt = &next
+	}
+	return nil
+}
+
+// NotifyCriticalAlerts mails the critical alerts raised by an analysis to every
+// active user in the URL's workspace with critical alert notifications enabled.
+// Failures are logged.
+func (s *NotificationService) NotifyCriticalAlerts(alerts []models.Alert, url models.URLResponse) {
+	var critical []models.Alert
+	for _, alert := range alerts {
+		if alert.Severity == "critical" {
+			critical = append(critical, alert)
+		}
+	}
+	if len(critical) == 0 || !s.mailer.Configured() {
+		return
+	}
+
+	var recipients []models.NotificationPreference
+	if err := s.db.Preload("User").Where("workspace_id = ? AND enabled = ? AND critical_alerts = ?", url.WorkspaceID, true, true).
+		Find(&recipients).Error; err != nil {
+		log.Printf("Failed to load alert notification recipients: %v", err)
+		return
+	}
+
+	subject := fmt.Sprintf("[Critical] %s: %s", url.URL, critical[0].RuleName)
+	if len(critical) > 1 {
+		subject = fmt.Sprintf("[Critical] %s: %d alerts", url.URL, len(critical))
+	}
+	for _, recipient := range recipients {
+		if recipient.User == nil || !recipient.User.Active {
+			continue
+		}
+		err := s.send(recipient, subject, "alert", map[string]interface{}{
+			"Recipient": recipient.User.Email,
+			"URL":       url,
+			"Alerts":    critical,
+		})
+		if err != nil {
+			log.Printf("Failed to send critical alert email to %s: %v", recipient.User.Email, err)
+		}
+	}
+}
+
+// SendTestEmail sends a test message to verify SMTP delivery to a recipient
+func (s *NotificationService) SendTestEmail(id uint) error {
+	preference, err := s.GetPreferenceByID(id)
+	if err != nil {
+		return err
+	}
+	return s.send(*preference, "Website Analyzer test email", "test", map[string]interface{}{
+		"Recipient": preference.User.Email,
+	})
+}
+
+// SendDigestNow builds and sends a recipient's digest immediately, covering the
+// period since their last digest. It does not change when the next digest is due.
+// Empty digests are returned but not sent.
+func (s *NotificationService) SendDigestNow(id uint) (*models.Digest, bool, error) {
+	preference, err := s.GetPreferenceByID(id)
+	if err != nil {
+		return nil, false, err
+	}
+	frequency := preference.Digest
+	if frequency == models.DigestNone {
+		frequency = models.DigestDaily
+	}
+
+	digest, err := s.ForWorkspace(preference.WorkspaceID).BuildDigest(frequency, digestStart(preference, frequency, time.Now()), time.Now())
+	if err != nil {
+		return nil, false, err
+	}
+	if digest.Empty() {
+		return digest, false, nil
+	}
+	if err := s.sendDigest(*preference, digest); err != nil {
+		return digest, false, err
+	}
+	return digest, true, nil
+}
+
+// SendDueDigests sends every digest that is due and schedules the next one.
+// Digests with nothing to report are skipped but still rescheduled.
+func (s *NotificationService) SendDueDigests(now time.Time) {
+	if !s.mailer.Configured() {
+		return
+	}
+
+	var preferences []models.NotificationPreference
+	if err := s.db.Scopes(allWorkspaces).Preload("User").
+		Where("enabled = ? AND digest <> ? AND next_digest_at IS NOT NULL AND next_digest_at <= ?", true, models.DigestNone, now).
+		Find(&preferences).Error; err != nil {
+		log.Printf("Failed to load due digests: %v", err)
+		return
+	}
+
+	for _, preference := range preferences {
+		// Inactive users are skipped but still rescheduled
+		if preference.User != nil && preference.User.Active {
+			digest, err := s.ForWorkspace(preference.WorkspaceID).BuildDigest(preference.Digest, digestStart(&preference, preference.Digest, now), now)
+			if err != nil {
+				log.Printf("Failed to build digest for %s: %v", preference.User.Email, err)
+				continue
+			}
+			if !digest.Empty() {
+				if err := s.sendDigest(preference, digest); err != nil {
+					// Leave the digest due so it is retried on the next tick
+					log.Printf("Failed to send digest to %s: %v", preference.User.Email, err)
+					continue
+				}
+			}
+		}
+
+		next := nextDigestTime(preference.Digest, now)
+		s.db.Model(&models.NotificationPreference{}).Where("id = ?", preference.ID).Updates(map[string]interface{}{
+			"last_digest_at": now,
+			"next_digest_at": next,
+			"updated_at":     time.Now(),
+		})
+	}
+}
+
+// BuildDigest summarizes new broken links, failed analyses and score changes
+// across the URLs of the workspace for analyses in (since, until]
+func (s *NotificationService) BuildDigest(frequency string, since, until time.Time) (*models.Digest, error) {
+	digest := &models.Digest{
+		Frequency:      frequency,
+		Since:          since,
+		Until:          until,
+		BrokenLinks:    []models.DigestBrokenLinks{},
+		FailedAnalyses: []models.DigestFailure{},
+		ScoreChanges:   []models.DigestScoreChange{},
+	}
+
+	var analyses []models.Analysis
+	if err := s.db.Scopes(urlInWorkspace(s.workspaceID)).Where("analyzed_at > ? AND analyzed_at <= ?", since, until).
+		Order("url_id ASC, analyzed_at ASC, id ASC").Find(&analyses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get analyses: %w", err)
+	}
+	if len(analyses) == 0 {
+		return digest, nil
+	}
+	digest.AnalysisCount = len(analyses)
+
+	// Group the period's analyses by URL
+	byURL := make(map[uint][]models.Analysis)
+	var urlIDs []uint
+	for _, analysis := range analyses {
+		if _, seen := byURL[analysis.URLID]; !seen {
+			urlIDs = append(urlIDs, analysis.URLID)
+		}
+		byURL[analysis.URLID] = append(byURL[analysis.URLID], analysis)
+	}
+	digest.URLCount = len(urlIDs)
+
+	// The last completed analysis before the period is each URL's baseline
+	var baselines []models.Analysis
+	if err := s.db.Where("id IN (?)", s.db.Model(&models.Analysis{}).Select("MAX(id)").
+		Where("url_id IN ? AND analyzed_at <= ? AND status = ?", urlIDs, since, "completed").Group("url_id")).
+		Find(&baselines).Error; err != nil {
+		return nil, fmt.Errorf("failed to get baseline analyses: %w", err)
+	}
+	baselineByURL := make(map[uint]models.Analysis, len(baselines))
+	for _, baseline := range baselines {
+		baselineByURL[baseline.URLID] = baseline
+	}
+
+	var urls []models.URL
+	if err := s.db.Where("id IN ?", urlIDs).Find(&urls).Error; err != nil {
+		return nil, fmt.Errorf("failed to get URLs: %w", err)
+	}
+	urlByID := make(map[uint]models.URL, len(urls))
+	for _, url := range urls {
+		urlByID[url.ID] = url
+	}
+
+	for _, urlID := range urlIDs {
+		url, exists := urlByID[urlID]
+		if !exists {
+			// Deleted since it was analyzed
+			continue
+		}
+
+		var first, last *models.Analysis
+		failure := models.DigestFailure{URLID: urlID, URL: url.URL}
+		for i := range byURL[urlID] {
+			analysis := &byURL[urlID][i]
+			if analysis.Status != "completed" {
+				failure.Count++
+				failure.LastError = analysis.ErrorMessage
+				failure.FailedAt = analysis.AnalyzedAt
+				continue
+			}
+			if first == nil {
+				first = analysis
+			}
+			last = analysis
+		}
+		if failure.Count > 0 {
+			digest.FailedAnalyses = append(digest.FailedAnalyses, failure)
+		}
+		if last == nil {
+			continue
+		}
+
+		baseline, hasBaseline := baselineByURL[urlID]
+		if !hasBaseline {
+			baseline = *first
+		}
+
+		if last.BrokenLinks > baseline.BrokenLinks {
+			entry := models.DigestBrokenLinks{
+				URLID:    urlID,
+				URL:      url.URL,
+				Previous: baseline.BrokenLinks,
+				Current:  last.BrokenLinks,
+				Links:    []string{},
+			}
+			if url.LastAnalysisID == last.ID {
+				// The URL row only holds the latest list of broken links
+				for _, link := range url.ToResponse().SEOAnalysis.LinkAnalysis.BrokenLinksList {
+					if len(entry.Links) == maxDigestLinks {
+						break
+					}
+					entry.Links = append(entry.Links, link.URL)
+				}
+			}
+			digest.BrokenLinks = append(digest.BrokenLinks, entry)
+		}
+
+		if change := last.SEOScore - baseline.SEOScore; change != 0 {
+			digest.ScoreChanges = append(digest.ScoreChanges, models.DigestScoreChange{
+				URLID:    urlID,
+				URL:      url.URL,
+				Previous: baseline.SEOScore,
+				Current:  last.SEOScore,
+				Change:   change,
+			})
+		}
+	}
+
+	// Largest score movements first
+	sort.SliceStable(digest.ScoreChanges, func(i, j int) bool {
+		return abs(digest.ScoreChanges[i].Change) > abs(digest.ScoreChanges[j].Change)
+	})
+
+	return digest, nil
+}
+
+// sendDigest mails a digest to a recipient
+func (s *NotificationService) sendDigest(preference models.NotificationPreference, digest *models.Digest) error {
+	var highlights []string
+	if n := len(digest.BrokenLinks); n > 0 {
+		highlights = append(highlights, fmt.Sprintf("%d with new broken links", n))
+	}
+	if n := len(digest.FailedAnalyses); n > 0 {
+		highlights = append(highlights, fmt.Sprintf("%d failing", n))
+	}
+	if n := len(digest.ScoreChanges); n > 0 {
+		highlights = append(highlights, fmt.Sprintf("%d score changes", n))
+	}
+	subject := fmt.Sprintf("Website Analyzer %s digest: %s", digest.Frequency, strings.Join(highlights, ", "))
+
+	return s.send(preference, subject, "digest", map[string]interface{}{
+		"Recipient": preference.User.Email,
+		"Frequency": digest.Frequency,
+		"Digest":    digest,
+	})
+}
+
+// send renders the HTML and plain-text versions of a template and mails them to
+// the user a preference belongs to
+func (s *NotificationService) send(preference models.NotificationPreference, subject, templateName string, data interface{}) error {
+	if preference.User == nil {
+		return errors.New("notification recipient not loaded")
+	}
+
+	var text, html bytes.Buffer
+	if err := textEmailTemplates.ExecuteTemplate(&text, templateName+".txt.tmpl", data); err != nil {
+		return fmt.Errorf("failed to render %s email: %w", templateName, err)
+	}
+	if err := htmlEmailTemplates.ExecuteTemplate(&html, templateName+".html.tmpl", data); err != nil {
+		return fmt.Errorf("failed to render %s email: %w", templateName, err)
+	}
+
+	to := (&mail.Address{Name: preference.User.Name, Address: preference.User.Email}).String()
+	return s.mailer.Send(Mail{
+		To:      to,
+		Subject: subject,
+		Text:    text.String(),
+		HTML:    html.String(),
+	})
+}
+
+// digestStart returns the start of the period a digest covers
+func digestStart(preference *models.NotificationPreference, frequency string, now time.Time) time.Time {
+	if preference.LastDigestAt != nil {
+		return *preference.LastDigestAt
+	}
+	if frequency == models.DigestWeekly {
+		return now.AddDate(0, 0, -7)
+	}
+	return now.AddDate(0, 0, -1)
+}
+
+// nextDigestTime returns when the next daily or weekly digest after now is due
+func nextDigestTime(frequency string, now time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), notificationSettings.DigestHour, 0, 0, 0, now.Location())
+	if frequency == models.DigestWeekly {
+		next = next.AddDate(0, 0, (int(notificationSettings.DigestWeekday)-int(next.Weekday())+7)%7)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	}
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// abs returns the absolute value of an integer
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
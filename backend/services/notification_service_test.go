@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"website-analyzer-backend/models"
+)
+
+func TestNextDigestTime(t *testing.T) {
+	saved := notificationSettings
+	t.Cleanup(func() { notificationSettings = saved })
+	notificationSettings.DigestHour = 8
+	notificationSettings.DigestWeekday = time.Monday
+
+	// 2026-03-15 is a Sunday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name      string
+		frequency string
+		now       time.Time
+		want      time.Time
+	}{
+		{"daily before the hour", models.DigestDaily, at(15, 7, 59), at(15, 8, 0)},
+		{"daily at the hour", models.DigestDaily, at(15, 8, 0), at(16, 8, 0)},
+		{"daily after the hour", models.DigestDaily, at(15, 20, 0), at(16, 8, 0)},
+		{"weekly on another day", models.DigestWeekly, at(15, 12, 0), at(16, 8, 0)},
+		{"weekly on the day before the hour", models.DigestWeekly, at(16, 6, 0), at(16, 8, 0)},
+		{"weekly on the day after the hour", models.DigestWeekly, at(16, 9, 0), at(23, 8, 0)},
+		{"weekly the day after", models.DigestWeekly, at(17, 8, 0), at(23, 8, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextDigestTime(tt.frequency, tt.now); !got.Equal(tt.want) {
+				t.Errorf("nextDigestTime = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestStart(t *testing.T) {
+	now := time.Date(2026, 3, 15, 8, 0, 0, 0, time.UTC)
+	last := now.Add(-36 * time.Hour)
+
+	tests := []struct {
+		name       string
+		lastDigest *time.Time
+		frequency  string
+		want       time.Time
+	}{
+		{"first daily digest", nil, models.DigestDaily, now.AddDate(0, 0, -1)},
+		{"first weekly digest", nil, models.DigestWeekly, now.AddDate(0, 0, -7)},
+		{"daily after a missed day", &last, models.DigestDaily, last},
+		{"weekly since the last one", &last, models.DigestWeekly, last},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference := &models.NotificationPreference{LastDigestAt: tt.lastDigest}
+			if got := digestStart(preference, tt.frequency, now); !got.Equal(tt.want) {
+				t.Errorf("digestStart = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyNotificationPreferenceRequest(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name     string
+		req      models.NotificationPreferenceRequest
+		want     models.NotificationPreference
+		wantNext bool
+		wantErr  bool
+	}{
+		{"defaults", models.NotificationPreferenceRequest{}, models.NotificationPreference{Digest: models.DigestNone, CriticalAlerts: true, Enabled: true}, false, false},
+		{"opt-outs", models.NotificationPreferenceRequest{CriticalAlerts: &off, Enabled: &off}, models.NotificationPreference{Digest: models.DigestNone}, false, false},
+		{"weekly digest", models.NotificationPreferenceRequest{Digest: " Weekly ", CriticalAlerts: &on}, models.NotificationPreference{Digest: models.DigestWeekly, CriticalAlerts: true, Enabled: true}, true, false},
+		{"unknown digest", models.NotificationPreferenceRequest{Digest: "hourly"}, models.NotificationPreference{}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var preference models.NotificationPreference
+			err := (&NotificationService{}).applyRequest(&preference, tt.req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNotificationPreference) {
+					t.Errorf("applyRequest = %v, want ErrInvalidNotificationPreference", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyRequest: %v", err)
+			}
+			if preference.Digest != tt.want.Digest || preference.CriticalAlerts != tt.want.CriticalAlerts || preference.Enabled != tt.want.Enabled {
+				t.Errorf("applyRequest = digest %q, critical alerts %v, enabled %v; want %q, %v, %v",
+					preference.Digest, preference.CriticalAlerts, preference.Enabled, tt.want.Digest, tt.want.CriticalAlerts, tt.want.Enabled)
+			}
+			if (preference.NextDigestAt != nil) != tt.wantNext {
+				t.Errorf("NextDigestAt = %v, want set: %v", preference.NextDigestAt, tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrQuotaExceeded is returned when starting analyses would exceed a
+// workspace's monthly analysis quota
+var ErrQuotaExceeded = errors.New("monthly analysis quota exceeded")
+
+// QuotaSettings configures per-workspace usage quotas
+type QuotaSettings struct {
+	MonthlyAnalyses int // default analyses per workspace per calendar month; 0 is unlimited
+}
+
+// quotaSettings holds the quotas used when a workspace does not override them
+var quotaSettings = QuotaSettings{}
+
+// ConfigureQuotas sets the default per-workspace quotas
+func ConfigureQuotas(settings QuotaSettings) {
+	quotaSettings = settings
+}
+
+// usagePeriod returns the calendar month containing t in local time as YYYY-MM,
+// along with the time the next one starts
+func usagePeriod(t time.Time) (string, time.Time) {
+	t = t.In(time.Local)
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+	return start.Format("2006-01"), start.AddDate(0, 1, 0)
+}
+
+// monthlyAnalysisQuota returns the quota that applies to a workspace; 0 is unlimited
+func monthlyAnalysisQuota(workspace *models.Workspace) int {
+	if workspace.MonthlyAnalysisQuota != nil {
+		return *workspace.MonthlyAnalysisQuota
+	}
+	return quotaSettings.MonthlyAnalyses
+}
+
+// reserveAnalyses counts n analyses against a workspace's quota for the
+// current month, or returns ErrQuotaExceeded if that would exceed it. The
+// check and increment are a single statement, so concurrent requests cannot
+// overshoot the quota. Run it in the transaction that starts the analyses so
+// the reservation is undone if they are not started.
+func reserveAnalyses(db *gorm.DB, workspaceID uint, n int) error {
+	var workspace models.Workspace
+	if err := db.First(&workspace, workspaceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWorkspaceNotFound
+		}
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	period, _ := usagePeriod(time.Now())
+	usage := models.AnalysisUsage{WorkspaceID: workspaceID, Period: period, UpdatedAt: time.Now()}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&usage).Error; err != nil {
+		return fmt.Errorf("failed to record analysis usage: %w", err)
+	}
+
+	query := db.Model(&models.AnalysisUsage{}).Where("workspace_id = ? AND period = ?", workspaceID, period)
+	quota := monthlyAnalysisQuota(&workspace)
+	if quota > 0 {
+		query = query.Where("analyses + ? <= ?", n, quota)
+	}
+	result := query.Updates(map[string]interface{}{
+		"analyses":   gorm.Expr("analyses + ?", n),
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to record analysis usage: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: workspace %q may run %d analyses in %s", ErrQuotaExceeded, workspace.Name, quota, period)
+	}
+	return nil
+}
+
+// GetAnalysisUsage reports the analyses a workspace started this month against its quota
+func (s *WorkspaceService) GetAnalysisUsage(workspaceID uint) (*models.AnalysisUsageResponse, error) {
+	workspace, err := s.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, resetsAt := usagePeriod(time.Now())
+	var usage models.AnalysisUsage
+	if err := s.db.Where("workspace_id = ? AND period = ?", workspaceID, period).First(&usage).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get analysis usage: %w", err)
+	}
+
+	response := &models.AnalysisUsageResponse{
+		WorkspaceID: workspaceID,
+		Period:      period,
+		Analyses:    usage.Analyses,
+		Quota:       monthlyAnalysisQuota(workspace),
+		ResetsAt:    resetsAt,
+	}
+	if response.Quota > 0 {
+		remaining := response.Quota - usage.Analyses
+		if remaining < 0 {
+			remaining = 0
+		}
+		response.Remaining = &remaining
+	}
+	return response, nil
+}
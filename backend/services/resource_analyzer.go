@@ -0,0 +1,56 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// resourceSelectors lists the elements that make the browser fetch a sub-resource
+var resourceSelectors = []struct {
+	selector  string
+	attribute string
+}{
+	{"script[src]", "src"},
+	{"link[rel~='stylesheet'][href], link[rel~='preload'][href], link[rel~='icon'][href]", "href"},
+	{"img[src]", "src"},
+	{"iframe[src]", "src"},
+	{"video[src], audio[src], source[src], track[src], embed[src]", "src"},
+	{"object[data]", "data"},
+}
+
+// analyzeResources counts the sub-resource requests referenced by the page
+func (s *SEOAnalyzer) analyzeResources(doc *goquery.Document, baseURL *url.URL, result *SEOAnalysisResult) {
+	seen := make(map[string]bool)
+
+	for _, resource := range resourceSelectors {
+		doc.Find(resource.selector).Each(func(i int, sel *goquery.Selection) {
+			value := strings.TrimSpace(sel.AttrOr(resource.attribute, ""))
+			if value == "" || strings.HasPrefix(value, "data:") {
+				return
+			}
+
+			resourceURL, err := url.Parse(value)
+			if err != nil {
+				return
+			}
+			resolvedURL := baseURL.ResolveReference(resourceURL)
+			if resolvedURL.Scheme != "http" && resolvedURL.Scheme != "https" {
+				return
+			}
+
+			// Browsers fetch each distinct resource once
+			key := resolvedURL.String()
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+
+			result.TotalRequests++
+			if resolvedURL.Host != "" && resolvedURL.Host != baseURL.Host {
+				result.ThirdPartyRequests++
+			}
+		})
+	}
+}
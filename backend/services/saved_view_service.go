@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidSavedView is returned when a saved view definition fails validation
+var ErrInvalidSavedView = errors.New("invalid saved view")
+
+const (
+	// maxSavedViewNameLength matches the size of the saved_views.name column
+	maxSavedViewNameLength = 100
+	// maxSavedViewSortLength matches the size of the saved_views.sort column
+	maxSavedViewSortLength = 200
+)
+
+// SavedViewService handles business logic for saved views. Views belong to a
+// user and are only visible to that user.
+type SavedViewService struct {
+	db          *gorm.DB
+	workspaceID uint
+	userID      uint
+}
+
+// NewSavedViewService creates a new saved view service instance
+func NewSavedViewService() *SavedViewService {
+	return &SavedViewService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *SavedViewService) ForWorkspace(workspaceID uint) *SavedViewService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// ForUser returns a copy of the service whose queries are limited to a user's views
+func (s *SavedViewService) ForUser(userID uint) *SavedViewService {
+	scoped := *s
+	scoped.userID = userID
+	return &scoped
+}
+
+// owned limits a query to the views of the service's workspace and user
+func (s *SavedViewService) owned(db *gorm.DB) *gorm.DB {
+	return db.Scopes(inWorkspace(s.workspaceID)).Where("user_id = ?", s.userID)
+}
+
+// GetAllViews retrieves the user's saved views, ordered by name
+func (s *SavedViewService) GetAllViews() ([]models.SavedView, error) {
+	var views []models.SavedView
+	if err := s.db.Scopes(s.owned).Order("name ASC").Find(&views).Error; err != nil {
+		return nil, fmt.Errorf("failed to get saved views: %w", err)
+	}
+	return views, nil
+}
+
+// GetViewByID retrieves a saved view by its ID
+func (s *SavedViewService) GetViewByID(id uint) (*models.SavedView, error) {
+	var view models.SavedView
+	if err := s.db.Scopes(s.owned).First(&view, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("saved view not found")
+		}
+		return nil, fmt.Errorf("failed to get saved view: %w", err)
+	}
+	return &view, nil
+}
+
+// CreateView validates and creates a saved view
+func (s *SavedViewService) CreateView(req models.SavedViewRequest) (*models.SavedView, error) {
+	view := models.SavedView{
+		WorkspaceID: s.workspaceID,
+		UserID:      s.userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.applyRequest(&view, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&view).Error; err != nil {
+		return nil, fmt.Errorf("failed to create saved view: %w", err)
+	}
+	return &view, nil
+}
+
+// UpdateView validates and replaces a saved view
+func (s *SavedViewService) UpdateView(id uint, req models.SavedViewRequest) (*models.SavedView, error) {
+	view, err := s.GetViewByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(view, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(view).Error; err != nil {
+		return nil, fmt.Errorf("failed to update saved view: %w", err)
+	}
+	return view, nil
+}
+
+// DeleteView deletes a saved view by its ID
+func (s *SavedViewService) DeleteView(id uint) error {
+	result := s.db.Scopes(s.owned).Delete(&models.SavedView{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete saved view: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("saved view not found")
+	}
+	return nil
+}
+
+// applyRequest validates a saved view request and copies it onto the model
+func (s *SavedViewService) applyRequest(view *models.SavedView, req models.SavedViewRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSavedView)
+	}
+	if len(name) > maxSavedViewNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidSavedView, maxSavedViewNameLength)
+	}
+
+	filter := strings.TrimSpace(req.Filter)
+	sort := strings.TrimSpace(req.Sort)
+	if filter == "" && sort == "" {
+		return fmt.Errorf("%w: a filter or a sort is required", ErrInvalidSavedView)
+	}
+	if filter != "" {
+		if _, err := compileURLFilter(filter, time.Now()); err != nil {
+			return err
+		}
+	}
+	if sort != "" {
+		if len(sort) > maxSavedViewSortLength {
+			return fmt.Errorf("%w: sort must be at most %d characters", ErrInvalidSavedView, maxSavedViewSortLength)
+		}
+		if _, err := parseURLSort(sort); err != nil {
+			return err
+		}
+	}
+
+	var count int64
+	if err := s.db.Model(&models.SavedView{}).Scopes(s.owned).Where("name = ? AND id <> ?", name, view.ID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check saved view name: %w", err)
+	}
+	if count > 0 {
+		return errors.New("saved view already exists")
+	}
+
+	view.Name = name
+	view.Filter = filter
+	view.Sort = sort
+	view.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidSchedule is returned when a schedule definition fails validation
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// minScheduleInterval is the shortest allowed fixed interval between runs
+const minScheduleInterval = 5 * time.Minute
+
+// ScheduleService handles business logic for recurring analysis schedules
+type ScheduleService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewScheduleService creates a new schedule service instance
+func NewScheduleService() *ScheduleService {
+	return &ScheduleService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *ScheduleService) ForWorkspace(workspaceID uint) *ScheduleService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllSchedules retrieves all schedules
+func (s *ScheduleService) GetAllSchedules() ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tag").Order("name ASC").Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// GetScheduleByID retrieves a schedule by its ID
+func (s *ScheduleService) GetScheduleByID(id uint) (*models.Schedule, error) {
+	var schedule models.Schedule
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tag").First(&schedule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("schedule not found")
+		}
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// CreateSchedule validates and creates a schedule
+func (s *ScheduleService) CreateSchedule(req models.ScheduleRequest) (*models.Schedule, error) {
+	schedule := models.Schedule{
+		WorkspaceID: s.workspaceID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.applyRequest(&schedule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Omit("Tag").Create(&schedule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	return s.GetScheduleByID(schedule.ID)
+}
+
+// UpdateSchedule validates and replaces a schedule, recomputing its next run
+func (s *ScheduleService) UpdateSchedule(id uint, req models.ScheduleRequest) (*models.Schedule, error) {
+	schedule, err := s.GetScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(schedule, req); err != nil {
+		return nil, err
+	}
+
+	schedule.Tag = nil
+	if err := s.db.Omit("Tag").Save(schedule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	return s.GetScheduleByID(id)
+}
+
+// DeleteSchedule deletes a schedule by ID
+func (s *ScheduleService) DeleteSchedule(id uint) error {
+	result := s.db.Scopes(inWorkspace(s.workspaceID)).Delete(&models.Schedule{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete schedule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("schedule not found")
+	}
+	return nil
+}
+
+// applyRequest validates a schedule request and copies it onto the model
+func (s *ScheduleService) applyRequest(schedule *models.Schedule, req models.ScheduleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	cron := strings.TrimSpace(req.Cron)
+	interval := strings.TrimSpace(req.Interval)
+	tag := strings.TrimSpace(req.Tag)
+
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSchedule)
+	}
+	if (cron == "") == (interval == "") {
+		return fmt.Errorf("%w: exactly one of cron or interval is required", ErrInvalidSchedule)
+	}
+	if req.URLID != nil && tag != "" {
+		return fmt.Errorf("%w: url_id and tag cannot both be set", ErrInvalidSchedule)
+	}
+	if req.JitterSeconds < 0 {
+		return fmt.Errorf("%w: jitter_seconds must not be negative", ErrInvalidSchedule)
+	}
+
+	schedule.Cron = ""
+	schedule.IntervalSeconds = 0
+	if cron != "" {
+		if _, err := ParseCron(cron); err != nil {
+			return fmt.Errorf("%w: invalid cron expression: %v", ErrInvalidSchedule, err)
+		}
+		schedule.Cron = cron
+	} else {
+		duration, err := time.ParseDuration(interval)
+		if err != nil {
+			return fmt.Errorf("%w: invalid interval: %v", ErrInvalidSchedule, err)
+		}
+		if duration < minScheduleInterval {
+			return fmt.Errorf("%w: interval must be at least %s", ErrInvalidSchedule, minScheduleInterval)
+		}
+		schedule.IntervalSeconds = int(duration.Seconds())
+	}
+
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, req.Timezone)
+		}
+	}
+
+	schedule.URLID = nil
+	schedule.TagID = nil
+	if req.URLID != nil {
+		var url models.URL
+		if err := s.db.Scopes(inWorkspace(schedule.WorkspaceID)).First(&url, *req.URLID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("URL not found")
+			}
+			return fmt.Errorf("failed to find URL: %w", err)
+		}
+		schedule.URLID = req.URLID
+	} else if tag != "" {
+		tags, err := findOrCreateTags(s.db, schedule.WorkspaceID, []string{tag})
+		if err != nil {
+			return err
+		}
+		schedule.TagID = &tags[0].ID
+	}
+
+	schedule.Name = name
+	schedule.Timezone = req.Timezone
+	schedule.JitterSeconds = req.JitterSeconds
+	schedule.Enabled = true
+	if req.Enabled != nil {
+		schedule.Enabled = *req.Enabled
+	}
+	schedule.UpdatedAt = time.Now()
+
+	schedule.NextRunAt = nil
+	if schedule.Enabled {
+		next, err := NextScheduleRun(schedule, time.Now())
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+		schedule.NextRunAt = &next
+	}
+
+	return nil
+}
+
+// GetScheduleURLIDs returns the IDs of the URLs in a schedule's workspace that it analyzes
+func (s *ScheduleService) GetScheduleURLIDs(schedule *models.Schedule) ([]uint, error) {
+	var ids []uint
+	query := s.db.Model(&models.URL{}).Scopes(inWorkspace(schedule.WorkspaceID))
+	switch schedule.Target() {
+	case models.ScheduleTargetURL:
+		query = query.Where("id = ?", *schedule.URLID)
+	case models.ScheduleTargetTag:
+		query = query.Where("id IN (?)", s.db.Table("url_tags").Select("url_id").Where("tag_id = ?", *schedule.TagID))
+	}
+	if err := query.Order("id ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to resolve schedule URLs: %w", err)
+	}
+	return ids, nil
+}
+
+// NextScheduleRun returns the next run time of a schedule after from, including jitter
+func NextScheduleRun(schedule *models.Schedule, from time.Time) (time.Time, error) {
+	var next time.Time
+	if schedule.Cron != "" {
+		cron, err := ParseCron(schedule.Cron)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+		}
+		location := time.Local
+		if schedule.Timezone != "" {
+			if location, err = time.LoadLocation(schedule.Timezone); err != nil {
+				return time.Time{}, fmt.Errorf("unknown timezone %q", schedule.Timezone)
+			}
+		}
+		if next = cron.Next(from.In(location)); next.IsZero() {
+			return time.Time{}, errors.New("cron expression never matches")
+		}
+	} else {
+		if schedule.IntervalSeconds <= 0 {
+			return time.Time{}, errors.New("schedule has no cron expression or interval")
+		}
+		next = from.Add(time.Duration(schedule.IntervalSeconds) * time.Second)
+	}
+
+	if schedule.JitterSeconds > 0 {
+		next = next.Add(time.Duration(rand.Intn(schedule.JitterSeconds+1)) * time.Second)
+	}
+	return next, nil
+}
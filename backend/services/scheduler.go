@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// Schedule run statuses
+const (
+	ScheduleRunRunning   = "running"
+	ScheduleRunCompleted = "completed"
+	ScheduleRunFailed    = "failed"
+	ScheduleRunSkipped   = "skipped"
+)
+
+// Scheduler runs due schedules in-process. Each schedule analyzes its URLs one
+// at a time; a schedule that is still running when it becomes due again is
+// skipped for that slot instead of overlapping the slow run. It also sends due
+// email digests.
+type Scheduler struct {
+	db              *gorm.DB
+	urlService      *URLService
+	scheduleService *ScheduleService
+	notifications   *NotificationService
+	tick            time.Duration
+
+	mu      sync.Mutex
+	running map[uint]bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler that checks for due schedules every tick
+func NewScheduler(tick time.Duration) *Scheduler {
+	if tick <= 0 {
+		tick = 30 * time.Second
+	}
+	return &Scheduler{
+		db:              database.GetDB(),
+		urlService:      NewURLService(),
+		scheduleService: NewScheduleService(),
+		notifications:   NewNotificationService(),
+		tick:            tick,
+		running:         make(map[uint]bool),
+		stop:            make(chan struct{}),
+	}
+}
+
+// Start begins polling for due schedules in the background
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.tick)
+		defer ticker.Stop()
+
+		log.Printf("Scheduler started (checking every %s)", s.tick)
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-ticker.C:
+				s.dispatchDue(now)
+				s.notifications.SendDueDigests(now)
+			}
+		}
+	}()
+}
+
+// Stop stops polling and waits for in-flight runs to finish the URL they are
+// analyzing, or until ctx is done
+func (s *Scheduler) Stop(ctx context.Context) {
+	close(s.stop)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("Scheduler stopped")
+	case <-ctx.Done():
+		log.Println("Scheduler stopped before in-flight runs finished")
+	}
+}
+
+// dispatchDue starts every enabled schedule whose next run is due
+func (s *Scheduler) dispatchDue(now time.Time) {
+	var schedules []models.Schedule
+	if err := s.db.Scopes(allWorkspaces).Where("enabled = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC").Find(&schedules).Error; err != nil {
+		log.Printf("Scheduler failed to load due schedules: %v", err)
+		return
+	}
+
+	for i := range schedules {
+		schedule := schedules[i]
+
+		updates := map[string]interface{}{
+			"updated_at": time.Now(),
+		}
+		next, err := NextScheduleRun(&schedule, now)
+		if err != nil {
+			// A schedule that can no longer be computed is disabled rather than retried every tick
+			updates["enabled"] = false
+			updates["next_run_at"] = nil
+			updates["last_run_status"] = ScheduleRunFailed
+			updates["last_run_error"] = err.Error()
+			s.db.Model(&models.Schedule{}).Where("id = ?", schedule.ID).Updates(updates)
+			log.Printf("Disabled schedule %q: %v", schedule.Name, err)
+			continue
+		}
+		updates["next_run_at"] = next
+
+		s.mu.Lock()
+		alreadyRunning := s.running[schedule.ID]
+		if !alreadyRunning {
+			s.running[schedule.ID] = true
+		}
+		s.mu.Unlock()
+
+		if alreadyRunning {
+			updates["last_run_status"] = ScheduleRunSkipped
+			updates["last_run_error"] = "Previous run was still in progress"
+			s.db.Model(&models.Schedule{}).Where("id = ?", schedule.ID).Updates(updates)
+			log.Printf("Skipped schedule %q: previous run still in progress", schedule.Name)
+			continue
+		}
+
+		updates["last_run_at"] = now
+		updates["last_run_status"] = ScheduleRunRunning
+		updates["last_run_error"] = ""
+		if err := s.db.Model(&models.Schedule{}).Where("id = ?", schedule.ID).Updates(updates).Error; err != nil {
+			log.Printf("Scheduler failed to update schedule %q: %v", schedule.Name, err)
+			s.finish(schedule.ID)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.run(schedule)
+	}
+}
+
+// run analyzes every URL targeted by a schedule, one at a time
+func (s *Scheduler) run(schedule models.Schedule) {
+	defer s.wg.Done()
+	defer s.finish(schedule.ID)
+
+	status, runError := ScheduleRunCompleted, ""
+	defer func() {
+		s.db.Model(&models.Schedule{}).Where("id = ?", schedule.ID).Updates(map[string]interface{}{
+			"last_run_status": status,
+			"last_run_error":  runError,
+			"updated_at":      time.Now(),
+		})
+	}()
+
+	ids, err := s.scheduleService.GetScheduleURLIDs(&schedule)
+	if err != nil {
+		status, runError = ScheduleRunFailed, err.Error()
+		log.Printf("Schedule %q failed: %v", schedule.Name, err)
+		return
+	}
+
+	log.Printf("Running schedule %q for %d URLs", schedule.Name, len(ids))
+	analyzed, skipped, failed := 0, 0, 0
+	for _, id := range ids {
+		select {
+		case <-s.stop:
+			status, runError = ScheduleRunFailed, "Scheduler stopped before the run finished"
+			return
+		default:
+		}
+
+		started, err := s.urlService.analyzeIfIdle(schedule.WorkspaceID, id)
+		switch {
+		case errors.Is(err, ErrQuotaExceeded):
+			// The remaining URLs would be rejected as well
+			status, runError = ScheduleRunFailed, err.Error()
+			log.Printf("Schedule %q stopped after %d analyses: %v", schedule.Name, analyzed, err)
+			return
+		case err != nil:
+			failed++
+			log.Printf("Schedule %q: analysis of URL ID %d failed: %v", schedule.Name, id, err)
+		case !started:
+			// Already being analyzed by another run or a manual request
+			skipped++
+		default:
+			analyzed++
+		}
+	}
+
+	if failed > 0 {
+		status = ScheduleRunFailed
+		runError = fmt.Sprintf("%d of %d analyses failed", failed, len(ids))
+	}
+	log.Printf("Schedule %q finished: %d analyzed, %d skipped, %d failed", schedule.Name, analyzed, skipped, failed)
+}
+
+// finish marks a schedule as no longer running
+func (s *Scheduler) finish(id uint) {
+	s.mu.Lock()
+	delete(s.running, id)
+	s.mu.Unlock()
+}
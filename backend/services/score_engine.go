@@ -0,0 +1,249 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"website-analyzer-backend/models"
+)
+
+// Score categories
+const (
+	ScoreCategoryContent       = "content"
+	ScoreCategoryLinks         = "links"
+	ScoreCategoryPerformance   = "performance"
+	ScoreCategoryAccessibility = "accessibility"
+	ScoreCategoryMobile        = "mobile"
+	ScoreCategorySecurity      = "security"
+)
+
+// Default thresholds used by the built-in rules
+const (
+	defaultMaxLoadTime = 3.0             // seconds
+	defaultMaxPageSize = 3 * 1024 * 1024 // bytes
+)
+
+// ScoreRule is a single weighted, explainable rule evaluated over an analysis result
+type ScoreRule struct {
+	ID       string
+	Name     string
+	Category string
+	Weight   float64
+	FixHint  string
+	Evaluate func(result *SEOAnalysisResult) (passed bool, detail string)
+}
+
+// ScoreEngine computes a 0-100 health score from weighted rules
+type ScoreEngine struct {
+	rules []ScoreRule
+}
+
+// NewScoreEngine creates a score engine with the built-in rule set
+func NewScoreEngine() *ScoreEngine {
+	return &ScoreEngine{
+		rules: defaultScoreRules(defaultMaxLoadTime, defaultMaxPageSize),
+	}
+}
+
+// Rules returns the rules evaluated by the engine
+func (e *ScoreEngine) Rules() []ScoreRule {
+	return e.rules
+}
+
+// Evaluate scores an analysis result, returning the overall score, per-category
+// subscores and the outcome of every rule
+func (e *ScoreEngine) Evaluate(result *SEOAnalysisResult) models.ScoreReport {
+	report := models.ScoreReport{
+		Categories: make(map[string]int),
+		Rules:      make([]models.ScoreRuleResult, 0, len(e.rules)),
+	}
+
+	var earned, total float64
+	categoryEarned := make(map[string]float64)
+	categoryTotal := make(map[string]float64)
+
+	for _, rule := range e.rules {
+		passed, detail := rule.Evaluate(result)
+
+		total += rule.Weight
+		categoryTotal[rule.Category] += rule.Weight
+		if passed {
+			earned += rule.Weight
+			categoryEarned[rule.Category] += rule.Weight
+		}
+
+		ruleResult := models.ScoreRuleResult{
+			ID:       rule.ID,
+			Name:     rule.Name,
+			Category: rule.Category,
+			Weight:   rule.Weight,
+			Passed:   passed,
+			Detail:   detail,
+		}
+		if !passed {
+			ruleResult.FixHint = rule.FixHint
+			report.FailedRules++
+		} else {
+			report.PassedRules++
+		}
+		report.Rules = append(report.Rules, ruleResult)
+	}
+
+	report.Score = percentage(earned, total)
+	for category, weight := range categoryTotal {
+		report.Categories[category] = percentage(categoryEarned[category], weight)
+	}
+
+	return report
+}
+
+// percentage returns earned/total as a rounded 0-100 integer
+func percentage(earned, total float64) int {
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(earned / total * 100))
+}
+
+// defaultScoreRules returns the built-in rule set
+func defaultScoreRules(maxLoadTime float64, maxPageSize int64) []ScoreRule {
+	return []ScoreRule{
+		// Content
+		{
+			ID: "title-present", Name: "Page has a title", Category: ScoreCategoryContent, Weight: 10,
+			FixHint: "Add a descriptive <title> element to the page head.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.MetaTitle != "", fmt.Sprintf("Title: %q", r.MetaTitle)
+			},
+		},
+		{
+			ID: "title-length", Name: "Title is 30-60 characters", Category: ScoreCategoryContent, Weight: 5,
+			FixHint: "Keep the title between 30 and 60 characters so it is not truncated in search results.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				length := len([]rune(r.MetaTitle))
+				return length >= 30 && length <= 60, fmt.Sprintf("Title length: %d characters", length)
+			},
+		},
+		{
+			ID: "meta-description-present", Name: "Meta description is present", Category: ScoreCategoryContent, Weight: 8,
+			FixHint: "Add a <meta name=\"description\"> summarizing the page.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.MetaDescription != "", fmt.Sprintf("Description length: %d characters", len([]rune(r.MetaDescription)))
+			},
+		},
+		{
+			ID: "meta-description-length", Name: "Meta description is 70-160 characters", Category: ScoreCategoryContent, Weight: 4,
+			FixHint: "Write a meta description between 70 and 160 characters.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				length := len([]rune(r.MetaDescription))
+				return length >= 70 && length <= 160, fmt.Sprintf("Description length: %d characters", length)
+			},
+		},
+		{
+			ID: "single-h1", Name: "Exactly one H1", Category: ScoreCategoryContent, Weight: 8,
+			FixHint: "Use exactly one <h1> describing the main topic of the page.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.H1Count == 1, fmt.Sprintf("H1 count: %d", r.H1Count)
+			},
+		},
+		{
+			ID: "html5-doctype", Name: "Uses the HTML5 doctype", Category: ScoreCategoryContent, Weight: 2,
+			FixHint: "Start the document with <!DOCTYPE html>.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.HTMLVersion == "HTML5", fmt.Sprintf("HTML version: %s", r.HTMLVersion)
+			},
+		},
+
+		// Links
+		{
+			ID: "no-broken-links", Name: "No broken links", Category: ScoreCategoryLinks, Weight: 10,
+			FixHint: "Fix or remove links that return errors.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return len(r.BrokenLinks) == 0, fmt.Sprintf("Broken links: %d", len(r.BrokenLinks))
+			},
+		},
+		{
+			ID: "has-internal-links", Name: "Has internal links", Category: ScoreCategoryLinks, Weight: 3,
+			FixHint: "Link to related pages on the same site to help crawlers and users navigate.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.InternalLinks > 0, fmt.Sprintf("Internal links: %d", r.InternalLinks)
+			},
+		},
+
+		// Performance
+		{
+			ID: "load-time", Name: fmt.Sprintf("Loads in under %.1fs", maxLoadTime), Category: ScoreCategoryPerformance, Weight: 10,
+			FixHint: "Reduce server response time, enable caching and compress responses.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.LoadTime > 0 && r.LoadTime < maxLoadTime, fmt.Sprintf("Load time: %.2fs", r.LoadTime)
+			},
+		},
+		{
+			ID: "page-size", Name: fmt.Sprintf("Page is smaller than %dMB", maxPageSize/(1024*1024)), Category: ScoreCategoryPerformance, Weight: 5,
+			FixHint: "Remove unused markup and inline assets to reduce the page size.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.PageSize > 0 && r.PageSize < maxPageSize, fmt.Sprintf("Page size: %d bytes", r.PageSize)
+			},
+		},
+
+		// Accessibility
+		{
+			ID: "no-critical-a11y", Name: "No critical accessibility violations", Category: ScoreCategoryAccessibility, Weight: 8,
+			FixHint: "Add alt text to images, labels to form fields and accessible names to buttons.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				critical := 0
+				for _, violation := range r.AccessibilityViolations {
+					if violation.Severity == "critical" {
+						critical++
+					}
+				}
+				return critical == 0, fmt.Sprintf("Critical violations: %d", critical)
+			},
+		},
+		{
+			ID: "a11y-violations", Name: "Fewer than 10 accessibility violations", Category: ScoreCategoryAccessibility, Weight: 4,
+			FixHint: "Work through the accessibility report, starting with the most severe violations.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.AccessibilityViolationCount < 10, fmt.Sprintf("Violations: %d", r.AccessibilityViolationCount)
+			},
+		},
+
+		// Mobile
+		{
+			ID: "viewport", Name: "Responsive viewport", Category: ScoreCategoryMobile, Weight: 6,
+			FixHint: "Add <meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.Mobile.HasViewport && r.Mobile.DeviceWidth, fmt.Sprintf("Viewport: %q", r.Mobile.Viewport)
+			},
+		},
+		{
+			ID: "mobile-friendly", Name: "Passes static mobile checks", Category: ScoreCategoryMobile, Weight: 4,
+			FixHint: "Remove fixed-width layouts and plugin content, and allow users to zoom.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.Mobile.MobileFriendly, fmt.Sprintf("Mobile issues: %d", r.Mobile.IssueCount)
+			},
+		},
+
+		// Security
+		{
+			ID: "no-vulnerable-libraries", Name: "No JavaScript libraries with known vulnerabilities", Category: ScoreCategorySecurity, Weight: 6,
+			FixHint: "Upgrade the affected libraries to a patched version.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				vulnerable := 0
+				for _, library := range r.JSLibraries {
+					if len(library.Vulnerabilities) > 0 {
+						vulnerable++
+					}
+				}
+				return vulnerable == 0, fmt.Sprintf("Vulnerable libraries: %d", vulnerable)
+			},
+		},
+		{
+			ID: "secure-forms", Name: "No high-severity form issues", Category: ScoreCategorySecurity, Weight: 7,
+			FixHint: "Submit credentials over HTTPS to your own origin only.",
+			Evaluate: func(r *SEOAnalysisResult) (bool, string) {
+				return r.HighSeverityForms == 0, fmt.Sprintf("High-severity forms: %d", r.HighSeverityForms)
+			},
+		},
+	}
+}
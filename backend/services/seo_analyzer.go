@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,7 +15,10 @@ import (
 
 // SEOAnalyzer handles comprehensive SEO analysis of websites
 type SEOAnalyzer struct {
-	client *http.Client
+	client       *http.Client
+	techDetector *TechnologyDetector
+	scoreEngine  *ScoreEngine
+	auditRules   *AuditRuleService
 }
 
 // NewSEOAnalyzer creates a new SEO analyzer instance
@@ -23,6 +27,9 @@ func NewSEOAnalyzer() *SEOAnalyzer {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		techDetector: NewTechnologyDetector(),
+		scoreEngine:  NewScoreEngine(),
+		auditRules:   NewAuditRuleService(),
 	}
 }
 
@@ -39,6 +46,7 @@ type SEOAnalysisResult struct {
 	HTMLVersion     string
 	MetaTitle       string
 	MetaDescription string
+	Noindex         bool
 	H1Tags          []string
 	H2Tags          []string
 	H3Tags          []string
@@ -58,13 +66,29 @@ type SEOAnalysisResult struct {
 	BrokenLinks     []BrokenLink
 	HasLoginForm    bool
 	FormCount       int
+	Forms           []models.FormReport
+	HighSeverityForms int
+	Mobile          models.MobileAnalysis
+	Technologies    []models.Technology
+	Scripts         []models.ScriptInfo
+	JSLibraries     []models.JSLibrary
+	AccessibilityViolations     []models.AccessibilityViolation
+	AccessibilityViolationCount int
+	Score           models.ScoreReport
+	RuleViolations  []models.RuleViolation
+	Findings        []models.Finding
+	Metrics         []models.Metric
+	CheckResults    []models.CheckResult
+	TotalRequests      int
+	ThirdPartyRequests int
 	LoadTime        float64
 	PageSize        int64
 	ErrorMessage    string
 }
 
-// AnalyzeURL performs comprehensive SEO analysis on a given URL
-func (s *SEOAnalyzer) AnalyzeURL(targetURL string) (*SEOAnalysisResult, error) {
+// AnalyzeURL performs comprehensive SEO analysis on a given URL, evaluating the
+// audit rules of the workspace it belongs to
+func (s *SEOAnalyzer) AnalyzeURL(targetURL string, workspaceID uint) (*SEOAnalysisResult, error) {
 	result := &SEOAnalysisResult{}
 	
 	// Parse the target URL
@@ -109,24 +133,17 @@ func (s *SEOAnalyzer) AnalyzeURL(targetURL string) (*SEOAnalysisResult, error) {
 		result.PageSize = int64(len(htmlContent))
 	}
 
-	// Analyze HTML version
-	result.HTMLVersion = s.detectHTMLVersion(doc)
-
-	// Extract meta information
-	result.MetaTitle = s.extractMetaTitle(doc)
-	result.MetaDescription = s.extractMetaDescription(doc)
-
-	// Analyze heading tags
-	s.analyzeHeadingTags(doc, result)
-
-	// Count images
-	result.ImageCount = doc.Find("img").Length()
-
-	// Analyze links
-	s.analyzeLinks(doc, parsedURL, result)
-
-	// Analyze forms
-	s.analyzeForms(doc, result)
+	// Run the check pipeline (built-in and registered extension checks)
+	s.runChecks(context.Background(), &Page{
+		URL:         parsedURL,
+		Doc:         doc,
+		Header:      resp.Header,
+		Cookies:     resp.Cookies(),
+		StatusCode:  resp.StatusCode,
+		LoadTime:    loadTime,
+		WorkspaceID: workspaceID,
+		Result:      result,
+	})
 
 	return result, nil
 }
@@ -184,6 +201,32 @@ func (s *SEOAnalyzer) extractMetaDescription(doc *goquery.Document) string {
 	return strings.TrimSpace(description)
 }
 
+// detectNoindex reports whether the page asks search engines not to index it,
+// through a robots meta tag or an X-Robots-Tag header
+func (s *SEOAnalyzer) detectNoindex(doc *goquery.Document, header http.Header) bool {
+	directives := header.Values("X-Robots-Tag")
+	doc.Find("meta[name]").Each(func(i int, sel *goquery.Selection) {
+		name := strings.ToLower(strings.TrimSpace(sel.AttrOr("name", "")))
+		if name == "robots" || name == "googlebot" {
+			directives = append(directives, sel.AttrOr("content", ""))
+		}
+	})
+
+	for _, directive := range directives {
+		for _, value := range strings.Split(strings.ToLower(directive), ",") {
+			// X-Robots-Tag values may be scoped to a crawler, as in "googlebot: noindex"
+			if _, scoped, ok := strings.Cut(value, ":"); ok {
+				value = scoped
+			}
+			value = strings.TrimSpace(value)
+			if value == "noindex" || value == "none" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // analyzeHeadingTags analyzes all heading tags (H1-H6)
 func (s *SEOAnalyzer) analyzeHeadingTags(doc *goquery.Document, result *SEOAnalysisResult) {
 	// H1 tags
@@ -242,7 +285,7 @@ func (s *SEOAnalyzer) analyzeHeadingTags(doc *goquery.Document, result *SEOAnaly
 }
 
 // analyzeLinks analyzes internal and external links and checks for broken links
-func (s *SEOAnalyzer) analyzeLinks(doc *goquery.Document, baseURL *url.URL, result *SEOAnalysisResult) {
+func (s *SEOAnalyzer) analyzeLinks(ctx context.Context, doc *goquery.Document, baseURL *url.URL, result *SEOAnalysisResult) {
 	var allLinks []string
 	
 	doc.Find("a[href]").Each(func(i int, sel *goquery.Selection) {
@@ -278,21 +321,37 @@ func (s *SEOAnalyzer) analyzeLinks(doc *goquery.Document, baseURL *url.URL, resu
 	}
 	
 	for _, link := range allLinks {
-		if brokenLink := s.checkLinkStatus(link); brokenLink != nil {
+		if ctx.Err() != nil {
+			break
+		}
+		if brokenLink := s.checkLinkStatus(ctx, link); brokenLink != nil {
 			result.BrokenLinks = append(result.BrokenLinks, *brokenLink)
 		}
 	}
 }
 
 // checkLinkStatus checks if a link is broken and returns details
-func (s *SEOAnalyzer) checkLinkStatus(link string) *BrokenLink {
+func (s *SEOAnalyzer) checkLinkStatus(ctx context.Context, link string) *BrokenLink {
 	// Create a new client with shorter timeout for link checking
 	client := &http.Client{
 		Timeout: 10 * time.Second,
 	}
 
-	resp, err := client.Head(link)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, link, nil)
+	if err != nil {
+		return &BrokenLink{
+			URL:        link,
+			StatusCode: 0,
+			Error:      err.Error(),
+		}
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
+		if ctx.Err() != nil {
+			// Abandoned because the check ran out of time, not a broken link
+			return nil
+		}
 		return &BrokenLink{
 			URL:        link,
 			StatusCode: 0,
@@ -311,8 +370,8 @@ func (s *SEOAnalyzer) checkLinkStatus(link string) *BrokenLink {
 	return nil
 }
 
-// analyzeForms analyzes forms on the page and detects login forms
-func (s *SEOAnalyzer) analyzeForms(doc *goquery.Document, result *SEOAnalysisResult) {
+// analyzeForms analyzes forms on the page, detects login forms and builds a per-form report
+func (s *SEOAnalyzer) analyzeForms(doc *goquery.Document, baseURL *url.URL, result *SEOAnalysisResult) {
 	forms := doc.Find("form")
 	result.FormCount = forms.Length()
 	
@@ -340,12 +399,56 @@ func (s *SEOAnalyzer) analyzeForms(doc *goquery.Document, result *SEOAnalysisRes
 			}
 		}
 		
-		if hasPasswordField && (hasEmailField || hasUsernameField || hasLoginKeyword) {
+		isLoginForm := hasPasswordField && (hasEmailField || hasUsernameField || hasLoginKeyword)
+		if isLoginForm {
 			result.HasLoginForm = true
 		}
+
+		// Build the detailed report for this form
+		report := s.buildFormReport(form, baseURL, isLoginForm)
+		if report.Severity == "high" {
+			result.HighSeverityForms++
+		}
+		result.Forms = append(result.Forms, report)
 	})
 }
 
+// analyzeScripts builds the script inventory and matches it against the JavaScript library feed
+func (s *SEOAnalyzer) analyzeScripts(doc *goquery.Document, baseURL *url.URL, result *SEOAnalysisResult) {
+	var inlineScripts []string
+
+	doc.Find("script").Each(func(i int, sel *goquery.Selection) {
+		scriptType, _ := sel.Attr("type")
+		_, isAsync := sel.Attr("async")
+		_, isDefer := sel.Attr("defer")
+
+		script := models.ScriptInfo{
+			Type:  scriptType,
+			Async: isAsync,
+			Defer: isDefer,
+		}
+
+		if src, exists := sel.Attr("src"); exists && strings.TrimSpace(src) != "" {
+			scriptURL, err := url.Parse(strings.TrimSpace(src))
+			if err != nil {
+				return
+			}
+			resolvedURL := baseURL.ResolveReference(scriptURL)
+			script.Src = resolvedURL.String()
+			script.ThirdParty = resolvedURL.Host != "" && resolvedURL.Host != baseURL.Host
+		} else {
+			content := sel.Text()
+			script.Inline = true
+			script.Size = len(content)
+			inlineScripts = append(inlineScripts, content)
+		}
+
+		result.Scripts = append(result.Scripts, script)
+	})
+
+	result.JSLibraries = GetJSLibraryDatabase().Detect(result.Scripts, inlineScripts)
+}
+
 // ConvertToJSONStrings converts slices to JSON strings for database storage
 func (s *SEOAnalyzer) ConvertToJSONStrings(result *SEOAnalysisResult) (map[string]string, error) {
 	jsonStrings := make(map[string]string)
@@ -367,6 +470,13 @@ func (s *SEOAnalyzer) ConvertToJSONStrings(result *SEOAnalysisResult) (map[strin
 		})
 	}
 	brokenLinksJSON, _ := json.Marshal(brokenLinksInfo)
+	technologiesJSON, _ := json.Marshal(result.Technologies)
+	scriptsJSON, _ := json.Marshal(result.Scripts)
+	jsLibrariesJSON, _ := json.Marshal(result.JSLibraries)
+	formsJSON, _ := json.Marshal(result.Forms)
+	mobileJSON, _ := json.Marshal(result.Mobile)
+	scoreJSON, _ := json.Marshal(result.Score)
+	checkResultsJSON, _ := json.Marshal(result.CheckResults)
 
 	jsonStrings["h1_tags"] = string(h1JSON)
 	jsonStrings["h2_tags"] = string(h2JSON)
@@ -375,6 +485,13 @@ func (s *SEOAnalyzer) ConvertToJSONStrings(result *SEOAnalysisResult) (map[strin
 	jsonStrings["h5_tags"] = string(h5JSON)
 	jsonStrings["h6_tags"] = string(h6JSON)
 	jsonStrings["broken_links_list"] = string(brokenLinksJSON)
+	jsonStrings["technologies"] = string(technologiesJSON)
+	jsonStrings["scripts"] = string(scriptsJSON)
+	jsonStrings["js_libraries"] = string(jsLibrariesJSON)
+	jsonStrings["forms"] = string(formsJSON)
+	jsonStrings["mobile_analysis"] = string(mobileJSON)
+	jsonStrings["score_breakdown"] = string(scoreJSON)
+	jsonStrings["check_results"] = string(checkResultsJSON)
 	
 	return jsonStrings, nil
 }
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrInvalidTag is returned when a tag name fails validation
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrTagInUse is returned when deleting a tag that schedules, budgets or alert rules target
+	ErrTagInUse = errors.New("tag is in use")
+)
+
+// maxTagNameLength matches the size of the tags.name column
+const maxTagNameLength = 100
+
+// tagReferrers lists the workspace-owned models that can target every URL with a tag
+var tagReferrers = []schema.Tabler{
+	&models.Schedule{},
+	&models.PerformanceBudget{},
+	&models.AlertRule{},
+}
+
+// TagService handles business logic for tags
+type TagService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewTagService creates a new tag service instance
+func NewTagService() *TagService {
+	return &TagService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *TagService) ForWorkspace(workspaceID uint) *TagService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllTags retrieves the workspace's tags with the number of URLs that have each
+func (s *TagService) GetAllTags() ([]models.Tag, map[uint]int64, error) {
+	var tags []models.Tag
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	ids := make([]uint, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	counts, err := s.countURLs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tags, counts, nil
+}
+
+// GetTagByID retrieves a tag by its ID with the number of URLs that have it
+func (s *TagService) GetTagByID(id uint) (*models.Tag, int64, error) {
+	var tag models.Tag
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&tag, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errors.New("tag not found")
+		}
+		return nil, 0, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	counts, err := s.countURLs([]uint{tag.ID})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &tag, counts[tag.ID], nil
+}
+
+// countURLs counts the URLs, outside the trash, that have each of the given tags
+func (s *TagService) countURLs(tagIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TagID    uint
+		URLCount int64
+	}
+	if err := s.db.Table("url_tags").
+		Select("url_tags.tag_id, COUNT(*) AS url_count").
+		Joins("JOIN urls ON urls.id = url_tags.url_id AND urls.deleted_at IS NULL").
+		Where("url_tags.tag_id IN ?", tagIDs).
+		Group("url_tags.tag_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count tagged URLs: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.TagID] = row.URLCount
+	}
+	return counts, nil
+}
+
+// CreateTag validates and creates a tag
+func (s *TagService) CreateTag(req models.TagRequest) (*models.Tag, error) {
+	name, err := validateTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(name, 0); err != nil {
+		return nil, err
+	}
+
+	tag := models.Tag{
+		WorkspaceID: s.workspaceID,
+		Name:        name,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := s.db.Create(&tag).Error; err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+	return &tag, nil
+}
+
+// UpdateTag renames a tag; URLs, schedules, budgets and alert rules keep it
+func (s *TagService) UpdateTag(id uint, req models.TagRequest) (*models.Tag, error) {
+	tag, _, err := s.GetTagByID(id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := validateTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(name, tag.ID); err != nil {
+		return nil, err
+	}
+
+	tag.Name = name
+	tag.UpdatedAt = time.Now()
+	if err := s.db.Save(tag).Error; err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+	return tag, nil
+}
+
+// DeleteTag removes a tag from every URL and deletes it. Tags that schedules,
+// budgets or alert rules target cannot be deleted until those are changed.
+func (s *TagService) DeleteTag(id uint) error {
+	tag, _, err := s.GetTagByID(id)
+	if err != nil {
+		return err
+	}
+
+	for _, model := range tagReferrers {
+		var count int64
+		if err := s.db.Model(model).Where("tag_id = ?", tag.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check tag usage: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: change the schedules, budgets and alert rules that target %q first", ErrTagInUse, tag.Name)
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM url_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return fmt.Errorf("failed to remove tag from URLs: %w", err)
+		}
+		if err := tx.Delete(&models.Tag{}, tag.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkNameAvailable reports an error if another tag in the workspace has the name
+func (s *TagService) checkNameAvailable(name string, exceptID uint) error {
+	var count int64
+	if err := s.db.Model(&models.Tag{}).Where("workspace_id = ? AND name = ? AND id <> ?", s.workspaceID, name, exceptID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check tag name: %w", err)
+	}
+	if count > 0 {
+		return errors.New("tag already exists")
+	}
+	return nil
+}
+
+// validateTagName trims a tag name and checks that it fits the tags table
+func validateTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidTag)
+	}
+	if len(name) > maxTagNameLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", ErrInvalidTag, maxTagNameLength)
+	}
+	return name, nil
+}
+
+// normalizeTagNames trims, de-duplicates and drops empty tag names
+func normalizeTagNames(names []string) []string {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+// findOrCreateTags returns the workspace's tags with the given names, creating missing ones
+func findOrCreateTags(db *gorm.DB, workspaceID uint, names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range normalizeTagNames(names) {
+		if _, err := validateTagName(name); err != nil {
+			return nil, err
+		}
+		tag := models.Tag{WorkspaceID: workspaceID, Name: name}
+		if err := db.Where("workspace_id = ? AND name = ?", workspaceID, name).Attrs(models.Tag{CreatedAt: time.Now(), UpdatedAt: time.Now()}).FirstOrCreate(&tag).Error; err != nil {
+			return nil, fmt.Errorf("failed to resolve tag %q: %w", name, err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"website-analyzer-backend/models"
+)
+
+//go:embed data/technologies.json
+var bundledTechnologies []byte
+
+// technologyFingerprint describes how a single technology can be recognised.
+// Patterns follow the Wappalyzer convention: a regular expression optionally
+// followed by "\;version:\1" to extract a version from a capture group.
+type technologyFingerprint struct {
+	Category string            `json:"category"`
+	Website  string            `json:"website"`
+	Headers  map[string]string `json:"headers"`
+	Meta     map[string]string `json:"meta"`
+	Cookies  map[string]string `json:"cookies"`
+	Scripts  []string          `json:"scripts"`
+	HTML     []string          `json:"html"`
+	Implies  []string          `json:"implies"`
+}
+
+// fingerprintPattern is a compiled fingerprint pattern
+type fingerprintPattern struct {
+	regex   *regexp.Regexp
+	version string
+}
+
+// compiledTechnology holds the compiled patterns for one technology
+type compiledTechnology struct {
+	name     string
+	category string
+	website  string
+	headers  map[string]*fingerprintPattern
+	meta     map[string]*fingerprintPattern
+	cookies  map[string]*fingerprintPattern
+	scripts  []*fingerprintPattern
+	html     []*fingerprintPattern
+	implies  []string
+}
+
+// TechnologyDetector matches pages against the bundled fingerprint database
+type TechnologyDetector struct {
+	technologies []*compiledTechnology
+	byName       map[string]*compiledTechnology
+}
+
+// NewTechnologyDetector creates a detector backed by the bundled fingerprint database
+func NewTechnologyDetector() *TechnologyDetector {
+	detector, err := NewTechnologyDetectorFromJSON(bundledTechnologies)
+	if err != nil {
+		log.Printf("Failed to load bundled technology fingerprints: %v", err)
+		return &TechnologyDetector{byName: map[string]*compiledTechnology{}}
+	}
+	return detector
+}
+
+// NewTechnologyDetectorFromJSON creates a detector from a fingerprint database in JSON format
+func NewTechnologyDetectorFromJSON(data []byte) (*TechnologyDetector, error) {
+	var fingerprints map[string]technologyFingerprint
+	if err := json.Unmarshal(data, &fingerprints); err != nil {
+		return nil, fmt.Errorf("failed to parse fingerprint database: %w", err)
+	}
+
+	detector := &TechnologyDetector{
+		byName: make(map[string]*compiledTechnology, len(fingerprints)),
+	}
+
+	for name, fp := range fingerprints {
+		tech := &compiledTechnology{
+			name:     name,
+			category: fp.Category,
+			website:  fp.Website,
+			implies:  fp.Implies,
+		}
+
+		var err error
+		if tech.headers, err = compilePatternMap(fp.Headers, true); err != nil {
+			return nil, fmt.Errorf("%s: invalid header pattern: %w", name, err)
+		}
+		if tech.meta, err = compilePatternMap(fp.Meta, true); err != nil {
+			return nil, fmt.Errorf("%s: invalid meta pattern: %w", name, err)
+		}
+		if tech.cookies, err = compilePatternMap(fp.Cookies, false); err != nil {
+			return nil, fmt.Errorf("%s: invalid cookie pattern: %w", name, err)
+		}
+		if tech.scripts, err = compilePatternList(fp.Scripts); err != nil {
+			return nil, fmt.Errorf("%s: invalid script pattern: %w", name, err)
+		}
+		if tech.html, err = compilePatternList(fp.HTML); err != nil {
+			return nil, fmt.Errorf("%s: invalid html pattern: %w", name, err)
+		}
+
+		detector.technologies = append(detector.technologies, tech)
+		detector.byName[name] = tech
+	}
+
+	// Keep detection order stable
+	sort.Slice(detector.technologies, func(i, j int) bool {
+		return detector.technologies[i].name < detector.technologies[j].name
+	})
+
+	return detector, nil
+}
+
+// compilePattern compiles a single fingerprint pattern with its optional version tag
+func compilePattern(raw string) (*fingerprintPattern, error) {
+	parts := strings.Split(raw, "\\;")
+	pattern := &fingerprintPattern{}
+
+	for _, tag := range parts[1:] {
+		if strings.HasPrefix(tag, "version:") {
+			pattern.version = strings.TrimPrefix(tag, "version:")
+		}
+	}
+
+	regex, err := regexp.Compile("(?i)" + parts[0])
+	if err != nil {
+		return nil, err
+	}
+	pattern.regex = regex
+
+	return pattern, nil
+}
+
+// compilePatternMap compiles a map of key to pattern, optionally lower-casing keys
+func compilePatternMap(raw map[string]string, lowerKeys bool) (map[string]*fingerprintPattern, error) {
+	compiled := make(map[string]*fingerprintPattern, len(raw))
+	for key, value := range raw {
+		pattern, err := compilePattern(value)
+		if err != nil {
+			return nil, err
+		}
+		if lowerKeys {
+			key = strings.ToLower(key)
+		}
+		compiled[key] = pattern
+	}
+	return compiled, nil
+}
+
+// compilePatternList compiles a list of patterns
+func compilePatternList(raw []string) ([]*fingerprintPattern, error) {
+	compiled := make([]*fingerprintPattern, 0, len(raw))
+	for _, value := range raw {
+		pattern, err := compilePattern(value)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, pattern)
+	}
+	return compiled, nil
+}
+
+// match reports whether the value matches the pattern and returns the extracted version
+func (p *fingerprintPattern) match(value string) (bool, string) {
+	groups := p.regex.FindStringSubmatch(value)
+	if groups == nil {
+		return false, ""
+	}
+	if p.version == "" {
+		return true, ""
+	}
+
+	version := p.version
+	for i := len(groups) - 1; i >= 1; i-- {
+		version = strings.ReplaceAll(version, fmt.Sprintf("\\%d", i), groups[i])
+	}
+	return true, strings.TrimSpace(version)
+}
+
+// Detect identifies the technologies used by a page from its response headers,
+// cookies and HTML document
+func (d *TechnologyDetector) Detect(headers http.Header, cookies []*http.Cookie, doc *goquery.Document) []models.Technology {
+	detected := make(map[string]string)
+
+	record := func(name, version string) {
+		if current, exists := detected[name]; !exists || (current == "" && version != "") {
+			detected[name] = version
+		}
+	}
+
+	// Collect the page signals once
+	lowerHeaders := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		lowerHeaders[strings.ToLower(key)] = values
+	}
+
+	cookieValues := make(map[string]string, len(cookies))
+	for _, cookie := range cookies {
+		cookieValues[cookie.Name] = cookie.Value
+	}
+
+	metaValues := make(map[string][]string)
+	doc.Find("meta[name][content]").Each(func(i int, sel *goquery.Selection) {
+		name, _ := sel.Attr("name")
+		content, _ := sel.Attr("content")
+		name = strings.ToLower(name)
+		metaValues[name] = append(metaValues[name], content)
+	})
+
+	var scriptSources []string
+	doc.Find("script[src]").Each(func(i int, sel *goquery.Selection) {
+		if src, exists := sel.Attr("src"); exists && src != "" {
+			scriptSources = append(scriptSources, src)
+		}
+	})
+
+	html, _ := doc.Html()
+
+	for _, tech := range d.technologies {
+		for header, pattern := range tech.headers {
+			for _, value := range lowerHeaders[header] {
+				if ok, version := pattern.match(value); ok {
+					record(tech.name, version)
+				}
+			}
+		}
+
+		for name, pattern := range tech.meta {
+			for _, value := range metaValues[name] {
+				if ok, version := pattern.match(value); ok {
+					record(tech.name, version)
+				}
+			}
+		}
+
+		for name, pattern := range tech.cookies {
+			if value, exists := cookieValues[name]; exists {
+				if ok, version := pattern.match(value); ok {
+					record(tech.name, version)
+				}
+			}
+		}
+
+		for _, pattern := range tech.scripts {
+			for _, src := range scriptSources {
+				if ok, version := pattern.match(src); ok {
+					record(tech.name, version)
+				}
+			}
+		}
+
+		for _, pattern := range tech.html {
+			if ok, version := pattern.match(html); ok {
+				record(tech.name, version)
+			}
+		}
+	}
+
+	// Resolve implied technologies (e.g. WordPress implies PHP)
+	queue := make([]string, 0, len(detected))
+	for name := range detected {
+		queue = append(queue, name)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		tech, exists := d.byName[name]
+		if !exists {
+			continue
+		}
+		for _, implied := range tech.implies {
+			if _, seen := detected[implied]; !seen {
+				detected[implied] = ""
+				queue = append(queue, implied)
+			}
+		}
+	}
+
+	technologies := make([]models.Technology, 0, len(detected))
+	for name, version := range detected {
+		technology := models.Technology{
+			Name:    name,
+			Version: version,
+		}
+		if tech, exists := d.byName[name]; exists {
+			technology.Category = tech.category
+			technology.Website = tech.website
+		}
+		technologies = append(technologies, technology)
+	}
+
+	sort.Slice(technologies, func(i, j int) bool {
+		if technologies[i].Category != technologies[j].Category {
+			return technologies[i].Category < technologies[j].Category
+		}
+		return technologies[i].Name < technologies[j].Name
+	})
+
+	return technologies
+}
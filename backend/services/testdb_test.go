@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// newTestDB returns a GORM connection to an in-memory table store that
+// understands just enough SQL for service tests: INSERTs are stored, and a
+// SELECT returns every row of its table, or their count. Like MySQL, columns
+// an INSERT leaves out take the defaults declared on the given models.
+func newTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+
+	store := &memoryStore{
+		defaults: map[string]map[string]driver.Value{},
+		tables:   map[string][]map[string]driver.Value{},
+	}
+	for _, model := range models {
+		parsed, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatalf("failed to parse %T: %v", model, err)
+		}
+		defaults := map[string]driver.Value{}
+		for _, field := range parsed.Fields {
+			if field.DBName != "" && field.HasDefaultValue && field.DefaultValueInterface != nil {
+				defaults[field.DBName] = field.DefaultValueInterface
+			}
+		}
+		store.defaults[parsed.Table] = defaults
+	}
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(memoryConnector{store}),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	return db
+}
+
+var (
+	insertPattern = regexp.MustCompile("(?is)^INSERT INTO `(\\w+)` \\(([^)]*)\\) VALUES")
+	fromPattern   = regexp.MustCompile("(?is)\\bFROM `(\\w+)`")
+)
+
+// memoryStore holds the rows of every table, keyed by column name
+type memoryStore struct {
+	mu       sync.Mutex
+	defaults map[string]map[string]driver.Value
+	tables   map[string][]map[string]driver.Value
+	nextID   int64
+}
+
+func (s *memoryStore) insert(query string, args []driver.NamedValue) (driver.Result, error) {
+	match := insertPattern.FindStringSubmatch(query)
+	if match == nil {
+		return driver.RowsAffected(0), nil
+	}
+	table := match[1]
+	var columns []string
+	for _, column := range strings.Split(match[2], ",") {
+		columns = append(columns, strings.Trim(strings.TrimSpace(column), "`"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstID int64
+	for start := 0; start+len(columns) <= len(args); start += len(columns) {
+		row := map[string]driver.Value{}
+		for column, value := range s.defaults[table] {
+			row[column] = value
+		}
+		for i, column := range columns {
+			row[column] = args[start+i].Value
+		}
+		if row["id"] == nil {
+			s.nextID++
+			row["id"] = s.nextID
+		}
+		if firstID == 0 {
+			firstID, _ = row["id"].(int64)
+		}
+		s.tables[table] = append(s.tables[table], row)
+	}
+	return memoryResult{lastID: firstID, rows: int64(len(args) / len(columns))}, nil
+}
+
+func (s *memoryStore) query(query string) (driver.Rows, error) {
+	match := fromPattern.FindStringSubmatch(query)
+	if match == nil {
+		return nil, errors.New("unsupported query: " + query)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rows := s.tables[match[1]]
+	if strings.Contains(strings.ToLower(query), "count(*)") {
+		return &memoryRows{columns: []string{"count(*)"}, values: [][]driver.Value{{int64(len(rows))}}}, nil
+	}
+
+	seen := map[string]bool{}
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	result := &memoryRows{columns: columns}
+	for _, row := range rows {
+		values := make([]driver.Value, len(columns))
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		result.values = append(result.values, values)
+	}
+	return result, nil
+}
+
+type memoryConnector struct{ store *memoryStore }
+
+func (c memoryConnector) Connect(context.Context) (driver.Conn, error) {
+	return &memoryConn{store: c.store}, nil
+}
+
+func (c memoryConnector) Driver() driver.Driver { return memoryDriver{} }
+
+type memoryDriver struct{}
+
+func (memoryDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("open the memory driver through its connector")
+}
+
+type memoryConn struct{ store *memoryStore }
+
+func (c *memoryConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *memoryConn) Close() error { return nil }
+
+func (c *memoryConn) Begin() (driver.Tx, error) { return memoryTx{}, nil }
+
+func (c *memoryConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.store.insert(query, args)
+}
+
+func (c *memoryConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	return c.store.query(query)
+}
+
+type memoryTx struct{}
+
+func (memoryTx) Commit() error   { return nil }
+func (memoryTx) Rollback() error { return nil }
+
+type memoryResult struct{ lastID, rows int64 }
+
+func (r memoryResult) LastInsertId() (int64, error) { return r.lastID, nil }
+func (r memoryResult) RowsAffected() (int64, error) { return r.rows, nil }
+
+type memoryRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *memoryRows) Columns() []string { return r.columns }
+func (r *memoryRows) Close() error      { return nil }
+
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
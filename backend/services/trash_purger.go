@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// trashPurgeBatchSize caps how many URLs are purged per transaction
+const trashPurgeBatchSize = 500
+
+// TrashSettings configures automatic purging of the URL trash
+type TrashSettings struct {
+	Retention time.Duration // how long deleted URLs stay restorable
+	Interval  time.Duration // how often expired URLs are purged
+}
+
+// TrashPurger permanently deletes URLs, in every workspace, that have been in
+// the trash longer than the retention period
+type TrashPurger struct {
+	db       *gorm.DB
+	settings TrashSettings
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewTrashPurger creates a trash purger
+func NewTrashPurger(settings TrashSettings) *TrashPurger {
+	if settings.Interval <= 0 {
+		settings.Interval = time.Hour
+	}
+	return &TrashPurger{
+		db:       database.GetDB(),
+		settings: settings,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins purging in the background, starting with a purge right away
+func (p *TrashPurger) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.settings.Interval)
+		defer ticker.Stop()
+
+		log.Printf("Trash purger started (purging URLs deleted more than %s ago every %s)", p.settings.Retention, p.settings.Interval)
+		p.purge(time.Now())
+		for {
+			select {
+			case <-p.stop:
+				return
+			case now := <-ticker.C:
+				p.purge(now)
+			}
+		}
+	}()
+}
+
+// Stop stops purging after the batch in flight, or when ctx is done
+func (p *TrashPurger) Stop(ctx context.Context) {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("Trash purger stopped")
+	case <-ctx.Done():
+		log.Println("Trash purger stopped before the batch in flight finished")
+	}
+}
+
+// purge permanently deletes the URLs deleted before the retention period, a batch at a time
+func (p *TrashPurger) purge(now time.Time) {
+	cutoff := now.Add(-p.settings.Retention)
+	purged := 0
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		var ids []uint
+		if err := p.db.Unscoped().Model(&models.URL{}).Scopes(allWorkspaces).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Order("id").Limit(trashPurgeBatchSize).Pluck("id", &ids).Error; err != nil {
+			log.Printf("Trash purger failed to find expired URLs: %v", err)
+			return
+		}
+		if len(ids) == 0 {
+			break
+		}
+		if err := purgeURLs(p.db, ids); err != nil {
+			log.Printf("Trash purger failed to purge %d URLs: %v", len(ids), err)
+			return
+		}
+		purged += len(ids)
+		if len(ids) < trashPurgeBatchSize {
+			break
+		}
+	}
+
+	if purged > 0 {
+		log.Printf("Trash purger purged %d URLs deleted before %s", purged, cutoff.Format(time.RFC3339))
+	}
+}
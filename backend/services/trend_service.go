@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultTrendPeriod is how far back trends go when no start is given
+	defaultTrendPeriod = 30 * 24 * time.Hour
+	// maxTrendBuckets caps the number of buckets a trend query may span
+	maxTrendBuckets = 1000
+)
+
+// Trend bucket sizes
+const (
+	TrendBucketHour  = "hour"
+	TrendBucketDay   = "day"
+	TrendBucketWeek  = "week" // weeks start on Monday
+	TrendBucketMonth = "month"
+)
+
+// ErrInvalidTrendQuery is returned for unknown metrics or buckets and malformed periods
+var ErrInvalidTrendQuery = errors.New("invalid trend query")
+
+// trendMetrics maps the metrics a trend can chart to their column in the analyses table
+var trendMetrics = map[string]string{
+	"load_time":                "load_time",
+	"page_size":                "page_size",
+	"broken_links":             "broken_links",
+	"score":                    "seo_score",
+	"accessibility_violations": "accessibility_violation_count",
+	"vulnerabilities":          "vulnerability_count",
+	"rule_violations":          "rule_violation_count",
+	"findings":                 "finding_count",
+}
+
+// trendBucketExprs maps bucket sizes to the SQL expression formatting the start
+// of an analysis' bucket as trendBucketLayout. Times are stored in local time.
+var trendBucketExprs = map[string]string{
+	TrendBucketHour:  "DATE_FORMAT(analyzed_at, '%Y-%m-%d %H:00:00')",
+	TrendBucketDay:   "DATE_FORMAT(analyzed_at, '%Y-%m-%d 00:00:00')",
+	TrendBucketWeek:  "DATE_FORMAT(DATE_SUB(analyzed_at, INTERVAL WEEKDAY(analyzed_at) DAY), '%Y-%m-%d 00:00:00')",
+	TrendBucketMonth: "DATE_FORMAT(analyzed_at, '%Y-%m-01 00:00:00')",
+}
+
+// trendBucketLayout is the layout of the bucket starts returned by trendBucketExprs
+const trendBucketLayout = "2006-01-02 15:04:05"
+
+// defaultTrendMetrics are charted when a query names no metrics
+var defaultTrendMetrics = []string{"load_time", "page_size", "broken_links", "score"}
+
+// TrendOptions holds the raw trend query parameters
+type TrendOptions struct {
+	Metrics string // comma-separated metric names
+	From    string // RFC 3339 time or YYYY-MM-DD date, defaults to 30 days before To
+	To      string // RFC 3339 time or YYYY-MM-DD date (inclusive), defaults to now
+	Bucket  string // hour, day, week or month, defaults to day
+}
+
+// trendQuery holds validated trend query parameters
+type trendQuery struct {
+	metrics []string
+	from    time.Time
+	to      time.Time
+	bucket  string
+}
+
+// TrendService handles historical metric trends built from stored analyses
+type TrendService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewTrendService creates a new trend service instance
+func NewTrendService() *TrendService {
+	return &TrendService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *TrendService) ForWorkspace(workspaceID uint) *TrendService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetURLTrends returns the metric series of a single URL
+func (s *TrendService) GetURLTrends(urlID uint, options TrendOptions) (*models.TrendReport, error) {
+	query, err := parseTrendOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var url models.URL
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Select("id").First(&url, urlID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("URL not found")
+		}
+		return nil, fmt.Errorf("failed to get URL: %w", err)
+	}
+
+	report, err := s.buildTrends(s.db.Where("url_id = ?", urlID), query)
+	if err != nil {
+		return nil, err
+	}
+	report.URLID = &url.ID
+	return report, nil
+}
+
+// GetPortfolioTrends returns metric series aggregated across all URLs of the
+// workspace, or across those with a tag when one is given
+func (s *TrendService) GetPortfolioTrends(tag string, options TrendOptions) (*models.TrendReport, error) {
+	query, err := parseTrendOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := s.db.Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID)).Select("id")
+	tag = strings.TrimSpace(tag)
+	if tag != "" {
+		var found models.Tag
+		if err := s.db.Scopes(inWorkspace(s.workspaceID)).Where("name = ?", tag).First(&found).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("tag not found")
+			}
+			return nil, fmt.Errorf("failed to get tag: %w", err)
+		}
+		urls = urls.Where("id IN (?)", s.db.Table("url_tags").Select("url_id").Where("tag_id = ?", found.ID))
+	}
+
+	report, err := s.buildTrends(s.db.Where("url_id IN (?)", urls), query)
+	if err != nil {
+		return nil, err
+	}
+	report.Tag = tag
+	return report, nil
+}
+
+// buildTrends aggregates the completed analyses matched by scope into one
+// series per metric, grouping them by bucket in the database
+func (s *TrendService) buildTrends(scope *gorm.DB, query trendQuery) (*models.TrendReport, error) {
+	analyses := func() *gorm.DB {
+		return scope.Session(&gorm.Session{}).Model(&models.Analysis{}).
+			Where("status = ? AND analyzed_at >= ? AND analyzed_at < ?", "completed", query.from, query.to)
+	}
+
+	report := &models.TrendReport{
+		From:   query.from,
+		To:     query.to,
+		Bucket: query.bucket,
+		Series: make([]models.TrendSeries, 0, len(query.metrics)),
+	}
+
+	var urlCount int64
+	if err := analyses().Distinct("url_id").Count(&urlCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count analyzed URLs: %w", err)
+	}
+	report.URLCount = int(urlCount)
+
+	bucket := trendBucketExprs[query.bucket]
+	for _, metric := range query.metrics {
+		column := trendMetrics[metric]
+		var rows []struct {
+			Bucket string
+			Count  int
+			Min    float64
+			Avg    float64
+			Max    float64
+		}
+		if err := analyses().
+			Select(fmt.Sprintf("%s AS bucket, COUNT(*) AS count, MIN(%s) AS min, AVG(%s) AS avg, MAX(%s) AS max", bucket, column, column, column)).
+			Group("bucket").
+			Order("bucket ASC").
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to aggregate %s: %w", metric, err)
+		}
+
+		series := models.TrendSeries{Metric: metric, Points: make([]models.TrendPoint, 0, len(rows))}
+		for _, row := range rows {
+			start, err := time.ParseInLocation(trendBucketLayout, row.Bucket, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse trend bucket %q: %w", row.Bucket, err)
+			}
+			series.Points = append(series.Points, models.TrendPoint{
+				Start: start,
+				Count: row.Count,
+				Min:   row.Min,
+				Avg:   math.Round(row.Avg*1000) / 1000,
+				Max:   row.Max,
+			})
+		}
+
+		if n := len(series.Points); n >= 2 {
+			change := math.Round((series.Points[n-1].Avg-series.Points[n-2].Avg)*1000) / 1000
+			series.Change = &change
+		}
+		report.Series = append(report.Series, series)
+	}
+
+	return report, nil
+}
+
+// parseTrendOptions validates raw trend query parameters, applying defaults
+func parseTrendOptions(options TrendOptions) (trendQuery, error) {
+	query := trendQuery{bucket: strings.ToLower(strings.TrimSpace(options.Bucket))}
+
+	if query.bucket == "" {
+		query.bucket = TrendBucketDay
+	}
+	switch query.bucket {
+	case TrendBucketHour, TrendBucketDay, TrendBucketWeek, TrendBucketMonth:
+	default:
+		return query, fmt.Errorf("%w: unknown bucket %q (use hour, day, week or month)", ErrInvalidTrendQuery, options.Bucket)
+	}
+
+	seen := make(map[string]bool)
+	for _, metric := range strings.Split(options.Metrics, ",") {
+		metric = strings.ToLower(strings.TrimSpace(metric))
+		if metric == "" || seen[metric] {
+			continue
+		}
+		if _, ok := trendMetrics[metric]; !ok {
+			return query, fmt.Errorf("%w: unknown metric %q (available: %s)", ErrInvalidTrendQuery, metric, strings.Join(TrendMetricNames(), ", "))
+		}
+		seen[metric] = true
+		query.metrics = append(query.metrics, metric)
+	}
+	if len(query.metrics) == 0 {
+		query.metrics = defaultTrendMetrics
+	}
+
+	query.to = time.Now()
+	if options.To != "" {
+		to, dateOnly, err := parseTrendTime(options.To)
+		if err != nil {
+			return query, fmt.Errorf("%w: invalid to %q", ErrInvalidTrendQuery, options.To)
+		}
+		if dateOnly {
+			// A date includes the whole day
+			to = to.AddDate(0, 0, 1)
+		}
+		query.to = to
+	}
+
+	query.from = query.to.Add(-defaultTrendPeriod)
+	if options.From != "" {
+		from, _, err := parseTrendTime(options.From)
+		if err != nil {
+			return query, fmt.Errorf("%w: invalid from %q", ErrInvalidTrendQuery, options.From)
+		}
+		query.from = from
+	}
+
+	if !query.from.Before(query.to) {
+		return query, fmt.Errorf("%w: from must be before to", ErrInvalidTrendQuery)
+	}
+
+	buckets := 0
+	for start := trendBucketStart(query.from, query.bucket); start.Before(query.to); start = nextTrendBucket(start, query.bucket) {
+		if buckets++; buckets > maxTrendBuckets {
+			return query, fmt.Errorf("%w: the period spans more than %d %s buckets, use a larger bucket", ErrInvalidTrendQuery, maxTrendBuckets, query.bucket)
+		}
+	}
+
+	return query, nil
+}
+
+// parseTrendTime parses an RFC 3339 time or a YYYY-MM-DD date in local time,
+// reporting whether the value was a date
+func parseTrendTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	return t, true, err
+}
+
+// trendBucketStart returns the start of the bucket containing t, in local time
+func trendBucketStart(t time.Time, bucket string) time.Time {
+	t = t.In(time.Local)
+	switch bucket {
+	case TrendBucketHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.Local)
+	case TrendBucketWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case TrendBucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+}
+
+// nextTrendBucket returns the start of the bucket after the one starting at start
+func nextTrendBucket(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case TrendBucketHour:
+		return start.Add(time.Hour)
+	case TrendBucketWeek:
+		return start.AddDate(0, 0, 7)
+	case TrendBucketMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// TrendMetricNames returns the metrics a trend can chart
+func TrendMetricNames() []string {
+	names := make([]string, 0, len(trendMetrics))
+	for name := range trendMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// UptimeMonitor probes every tracked URL on a fixed interval and records the
+// status code, latency and error of each probe. Probes are plain GET requests,
+// independent of (and much cheaper than) a full analysis.
+type UptimeMonitor struct {
+	db       *gorm.DB
+	client   *http.Client
+	settings UptimeSettings
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewUptimeMonitor creates an uptime monitor using the configured uptime settings
+func NewUptimeMonitor() *UptimeMonitor {
+	return &UptimeMonitor{
+		db: database.GetDB(),
+		client: &http.Client{
+			Timeout: uptimeSettings.Timeout,
+		},
+		settings: uptimeSettings,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins probing in the background
+func (m *UptimeMonitor) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.settings.Interval)
+		defer ticker.Stop()
+
+		log.Printf("Uptime monitor started (probing every %s)", m.settings.Interval)
+		for {
+			select {
+			case <-m.stop:
+				return
+			case now := <-ticker.C:
+				m.probeAll(now)
+				m.prune(now)
+			}
+		}
+	}()
+}
+
+// Stop stops probing after the probes in flight, or when ctx is done
+func (m *UptimeMonitor) Stop(ctx context.Context) {
+	close(m.stop)
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("Uptime monitor stopped")
+	case <-ctx.Done():
+		log.Println("Uptime monitor stopped before in-flight probes finished")
+	}
+}
+
+// probeAll probes every URL, a few at a time, and stores the results
+func (m *UptimeMonitor) probeAll(now time.Time) {
+	var urls []models.URL
+	if err := m.db.Scopes(allWorkspaces).Select("id", "url").Find(&urls).Error; err != nil {
+		log.Printf("Uptime monitor failed to load URLs: %v", err)
+		return
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	checks := make([]models.UptimeCheck, len(urls))
+	semaphore := make(chan struct{}, m.settings.Concurrency)
+	var wg sync.WaitGroup
+
+probing:
+	for i := range urls {
+		select {
+		case <-m.stop:
+			checks = checks[:i]
+			break probing
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			checks[i] = m.probe(urls[i])
+		}(i)
+	}
+	wg.Wait()
+
+	if len(checks) == 0 {
+		return
+	}
+	if err := m.db.CreateInBatches(checks, 500).Error; err != nil {
+		log.Printf("Uptime monitor failed to record %d probes: %v", len(checks), err)
+		return
+	}
+
+	down := 0
+	for _, check := range checks {
+		if !check.Up {
+			down++
+		}
+	}
+	log.Printf("Uptime monitor probed %d URLs in %s (%d down)", len(checks), time.Since(now).Round(time.Millisecond), down)
+}
+
+// probe makes a single GET request to a URL. A probe is up when the server
+// responds with a status below 400; latency is measured to the response headers.
+func (m *UptimeMonitor) probe(url models.URL) models.UptimeCheck {
+	check := models.UptimeCheck{
+		URLID:     url.ID,
+		CheckedAt: time.Now(),
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url.URL, nil)
+	if err != nil {
+		check.Error = fmt.Sprintf("Invalid URL: %v", err)
+		return check
+	}
+	req.Header.Set("User-Agent", "Website-Analyzer-Uptime/1.0")
+
+	start := time.Now()
+	resp, err := m.client.Do(req)
+	check.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	resp.Body.Close()
+
+	check.StatusCode = resp.StatusCode
+	check.Up = resp.StatusCode < 400
+	if !check.Up {
+		check.Error = fmt.Sprintf("HTTP error: %d", resp.StatusCode)
+	}
+	return check
+}
+
+// prune deletes probe results older than the retention period
+func (m *UptimeMonitor) prune(now time.Time) {
+	result := m.db.Where("checked_at < ?", now.Add(-m.settings.Retention)).Delete(&models.UptimeCheck{})
+	if result.Error != nil {
+		log.Printf("Uptime monitor failed to prune old probes: %v", result.Error)
+	}
+}
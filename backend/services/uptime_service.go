@@ -0,0 +1,207 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// defaultUptimeRange is the report range used when none is given
+const defaultUptimeRange = "24h"
+
+// ErrInvalidUptimeRange is returned for malformed or out-of-retention report ranges
+var ErrInvalidUptimeRange = errors.New("invalid uptime range")
+
+// UptimeSettings configures the uptime monitor and its reports
+type UptimeSettings struct {
+	Interval          time.Duration // how often every URL is probed
+	Timeout           time.Duration // per-probe timeout
+	Concurrency       int           // probes running at once
+	IncidentThreshold int           // consecutive failed probes that make an incident
+	Retention         time.Duration // how long probe results are kept
+}
+
+// uptimeSettings holds the active uptime settings (see ConfigureUptime)
+var uptimeSettings = UptimeSettings{
+	Interval:          5 * time.Minute,
+	Timeout:           10 * time.Second,
+	Concurrency:       10,
+	IncidentThreshold: 2,
+	Retention:         90 * 24 * time.Hour,
+}
+
+// ConfigureUptime sets the uptime monitor settings; zero values keep the defaults
+func ConfigureUptime(settings UptimeSettings) {
+	if settings.Interval > 0 {
+		uptimeSettings.Interval = settings.Interval
+	}
+	if settings.Timeout > 0 {
+		uptimeSettings.Timeout = settings.Timeout
+	}
+	if settings.Concurrency > 0 {
+		uptimeSettings.Concurrency = settings.Concurrency
+	}
+	if settings.IncidentThreshold > 0 {
+		uptimeSettings.IncidentThreshold = settings.IncidentThreshold
+	}
+	if settings.Retention > 0 {
+		uptimeSettings.Retention = settings.Retention
+	}
+}
+
+// UptimeService handles uptime reporting
+type UptimeService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewUptimeService creates a new uptime service instance
+func NewUptimeService() *UptimeService {
+	return &UptimeService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *UptimeService) ForWorkspace(workspaceID uint) *UptimeService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetUptime reports the availability of a URL over a range such as "24h", "7d"
+// or "30d", optionally including every probe in the range
+func (s *UptimeService) GetUptime(urlID uint, rangeParam string, includeChecks bool) (*models.UptimeReport, error) {
+	if rangeParam == "" {
+		rangeParam = defaultUptimeRange
+	}
+	period, err := parseUptimeRange(rangeParam)
+	if err != nil {
+		return nil, err
+	}
+
+	var url models.URL
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Select("id", "url").First(&url, urlID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("URL not found")
+		}
+		return nil, fmt.Errorf("failed to get URL: %w", err)
+	}
+
+	until := time.Now()
+	since := until.Add(-period)
+
+	var checks []models.UptimeCheck
+	if err := s.db.Where("url_id = ? AND checked_at >= ?", urlID, since).
+		Order("checked_at ASC").Find(&checks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get uptime checks: %w", err)
+	}
+
+	report := &models.UptimeReport{
+		URLID:       url.ID,
+		URL:         url.URL,
+		Range:       rangeParam,
+		Since:       since,
+		Until:       until,
+		TotalChecks: len(checks),
+		Incidents:   uptimeIncidents(checks, uptimeSettings.IncidentThreshold, until),
+	}
+
+	var totalLatency int64
+	for _, check := range checks {
+		if check.Up {
+			report.UpChecks++
+		} else {
+			report.DownChecks++
+		}
+		totalLatency += check.Latency
+		if check.Latency > report.MaxLatency {
+			report.MaxLatency = check.Latency
+		}
+	}
+
+	if len(checks) > 0 {
+		uptime := math.Round(float64(report.UpChecks)/float64(len(checks))*100000) / 1000
+		report.UptimePercent = &uptime
+		report.AvgLatency = totalLatency / int64(len(checks))
+
+		last := checks[len(checks)-1]
+		report.LastCheck = &last
+		report.CurrentlyUp = &last.Up
+	}
+
+	if includeChecks {
+		report.Checks = checks
+	}
+
+	return report, nil
+}
+
+// uptimeIncidents groups consecutive failed probes (sorted oldest first) into
+// incidents; runs shorter than threshold are treated as blips and ignored
+func uptimeIncidents(checks []models.UptimeCheck, threshold int, now time.Time) []models.UptimeIncident {
+	incidents := []models.UptimeIncident{}
+
+	var current *models.UptimeIncident
+	for _, check := range checks {
+		if !check.Up {
+			if current == nil {
+				current = &models.UptimeIncident{StartedAt: check.CheckedAt}
+			}
+			current.Failures++
+			current.StatusCode = check.StatusCode
+			current.Error = check.Error
+			continue
+		}
+
+		if current != nil && current.Failures >= threshold {
+			endedAt := check.CheckedAt
+			current.EndedAt = &endedAt
+			current.Duration = math.Round(endedAt.Sub(current.StartedAt).Seconds())
+			incidents = append(incidents, *current)
+		}
+		current = nil
+	}
+
+	if current != nil && current.Failures >= threshold {
+		current.Ongoing = true
+		current.Duration = math.Round(now.Sub(current.StartedAt).Seconds())
+		incidents = append(incidents, *current)
+	}
+
+	return incidents
+}
+
+// parseUptimeRange parses a report range: a Go duration ("12h") or a number of days ("7d")
+func parseUptimeRange(value string) (time.Duration, error) {
+	var period time.Duration
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidUptimeRange, value)
+		}
+		period = time.Duration(count) * 24 * time.Hour
+	} else {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q (use e.g. 24h, 7d or 30d)", ErrInvalidUptimeRange, value)
+		}
+		period = duration
+	}
+
+	if period <= 0 {
+		return 0, fmt.Errorf("%w: %q must be positive", ErrInvalidUptimeRange, value)
+	}
+	if period > uptimeSettings.Retention {
+		return 0, fmt.Errorf("%w: %q exceeds the %s retention period", ErrInvalidUptimeRange, value, uptimeSettings.Retention)
+	}
+	return period, nil
+}
@@ -0,0 +1,534 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidFilter is returned when a URL filter expression or sort cannot be parsed
+var ErrInvalidFilter = errors.New("invalid filter")
+
+const (
+	// maxFilterLength caps the length of a filter expression
+	maxFilterLength = 2000
+	// maxFilterTerms caps the comparisons in a filter expression
+	maxFilterTerms = 50
+	// maxFilterDepth caps how deeply parentheses and NOT may nest
+	maxFilterDepth = 10
+	// maxSortFields caps the fields in a sort
+	maxSortFields = 5
+)
+
+// filterKind is the type of a filterable URL field, which decides the
+// operators and values it accepts
+type filterKind int
+
+const (
+	filterText filterKind = iota
+	filterInt
+	filterFloat
+	filterBool
+	filterTime
+	filterTag        // matches URLs with a tag of that name
+	filterTechnology // matches URLs with a detected technology of that name
+)
+
+// filterField maps a field of the filter language to a column of the urls table
+type filterField struct {
+	column string
+	kind   filterKind
+}
+
+// urlFilterFields lists the fields that filter expressions and sorts may use.
+// Only these column names ever reach the generated SQL.
+var urlFilterFields = map[string]filterField{
+	"url":                      {"url", filterText},
+	"title":                    {"title", filterText},
+	"description":              {"description", filterText},
+	"status":                   {"status", filterText},
+	"meta_title":               {"meta_title", filterText},
+	"html_version":             {"html_version", filterText},
+	"vulnerability_severity":   {"vulnerability_severity", filterText},
+	"status_code":              {"status_code", filterInt},
+	"score":                    {"seo_score", filterInt},
+	"h1_count":                 {"h1_count", filterInt},
+	"h2_count":                 {"h2_count", filterInt},
+	"h3_count":                 {"h3_count", filterInt},
+	"h4_count":                 {"h4_count", filterInt},
+	"h5_count":                 {"h5_count", filterInt},
+	"h6_count":                 {"h6_count", filterInt},
+	"image_count":              {"image_count", filterInt},
+	"link_count":               {"link_count", filterInt},
+	"internal_links":           {"internal_links", filterInt},
+	"external_links":           {"external_links", filterInt},
+	"broken_links":             {"broken_links", filterInt},
+	"form_count":               {"form_count", filterInt},
+	"high_severity_forms":      {"high_severity_forms", filterInt},
+	"accessibility_violations": {"accessibility_violation_count", filterInt},
+	"vulnerable_libraries":     {"vulnerable_libraries", filterInt},
+	"vulnerabilities":          {"vulnerability_count", filterInt},
+	"rule_violations":          {"rule_violation_count", filterInt},
+	"page_size":                {"page_size", filterInt},
+	"total_requests":           {"total_requests", filterInt},
+	"third_party_requests":     {"third_party_requests", filterInt},
+	"load_time":                {"load_time", filterFloat},
+	"noindex":                  {"noindex", filterBool},
+	"has_login_form":           {"has_login_form", filterBool},
+	"mobile_friendly":          {"mobile_friendly", filterBool},
+	"over_budget":              {"over_budget", filterBool},
+	"analyzed_at":              {"analyzed_at", filterTime},
+	"created_at":               {"created_at", filterTime},
+	"updated_at":               {"updated_at", filterTime},
+	"tag":                      {"", filterTag},
+	"technology":               {"", filterTechnology},
+}
+
+// filterTokenKind classifies the tokens of a filter expression
+type filterTokenKind int
+
+const (
+	tokenEOF filterTokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenOpenParen
+	tokenCloseParen
+)
+
+// filterToken is one token of a filter expression; pos is its byte offset
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+// compiledFilter is a filter expression translated to a parameterized SQL condition
+type compiledFilter struct {
+	sql  string
+	args []interface{}
+}
+
+// compileURLFilter translates a filter expression such as
+// `status:completed AND (load_time>2 OR broken_links>0) AND analyzed_at<7d`
+// into a SQL condition on the urls table. Field names are looked up in
+// urlFilterFields and every value is passed as a parameter. Relative times
+// compare ages: analyzed_at<7d matches URLs analyzed less than 7 days before now.
+func compileURLFilter(expression string, now time.Time) (*compiledFilter, error) {
+	if len(expression) > maxFilterLength {
+		return nil, fmt.Errorf("%w: expression is longer than %d characters", ErrInvalidFilter, maxFilterLength)
+	}
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &filterParser{tokens: tokens, now: now}
+	filter, err := parser.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != tokenEOF {
+		return nil, parser.errorAt(token, "unexpected %q", token.text)
+	}
+	return filter, nil
+}
+
+// tokenizeFilter splits a filter expression into tokens
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{tokenOpenParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{tokenCloseParen, ")", i})
+			i++
+		case c == '"':
+			start := i
+			var value strings.Builder
+			for i++; i < len(expression) && expression[i] != '"'; i++ {
+				if expression[i] == '\\' && i+1 < len(expression) {
+					i++
+				}
+				value.WriteByte(expression[i])
+			}
+			if i >= len(expression) {
+				return nil, fmt.Errorf("%w: unterminated string at position %d", ErrInvalidFilter, start+1)
+			}
+			tokens = append(tokens, filterToken{tokenString, value.String(), start})
+			i++
+		case strings.ContainsRune(":=!<>", rune(c)):
+			operator := string(c)
+			if i+1 < len(expression) && expression[i+1] == '=' && c != ':' && c != '=' {
+				operator += "="
+			}
+			if operator == "!" {
+				return nil, fmt.Errorf("%w: unexpected \"!\" at position %d", ErrInvalidFilter, i+1)
+			}
+			tokens = append(tokens, filterToken{tokenOperator, operator, i})
+			i += len(operator)
+		default:
+			start := i
+			for i < len(expression) && !isFilterDelimiter(expression[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{tokenWord, expression[start:i], start})
+		}
+	}
+	return append(tokens, filterToken{tokenEOF, "", len(expression)}), nil
+}
+
+// isFilterDelimiter reports whether a byte ends a bare word
+func isFilterDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || strings.IndexByte("()\":=!<>", c) >= 0
+}
+
+// filterParser is a recursive descent parser over filter tokens:
+//
+//	or   = and { "OR" and }
+//	and  = not { ["AND"] not }
+//	not  = "NOT" not | "(" or ")" | term
+//	term = field operator value
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	terms  int
+	now    time.Time
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+// isKeyword reports whether a token is the given keyword, in any case
+func isKeyword(token filterToken, keyword string) bool {
+	return token.kind == tokenWord && strings.EqualFold(token.text, keyword)
+}
+
+func (p *filterParser) errorAt(token filterToken, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidFilter, fmt.Sprintf(format, args...), token.pos+1)
+}
+
+func (p *filterParser) parseOr(depth int) (*compiledFilter, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &compiledFilter{
+			sql:  "(" + left.sql + " OR " + right.sql + ")",
+			args: append(left.args, right.args...),
+		}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(depth int) (*compiledFilter, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := p.peek()
+		if token.kind == tokenEOF || token.kind == tokenCloseParen || isKeyword(token, "OR") {
+			return left, nil
+		}
+		if isKeyword(token, "AND") {
+			p.next()
+		}
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &compiledFilter{
+			sql:  left.sql + " AND " + right.sql,
+			args: append(left.args, right.args...),
+		}
+	}
+}
+
+func (p *filterParser) parseNot(depth int) (*compiledFilter, error) {
+	if depth > maxFilterDepth {
+		return nil, p.errorAt(p.peek(), "expression is nested more than %d levels deep", maxFilterDepth)
+	}
+
+	token := p.peek()
+	switch {
+	case isKeyword(token, "NOT"):
+		p.next()
+		inner, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &compiledFilter{sql: "NOT (" + inner.sql + ")", args: inner.args}, nil
+	case token.kind == tokenOpenParen:
+		p.next()
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenCloseParen {
+			return nil, p.errorAt(closing, "expected \")\"")
+		}
+		// OR groups are already parenthesized and AND binds tighter than OR
+		return inner, nil
+	default:
+		return p.parseTerm()
+	}
+}
+
+func (p *filterParser) parseTerm() (*compiledFilter, error) {
+	fieldToken := p.next()
+	if fieldToken.kind != tokenWord {
+		if fieldToken.kind == tokenEOF {
+			return nil, p.errorAt(fieldToken, "expected a field")
+		}
+		return nil, p.errorAt(fieldToken, "expected a field, found %q", fieldToken.text)
+	}
+	field, known := urlFilterFields[strings.ToLower(fieldToken.text)]
+	if !known {
+		return nil, p.errorAt(fieldToken, "unknown field %q", fieldToken.text)
+	}
+
+	operatorToken := p.next()
+	if operatorToken.kind != tokenOperator {
+		return nil, p.errorAt(operatorToken, "expected an operator after %q", fieldToken.text)
+	}
+	valueToken := p.next()
+	if valueToken.kind != tokenWord && valueToken.kind != tokenString {
+		return nil, p.errorAt(valueToken, "expected a value after %q", fieldToken.text+operatorToken.text)
+	}
+
+	p.terms++
+	if p.terms > maxFilterTerms {
+		return nil, p.errorAt(fieldToken, "expression has more than %d comparisons", maxFilterTerms)
+	}
+
+	filter, err := compileFilterTerm(field, operatorToken.text, valueToken.text, p.now)
+	if err != nil {
+		return nil, p.errorAt(valueToken, "%s %s", fieldToken.text, err.Error())
+	}
+	return filter, nil
+}
+
+// sqlOperators maps filter operators to SQL comparison operators
+var sqlOperators = map[string]string{
+	":":  "=",
+	"=":  "=",
+	"!=": "<>",
+	">":  ">",
+	">=": ">=",
+	"<":  "<",
+	"<=": "<=",
+}
+
+// compileFilterTerm translates one comparison to SQL
+func compileFilterTerm(field filterField, operator, value string, now time.Time) (*compiledFilter, error) {
+	equality := operator == ":" || operator == "="
+	if !equality && operator != "!=" && (field.kind == filterText || field.kind == filterBool || field.kind == filterTag || field.kind == filterTechnology) {
+		return nil, fmt.Errorf("does not support %q", operator)
+	}
+
+	switch field.kind {
+	case filterText:
+		if strings.Contains(value, "*") {
+			pattern := strings.ReplaceAll(escapeLike(value), "*", "%")
+			if equality {
+				return &compiledFilter{field.column + " LIKE ?", []interface{}{pattern}}, nil
+			}
+			return &compiledFilter{field.column + " NOT LIKE ?", []interface{}{pattern}}, nil
+		}
+		return &compiledFilter{field.column + " " + sqlOperators[operator] + " ?", []interface{}{value}}, nil
+
+	case filterInt:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expects a whole number, not %q", value)
+		}
+		return &compiledFilter{field.column + " " + sqlOperators[operator] + " ?", []interface{}{number}}, nil
+
+	case filterFloat:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expects a number, not %q", value)
+		}
+		return &compiledFilter{field.column + " " + sqlOperators[operator] + " ?", []interface{}{number}}, nil
+
+	case filterBool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expects true or false, not %q", value)
+		}
+		return &compiledFilter{field.column + " " + sqlOperators[operator] + " ?", []interface{}{flag}}, nil
+
+	case filterTime:
+		return compileTimeTerm(field.column, operator, value, now)
+
+	case filterTag:
+		condition := "id IN (SELECT url_tags.url_id FROM url_tags JOIN tags ON tags.id = url_tags.tag_id WHERE tags.name = ?)"
+		if !equality {
+			condition = "id NOT IN (SELECT url_tags.url_id FROM url_tags JOIN tags ON tags.id = url_tags.tag_id WHERE tags.name = ?)"
+		}
+		return &compiledFilter{condition, []interface{}{value}}, nil
+
+	case filterTechnology:
+		name, _ := json.Marshal(value)
+		pattern := "%\"name\":" + escapeLike(string(name)) + "%"
+		if equality {
+			return &compiledFilter{"technologies LIKE ?", []interface{}{pattern}}, nil
+		}
+		return &compiledFilter{"(technologies IS NULL OR technologies NOT LIKE ?)", []interface{}{pattern}}, nil
+	}
+	return nil, fmt.Errorf("cannot be filtered")
+}
+
+// compileTimeTerm translates a comparison on a time column. Values are a date
+// (2026-01-31), an RFC 3339 time, or an age such as 30m, 12h, 7d or 2w.
+func compileTimeTerm(column, operator, value string, now time.Time) (*compiledFilter, error) {
+	if age, ok := parseFilterAge(value); ok {
+		// Ages compare in the opposite direction to times: younger is later
+		cutoff := now.Add(-age)
+		ageOperators := map[string]string{"<": ">", "<=": ">=", ">": "<", ">=": "<="}
+		sqlOperator, supported := ageOperators[operator]
+		if !supported {
+			return nil, fmt.Errorf("only supports <, <=, > and >= with an age such as %q", value)
+		}
+		return &compiledFilter{column + " " + sqlOperator + " ?", []interface{}{cutoff}}, nil
+	}
+
+	at, dateOnly, err := parseTrendTime(value)
+	if err != nil {
+		return nil, fmt.Errorf("expects a date, an RFC 3339 time or an age such as 7d, not %q", value)
+	}
+	if !dateOnly {
+		return &compiledFilter{column + " " + sqlOperators[operator] + " ?", []interface{}{at}}, nil
+	}
+
+	// A date covers the whole day
+	nextDay := at.AddDate(0, 0, 1)
+	switch operator {
+	case ":", "=":
+		return &compiledFilter{"(" + column + " >= ? AND " + column + " < ?)", []interface{}{at, nextDay}}, nil
+	case "!=":
+		return &compiledFilter{"(" + column + " < ? OR " + column + " >= ?)", []interface{}{at, nextDay}}, nil
+	case "<", ">=":
+		return &compiledFilter{column + " " + sqlOperators[operator] + " ?", []interface{}{at}}, nil
+	default: // "<=", ">"
+		sqlOperator := map[string]string{"<=": "<", ">": ">="}[operator]
+		return &compiledFilter{column + " " + sqlOperator + " ?", []interface{}{nextDay}}, nil
+	}
+}
+
+// parseFilterAge parses an age: a number of weeks ("2w") or days ("7d"), or a
+// Go duration ("12h", "30m")
+func parseFilterAge(value string) (time.Duration, bool) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if count, found := strings.CutSuffix(value, suffix); found {
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 0 {
+				return 0, false
+			}
+			return time.Duration(n) * unit, true
+		}
+	}
+	if value == "" || !unicode.IsDigit(rune(value[0])) {
+		return 0, false
+	}
+	age, err := time.ParseDuration(value)
+	return age, err == nil && age >= 0
+}
+
+// escapeLike escapes the LIKE wildcards in a value matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// neverAnalyzed stands in for the analysis time of URLs that were never
+// analyzed when sorting, so that every URL has a value cursors can compare. It
+// matches the TIMESTAMP('1000-01-01') that urlSortKey.expression substitutes.
+var neverAnalyzed = time.Date(1000, 1, 1, 0, 0, 0, 0, time.Local)
+
+// urlSortKey is one field of a URL list sort
+type urlSortKey struct {
+	column string
+	kind   filterKind
+	desc   bool
+}
+
+// expression returns the SQL expression the key sorts on
+func (k urlSortKey) expression() string {
+	if k.column == "analyzed_at" {
+		return "COALESCE(analyzed_at, TIMESTAMP('1000-01-01'))"
+	}
+	return k.column
+}
+
+// idSortKey breaks ties between URLs that sort equally, newest first
+var idSortKey = urlSortKey{column: "id", kind: filterInt, desc: true}
+
+// parseURLSort parses a sort such as "-load_time,title", where a leading "-"
+// sorts descending. The keys end with idSortKey, so the order is total.
+func parseURLSort(sort string) ([]urlSortKey, error) {
+	var keys []urlSortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name := strings.ToLower(strings.TrimLeft(part, "+-"))
+
+		field, known := urlFilterFields[name]
+		if !known || field.column == "" {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidFilter, name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		keys = append(keys, urlSortKey{column: field.column, kind: field.kind, desc: strings.HasPrefix(part, "-")})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: sort has no fields", ErrInvalidFilter)
+	}
+	if len(keys) > maxSortFields {
+		return nil, fmt.Errorf("%w: sort has more than %d fields", ErrInvalidFilter, maxSortFields)
+	}
+	return append(keys, idSortKey), nil
+}
+
+// urlOrderClause returns the ORDER BY clause for sort keys
+func urlOrderClause(keys []urlSortKey) string {
+	clauses := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		clauses = append(clauses, key.expression()+" "+direction)
+	}
+	return strings.Join(clauses, ", ")
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompileURLFilter(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+	nextDay := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		expression string
+		wantSQL    string
+		wantArgs   []interface{}
+	}{
+		{
+			name:       "implicit AND binds tighter than OR",
+			expression: "status:completed OR score>80 broken_links>0",
+			wantSQL:    "(status = ? OR seo_score > ? AND broken_links > ?)",
+			wantArgs:   []interface{}{"completed", int64(80), int64(0)},
+		},
+		{
+			name:       "parentheses group an OR",
+			expression: "(status:completed OR score>80) AND broken_links>0",
+			wantSQL:    "(status = ? OR seo_score > ?) AND broken_links > ?",
+			wantArgs:   []interface{}{"completed", int64(80), int64(0)},
+		},
+		{
+			name:       "keywords in any case",
+			expression: "status:failed or not noindex:true",
+			wantSQL:    "(status = ? OR NOT (noindex = ?))",
+			wantArgs:   []interface{}{"failed", true},
+		},
+		{
+			name:       "NOT of a group",
+			expression: "NOT (load_time>=2.5 OR status_code!=200)",
+			wantSQL:    "NOT ((load_time >= ? OR status_code <> ?))",
+			wantArgs:   []interface{}{2.5, int64(200)},
+		},
+		{
+			name:       "quoted values",
+			expression: `title:"Hello \"World\""`,
+			wantSQL:    "title = ?",
+			wantArgs:   []interface{}{`Hello "World"`},
+		},
+		{
+			name:       "wildcards escape LIKE metacharacters",
+			expression: `url:"*100%_off*"`,
+			wantSQL:    "url LIKE ?",
+			wantArgs:   []interface{}{`%100\%\_off%`},
+		},
+		{
+			name:       "negated wildcard",
+			expression: "url!=*.example.com*",
+			wantSQL:    "url NOT LIKE ?",
+			wantArgs:   []interface{}{"%.example.com%"},
+		},
+		{
+			name:       "technology names are escaped",
+			expression: `technology:"50%_off"`,
+			wantSQL:    "technologies LIKE ?",
+			wantArgs:   []interface{}{`%"name":"50\%\_off"%`},
+		},
+		{
+			name:       "tag",
+			expression: "tag!=blog",
+			wantSQL:    "id NOT IN (SELECT url_tags.url_id FROM url_tags JOIN tags ON tags.id = url_tags.tag_id WHERE tags.name = ?)",
+			wantArgs:   []interface{}{"blog"},
+		},
+		{
+			name:       "date covers the whole day",
+			expression: "analyzed_at:2026-01-31",
+			wantSQL:    "(analyzed_at >= ? AND analyzed_at < ?)",
+			wantArgs:   []interface{}{day, nextDay},
+		},
+		{
+			name:       "date excludes the whole day",
+			expression: "analyzed_at!=2026-01-31",
+			wantSQL:    "(analyzed_at < ? OR analyzed_at >= ?)",
+			wantArgs:   []interface{}{day, nextDay},
+		},
+		{
+			name:       "on or before a date",
+			expression: "created_at<=2026-01-31",
+			wantSQL:    "created_at < ?",
+			wantArgs:   []interface{}{nextDay},
+		},
+		{
+			name:       "after a date",
+			expression: "created_at>2026-01-31",
+			wantSQL:    "created_at >= ?",
+			wantArgs:   []interface{}{nextDay},
+		},
+		{
+			name:       "before a date",
+			expression: "created_at<2026-01-31",
+			wantSQL:    "created_at < ?",
+			wantArgs:   []interface{}{day},
+		},
+		{
+			name:       "quoted RFC 3339 time",
+			expression: `updated_at>="2026-01-31T08:30:00Z"`,
+			wantSQL:    "updated_at >= ?",
+			wantArgs:   []interface{}{time.Date(2026, 1, 31, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:       "younger than an age",
+			expression: "analyzed_at<7d",
+			wantSQL:    "analyzed_at > ?",
+			wantArgs:   []interface{}{now.AddDate(0, 0, -7)},
+		},
+		{
+			name:       "at least an age",
+			expression: "analyzed_at>=2w",
+			wantSQL:    "analyzed_at <= ?",
+			wantArgs:   []interface{}{now.AddDate(0, 0, -14)},
+		},
+		{
+			name:       "age as a duration",
+			expression: "updated_at<=12h",
+			wantSQL:    "updated_at >= ?",
+			wantArgs:   []interface{}{now.Add(-12 * time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := compileURLFilter(tt.expression, now)
+			if err != nil {
+				t.Fatalf("compileURLFilter(%q): %v", tt.expression, err)
+			}
+			if filter.sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", filter.sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(filter.args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", filter.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCompileURLFilterRejects(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat("(", depth) + "status:completed" + strings.Repeat(")", depth)
+	}
+	terms := func(n int) string {
+		return strings.TrimSpace(strings.Repeat("score>1 ", n))
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		wantError  string
+	}{
+		{"unknown field", "colour:red", `unknown field "colour"`},
+		{"ordering a text field", "title>abc", `title does not support ">"`},
+		{"ordering a boolean", "noindex<true", `noindex does not support "<"`},
+		{"bare bang", "status!completed", `unexpected "!"`},
+		{"number expected", "score:high", `expects a whole number`},
+		{"boolean expected", "over_budget:maybe", `expects true or false`},
+		{"age with equality", "analyzed_at:7d", `only supports <, <=, > and >=`},
+		{"malformed time", "analyzed_at>yesterday", `expects a date, an RFC 3339 time or an age`},
+		{"missing value", "status:", `expected a value after "status:"`},
+		{"missing operator", "status", `expected an operator after "status"`},
+		{"unbalanced parenthesis", "(status:completed", `expected ")"`},
+		{"stray parenthesis", "status:completed)", `unexpected ")"`},
+		{"unterminated string", `title:"abc`, `unterminated string`},
+		{"dangling OR", "status:completed OR", `expected a field`},
+		{"too deeply nested", nested(maxFilterDepth + 1), "nested more than"},
+		{"too many NOTs", strings.Repeat("NOT ", maxFilterDepth+1) + "noindex:true", "nested more than"},
+		{"too many comparisons", terms(maxFilterTerms + 1), "more than 50 comparisons"},
+		{"too long", "title:" + strings.Repeat("a", maxFilterLength), "longer than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileURLFilter(tt.expression, time.Now())
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("compileURLFilter(%q) = %v, want ErrInvalidFilter", tt.expression, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("error %q does not mention %q", err, tt.wantError)
+			}
+		})
+	}
+
+	// The limits themselves are allowed
+	for _, expression := range []string{nested(maxFilterDepth), terms(maxFilterTerms)} {
+		if _, err := compileURLFilter(expression, time.Now()); err != nil {
+			t.Errorf("compileURLFilter at the limit: %v", err)
+		}
+	}
+}
+
+func TestParseURLSort(t *testing.T) {
+	tests := []struct {
+		sort      string
+		wantOrder string
+	}{
+		{"title", "title ASC, id DESC"},
+		{"-load_time, +title", "load_time DESC, title ASC, id DESC"},
+		{"score,-SCORE", "seo_score ASC, id DESC"},
+		{"-analyzed_at", "COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) DESC, id DESC"},
+	}
+	for _, tt := range tests {
+		keys, err := parseURLSort(tt.sort)
+		if err != nil {
+			t.Errorf("parseURLSort(%q): %v", tt.sort, err)
+			continue
+		}
+		if order := urlOrderClause(keys); order != tt.wantOrder {
+			t.Errorf("parseURLSort(%q) orders by %q, want %q", tt.sort, order, tt.wantOrder)
+		}
+	}
+
+	for _, sort := range []string{"", " , ", "colour", "tag", "technology", "title,url,score,load_time,status,created_at"} {
+		if _, err := parseURLSort(sort); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("parseURLSort(%q) = %v, want ErrInvalidFilter", sort, err)
+		}
+	}
+}
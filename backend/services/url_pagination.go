@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidCursor is returned for a pagination cursor that is malformed or
+	// was issued for a different sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidFields is returned when a sparse fieldset names an unknown field
+	ErrInvalidFields = errors.New("invalid fields")
+)
+
+// URLListOptions controls how GetAllURLs pages through URLs and what it loads
+type URLListOptions struct {
+	Page      int      // page number for offset pagination; ignored when Cursor is set
+	Limit     int      // URLs per page
+	Cursor    *string  // cursor pagination: "" for the first page, then URLPage.NextCursor
+	Fields    []string // URLResponse fields to load; empty loads every field
+	SkipCount bool     // leave URLPage.Total nil instead of counting every match
+}
+
+// URLPage is one page of the URL list
+type URLPage struct {
+	URLs       []models.URL
+	Total      *int64 // every URL matching the filters; nil when the count was skipped
+	HasMore    bool   // whether URLs follow this page
+	NextCursor string // the cursor of the next page in cursor pagination, if HasMore
+}
+
+// urlResponseColumns lists the columns each top-level URLResponse field is built
+// from. Tags come from the url_tags table and only need the ID.
+var urlResponseColumns = map[string][]string{
+	"id":           {"id"},
+	"workspace_id": {"workspace_id"},
+	"url":          {"url"},
+	"title":        {"title"},
+	"description":  {"description"},
+	"status":       {"status"},
+	"status_code":  {"status_code"},
+	"seo_analysis": {
+		"meta_title", "meta_description", "html_version", "noindex",
+		"h1_tags", "h2_tags", "h3_tags", "h4_tags", "h5_tags", "h6_tags",
+		"h1_count", "h2_count", "h3_count", "h4_count", "h5_count", "h6_count",
+		"link_count", "internal_links", "external_links", "broken_links", "broken_links_list",
+		"has_login_form", "form_count", "forms", "high_severity_forms",
+		"accessibility_violation_count",
+		"mobile_analysis", "image_count",
+	},
+	"performance":          {"load_time", "page_size", "total_requests", "third_party_requests", "over_budget", "budget_results"},
+	"tags":                 {"id"},
+	"folder_id":            {"folder_id"},
+	"technologies":         {"technologies"},
+	"javascript":           {"scripts", "js_libraries", "vulnerable_libraries", "vulnerability_count", "vulnerability_severity"},
+	"score":                {"seo_score", "score_breakdown"},
+	"last_analysis_id":     {"last_analysis_id"},
+	"rule_violation_count": {"rule_violation_count"},
+	"checks":               {"check_results"},
+	"analyzed_at":          {"analyzed_at"},
+	"created_at":           {"created_at"},
+	"updated_at":           {"updated_at"},
+}
+
+// NormalizeURLFields trims, lowercases and de-duplicates the names of a sparse
+// fieldset, checking that each is a URLResponse field. The ID is always included.
+func NormalizeURLFields(fields []string) ([]string, error) {
+	normalized := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		if _, known := urlResponseColumns[field]; !known {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFields, field)
+		}
+		seen[field] = true
+		normalized = append(normalized, field)
+	}
+	return normalized, nil
+}
+
+// urlSelectColumns returns the columns to load for a sparse fieldset, along with
+// the columns the sort keys need to build a cursor
+func urlSelectColumns(fields []string, keys []urlSortKey) []string {
+	columns := make(map[string]bool)
+	for _, field := range fields {
+		for _, column := range urlResponseColumns[field] {
+			columns[column] = true
+		}
+	}
+	for _, key := range keys {
+		columns[key.column] = true
+	}
+
+	selected := make([]string, 0, len(columns))
+	for column := range columns {
+		selected = append(selected, column)
+	}
+	sort.Strings(selected)
+	return selected
+}
+
+// urlCursor is the decoded form of a pagination cursor: the sort it was issued
+// for and the sort key values of the last URL on the previous page
+type urlCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// encodeURLCursor returns the cursor that continues after url
+func encodeURLCursor(db *gorm.DB, keys []urlSortKey, url *models.URL) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&models.URL{}); err != nil {
+		return "", fmt.Errorf("failed to parse URL schema: %w", err)
+	}
+
+	cursor := urlCursor{Sort: urlOrderClause(keys)}
+	row := reflect.ValueOf(url).Elem()
+	for _, key := range keys {
+		field := stmt.Schema.LookUpField(key.column)
+		if field == nil {
+			return "", fmt.Errorf("unknown URL column %q", key.column)
+		}
+		value, _ := field.ValueOf(context.Background(), row)
+		cursor.Values = append(cursor.Values, formatCursorValue(value))
+	}
+
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// formatCursorValue formats a sort key value of a URL for a cursor
+func formatCursorValue(value interface{}) string {
+	switch v := value.(type) {
+	case *time.Time:
+		if v == nil {
+			return "" // never analyzed
+		}
+		return v.Format(time.RFC3339Nano)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// urlCursorCondition decodes a cursor and returns the condition that selects
+// the URLs sorting after the URL it was issued for
+func urlCursorCondition(keys []urlSortKey, encoded string) (string, []interface{}, error) {
+	invalid := fmt.Errorf("%w: the cursor is malformed", ErrInvalidCursor)
+
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, invalid
+	}
+	var cursor urlCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return "", nil, invalid
+	}
+	if cursor.Sort != urlOrderClause(keys) {
+		return "", nil, fmt.Errorf("%w: the cursor was issued for a different sort", ErrInvalidCursor)
+	}
+	if len(cursor.Values) != len(keys) {
+		return "", nil, invalid
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if values[i], err = parseCursorValue(key.kind, cursor.Values[i]); err != nil {
+			return "", nil, invalid
+		}
+	}
+
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < for descending keys
+	var alternatives []string
+	var args []interface{}
+	for i, key := range keys {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, keys[j].expression()+" = ?")
+			args = append(args, values[j])
+		}
+		operator := ">"
+		if key.desc {
+			operator = "<"
+		}
+		terms = append(terms, key.expression()+" "+operator+" ?")
+		args = append(args, values[i])
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	// The redundant bound on the first key lets MySQL range-scan an index on it
+	bound := keys[0].expression() + " >= ?"
+	if keys[0].desc {
+		bound = keys[0].expression() + " <= ?"
+	}
+	args = append([]interface{}{values[0]}, args...)
+	return bound + " AND (" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
+
+// parseCursorValue parses a sort key value formatted by formatCursorValue
+func parseCursorValue(kind filterKind, value string) (interface{}, error) {
+	switch kind {
+	case filterInt:
+		return strconv.ParseInt(value, 10, 64)
+	case filterFloat:
+		return strconv.ParseFloat(value, 64)
+	case filterBool:
+		return strconv.ParseBool(value)
+	case filterTime:
+		if value == "" {
+			return neverAnalyzed, nil
+		}
+		return time.Parse(time.RFC3339Nano, value)
+	default:
+		return value, nil
+	}
+}
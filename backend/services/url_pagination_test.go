@@ -0,0 +1,144 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"website-analyzer-backend/models"
+)
+
+func TestURLCursorRoundTrip(t *testing.T) {
+	db := newTestDB(t)
+	analyzedAt := time.Date(2026, 3, 1, 9, 15, 30, 123456789, time.UTC)
+
+	tests := []struct {
+		name     string
+		sort     string
+		url      models.URL
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			// URLs with the same score and title fall back to the ID
+			name: "ties on every sort field",
+			sort: "-score,title",
+			url:  models.URL{ID: 42, SEOScore: 80, Title: "Home"},
+			wantSQL: "seo_score <= ? AND ((seo_score < ?) OR (seo_score = ? AND title > ?) OR " +
+				"(seo_score = ? AND title = ? AND id < ?))",
+			wantArgs: []interface{}{int64(80), int64(80), int64(80), "Home", int64(80), "Home", int64(42)},
+		},
+		{
+			name:     "fractional values",
+			sort:     "load_time",
+			url:      models.URL{ID: 7, LoadTime: 1.25},
+			wantSQL:  "load_time >= ? AND ((load_time > ?) OR (load_time = ? AND id < ?))",
+			wantArgs: []interface{}{1.25, 1.25, 1.25, int64(7)},
+		},
+		{
+			name: "analysis time",
+			sort: "-analyzed_at",
+			url:  models.URL{ID: 3, AnalyzedAt: &analyzedAt},
+			wantSQL: "COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) <= ? AND " +
+				"((COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) < ?) OR " +
+				"(COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) = ? AND id < ?))",
+			wantArgs: []interface{}{analyzedAt, analyzedAt, analyzedAt, int64(3)},
+		},
+		{
+			name: "never analyzed",
+			sort: "analyzed_at",
+			url:  models.URL{ID: 5},
+			wantSQL: "COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) >= ? AND " +
+				"((COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) > ?) OR " +
+				"(COALESCE(analyzed_at, TIMESTAMP('1000-01-01')) = ? AND id < ?))",
+			wantArgs: []interface{}{neverAnalyzed, neverAnalyzed, neverAnalyzed, int64(5)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseURLSort(tt.sort)
+			if err != nil {
+				t.Fatalf("parseURLSort(%q): %v", tt.sort, err)
+			}
+			cursor, err := encodeURLCursor(db, keys, &tt.url)
+			if err != nil {
+				t.Fatalf("encodeURLCursor: %v", err)
+			}
+
+			sql, args, err := urlCursorCondition(keys, cursor)
+			if err != nil {
+				t.Fatalf("urlCursorCondition: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+			for i := range args {
+				got, isTime := args[i].(time.Time)
+				if want, ok := tt.wantArgs[i].(time.Time); ok && isTime {
+					if !got.Equal(want) {
+						t.Errorf("args[%d] = %v, want %v", i, got, want)
+					}
+				} else if !reflect.DeepEqual(args[i], tt.wantArgs[i]) {
+					t.Errorf("args[%d] = %#v, want %#v", i, args[i], tt.wantArgs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestURLCursorRejects(t *testing.T) {
+	db := newTestDB(t)
+	descending, err := parseURLSort("-score")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ascending, err := parseURLSort("score")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := encodeURLCursor(db, descending, &models.URL{ID: 1, SEOScore: 90})
+	if err != nil {
+		t.Fatalf("encodeURLCursor: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		keys      []urlSortKey
+		cursor    string
+		wantError string
+	}{
+		{"different sort", ascending, cursor, "different sort"},
+		{"not base64", descending, "not a cursor!", "malformed"},
+		{"not JSON", descending, base64.RawURLEncoding.EncodeToString([]byte("not JSON")), "malformed"},
+		{"missing values", descending, rawCursor(t, "seo_score DESC, id DESC", "90"), "malformed"},
+		{"wrong value type", descending, rawCursor(t, "seo_score DESC, id DESC", "ninety", "1"), "malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := urlCursorCondition(tt.keys, tt.cursor)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("urlCursorCondition = %v, want ErrInvalidCursor", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("error %q does not mention %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+// rawCursor encodes a cursor with arbitrary contents
+func rawCursor(t *testing.T, sort string, values ...string) string {
+	t.Helper()
+	encoded, err := json.Marshal(urlCursor{Sort: sort, Values: values})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -10,50 +11,86 @@ import (
 	"website-analyzer-backend/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // URLService handles business logic for URL operations
 type URLService struct {
-	db          *gorm.DB
-	seoAnalyzer *SEOAnalyzer
+	db            *gorm.DB
+	seoAnalyzer   *SEOAnalyzer
+	budgetService *BudgetService
+	alertService   *AlertService
+	webhookService *WebhookService
+	notifications  *NotificationService
+	workspaceID    uint // workspace whose URLs are visible; 0 sees none, for background jobs that work by ID
 }
 
 // NewURLService creates a new URL service instance
 func NewURLService() *URLService {
 	return &URLService{
-		db:          database.GetDB(),
-		seoAnalyzer: NewSEOAnalyzer(),
+		db:            database.GetDB(),
+		seoAnalyzer:   NewSEOAnalyzer(),
+		budgetService: NewBudgetService(),
+		alertService:   NewAlertService(),
+		webhookService: NewWebhookService(),
+		notifications:  NewNotificationService(),
 	}
 }
 
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *URLService) ForWorkspace(workspaceID uint) *URLService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
 // CreateURL creates a new URL record
 func (s *URLService) CreateURL(req models.URLCreateRequest) (*models.URL, error) {
-	// Check if URL already exists
+	// Check if URL already exists in the workspace
 	var existingURL models.URL
-	if err := s.db.Where("url = ?", req.URL).First(&existingURL).Error; err == nil {
+	if err := s.db.Where("workspace_id = ? AND url = ?", s.workspaceID, req.URL).First(&existingURL).Error; err == nil {
 		return nil, errors.New("URL already exists")
 	}
+	if trashed := s.findTrashedURL(s.db, req.URL); trashed != nil {
+		return nil, &TrashedURLError{URL: *trashed}
+	}
 
 	// Create new URL record
 	url := models.URL{
-		URL:       req.URL,
-		Title:     req.Title,
-		Status:    "pending",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		WorkspaceID: s.workspaceID,
+		URL:         req.URL,
+		Title:       req.Title,
+		Status:      "pending",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
+	if len(req.Tags) > 0 {
+		tags, err := findOrCreateTags(s.db, s.workspaceID, req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		url.Tags = tags
+	}
+
+	folderID, err := s.resolveFolder(req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	url.FolderID = folderID
+
 	if err := s.db.Create(&url).Error; err != nil {
 		return nil, fmt.Errorf("failed to create URL: %w", err)
 	}
 
+	s.webhookService.publish(url.WorkspaceID, models.WebhookEventURLCreated, url.ToResponse())
 	return &url, nil
 }
 
 // GetURLByID retrieves a URL by its ID
 func (s *URLService) GetURLByID(id uint) (*models.URL, error) {
 	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Preload("Tags").First(&url, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("URL not found")
 		}
@@ -65,19 +102,26 @@ func (s *URLService) GetURLByID(id uint) (*models.URL, error) {
 
 // URLFilters represents filters for URL queries
 type URLFilters struct {
-	Search    string
-	Status    string
-	SortBy    string
-	SortOrder string
+	Search     string
+	Status     string
+	Technology string
+	RuleID     uint // URLs whose latest analysis violated this audit rule
+	OverBudget bool // URLs whose latest analysis exceeded a performance budget
+	Tags       []string // URLs with every one of these tags
+	FolderID   *uint    // URLs in this folder or its sub-folders; 0 for URLs in no folder
+	Query      string   // filter expression, see compileURLFilter
+	Sort       string   // fields to sort by such as "-load_time,title"; overrides SortBy
+	SortBy     string
+	SortOrder  string
 }
 
-// GetAllURLs retrieves all URLs with pagination, search, and filtering
-func (s *URLService) GetAllURLs(page, limit int, filters URLFilters) ([]models.URL, int64, error) {
+// GetAllURLs retrieves a page of URLs with search, filtering and sorting. Pages
+// are numbered, or follow on from a cursor for lists too long for offsets.
+func (s *URLService) GetAllURLs(filters URLFilters, options URLListOptions) (*URLPage, error) {
 	var urls []models.URL
-	var total int64
 
 	// Build query with filters
-	query := s.db.Model(&models.URL{})
+	query := s.db.Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID))
 
 	// Apply search filter
 	if filters.Search != "" {
@@ -90,50 +134,174 @@ func (s *URLService) GetAllURLs(page, limit int, filters URLFilters) ([]models.U
 		query = query.Where("status = ?", filters.Status)
 	}
 
-	// Count total records with filters
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count URLs: %w", err)
+	// Apply technology filter (matches the name inside the stored JSON list)
+	if filters.Technology != "" {
+		technologyName, _ := json.Marshal(filters.Technology)
+		query = query.Where("technologies LIKE ?", "%\"name\":"+escapeLike(string(technologyName))+"%")
 	}
 
-	// Calculate offset
-	offset := (page - 1) * limit
+	// Apply audit rule filter (violations recorded by each URL's latest analysis)
+	if filters.RuleID != 0 {
+		query = query.Where("last_analysis_id IN (?)", s.db.Model(&models.RuleViolation{}).Select("analysis_id").Where("rule_id = ?", filters.RuleID))
+	}
+
+	// Apply performance budget filter
+	if filters.OverBudget {
+		query = query.Where("over_budget = ?", true)
+	}
+
+	// Apply tag filter
+	for _, tag := range normalizeTagNames(filters.Tags) {
+		query = query.Where("id IN (?)", s.db.Table("url_tags").Select("url_tags.url_id").Joins("JOIN tags ON tags.id = url_tags.tag_id").Where("tags.name = ?", tag))
+	}
+
+	// Apply folder filter
+	if filters.FolderID != nil {
+		if *filters.FolderID == 0 {
+			query = query.Where("folder_id IS NULL")
+		} else {
+			tree, err := loadFolderTree(s.db, s.workspaceID)
+			if err != nil {
+				return nil, err
+			}
+			query = query.Where("folder_id IN ?", tree.descendants(*filters.FolderID))
+		}
+	}
+
+	// Apply filter expression
+	if filters.Query != "" {
+		filter, err := compileURLFilter(filters.Query, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(filter.sql, filter.args...)
+	}
 
 	// Apply sorting
-	orderClause := "created_at DESC" // default sorting
+	keys := []urlSortKey{{column: "created_at", kind: filterTime, desc: true}, idSortKey} // default sorting
 	if filters.SortBy != "" {
-		validSortFields := map[string]string{
-			"created_at":       "created_at",
-			"title":           "title",
-			"url":             "url",
-			"status":          "status",
-			"html_version":    "html_version",
-			"internal_links":  "internal_links",
-			"external_links":  "external_links",
-			"load_time":       "load_time",
-			"page_size":       "page_size",
-		}
-
-		if dbField, exists := validSortFields[filters.SortBy]; exists {
-			sortOrder := "ASC"
+		validSortFields := map[string]bool{
+			"created_at":     true,
+			"title":          true,
+			"url":            true,
+			"status":         true,
+			"html_version":   true,
+			"internal_links": true,
+			"external_links": true,
+			"load_time":      true,
+			"page_size":      true,
+			"score":          true,
+		}
+
+		if validSortFields[filters.SortBy] {
+			sortBy := filters.SortBy
 			if filters.SortOrder == "desc" {
-				sortOrder = "DESC"
+				sortBy = "-" + sortBy
 			}
-			orderClause = fmt.Sprintf("%s %s", dbField, sortOrder)
+			keys, _ = parseURLSort(sortBy)
+		}
+	}
+	if filters.Sort != "" {
+		sortKeys, err := parseURLSort(filters.Sort)
+		if err != nil {
+			return nil, err
 		}
+		keys = sortKeys
 	}
 
-	// Get paginated results with filters and sorting
-	if err := query.Offset(offset).Limit(limit).Order(orderClause).Find(&urls).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to get URLs: %w", err)
+	// Count total records with filters, unless the caller can do without
+	page := &URLPage{}
+	if !options.SkipCount {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, fmt.Errorf("failed to count URLs: %w", err)
+		}
+		page.Total = &total
 	}
 
-	return urls, total, nil
+	// Continue after the cursor, or skip to the page
+	if options.Cursor != nil {
+		if *options.Cursor != "" {
+			condition, args, err := urlCursorCondition(keys, *options.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			query = query.Where(condition, args...)
+		}
+	} else {
+		query = query.Offset((options.Page - 1) * options.Limit)
+	}
+
+	// Load only the columns of the requested fields
+	if len(options.Fields) > 0 {
+		query = query.Select(urlSelectColumns(options.Fields, keys))
+	}
+	if len(options.Fields) == 0 || containsString(options.Fields, "tags") {
+		query = query.Preload("Tags")
+	}
+
+	// Get one URL more than the page holds to learn whether another page follows
+	if err := query.Limit(options.Limit + 1).Order(urlOrderClause(keys)).Find(&urls).Error; err != nil {
+		return nil, fmt.Errorf("failed to get URLs: %w", err)
+	}
+	if len(urls) > options.Limit {
+		urls = urls[:options.Limit]
+		page.HasMore = true
+		if options.Cursor != nil {
+			cursor, err := encodeURLCursor(s.db, keys, &urls[len(urls)-1])
+			if err != nil {
+				return nil, err
+			}
+			page.NextCursor = cursor
+		}
+	}
+	page.URLs = urls
+
+	return page, nil
+}
+
+// GetURLFindings retrieves the findings and metrics recorded by a URL's latest analysis
+func (s *URLService) GetURLFindings(id uint, check, severity string) ([]models.Finding, []models.Metric, error) {
+	url, err := s.GetURLByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	findings := []models.Finding{}
+	metrics := []models.Metric{}
+	if url.LastAnalysisID == 0 {
+		return findings, metrics, nil
+	}
+
+	findingQuery := s.db.Where("analysis_id = ?", url.LastAnalysisID)
+	metricQuery := s.db.Where("analysis_id = ?", url.LastAnalysisID)
+	if check != "" {
+		findingQuery = findingQuery.Where("check_name = ?", check)
+		metricQuery = metricQuery.Where("check_name = ?", check)
+	}
+	if severity != "" {
+		findingQuery = findingQuery.Where("severity = ?", severity)
+	}
+
+	if err := findingQuery.Order("id ASC").Find(&findings).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get findings: %w", err)
+	}
+	if err := metricQuery.Order("id ASC").Find(&metrics).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get metrics: %w", err)
+	}
+
+	return findings, metrics, nil
+}
+
+// GetChecks describes the analyzer check pipeline
+func (s *URLService) GetChecks() []CheckInfo {
+	return s.seoAnalyzer.Checks()
 }
 
 // UpdateURL updates an existing URL
 func (s *URLService) UpdateURL(id uint, req models.URLUpdateRequest) (*models.URL, error) {
 	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&url, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("URL not found")
 		}
@@ -151,14 +319,32 @@ func (s *URLService) UpdateURL(id uint, req models.URLUpdateRequest) (*models.UR
 	if req.Status != nil {
 		updates["status"] = *req.Status
 	}
+	if req.FolderID != nil {
+		folderID, err := s.resolveFolder(req.FolderID)
+		if err != nil {
+			return nil, err
+		}
+		updates["folder_id"] = folderID
+	}
 	updates["updated_at"] = time.Now()
 
 	if err := s.db.Model(&url).Updates(updates).Error; err != nil {
 		return nil, fmt.Errorf("failed to update URL: %w", err)
 	}
 
+	// Replace tags if provided
+	if req.Tags != nil {
+		tags, err := findOrCreateTags(s.db, url.WorkspaceID, *req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.Model(&url).Association("Tags").Replace(tags); err != nil {
+			return nil, fmt.Errorf("failed to update URL tags: %w", err)
+		}
+	}
+
 	// Reload the updated record
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Preload("Tags").First(&url, id).Error; err != nil {
 		return nil, fmt.Errorf("failed to reload URL: %w", err)
 	}
 
@@ -167,22 +353,15 @@ func (s *URLService) UpdateURL(id uint, req models.URLUpdateRequest) (*models.UR
 
 // DeleteURL deletes a URL by ID
 func (s *URLService) DeleteURL(id uint) error {
-	result := s.db.Delete(&models.URL{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete URL: %w", result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("URL not found")
-	}
-
-	return nil
+	_, err := s.DeleteURLWithDetails(id)
+	return err
 }
 
 // DeleteURLWithDetails deletes a URL by ID and returns detailed information
 func (s *URLService) DeleteURLWithDetails(id uint) (*models.URLDeleteResponse, error) {
 	// First, get the URL details before deletion
 	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&url, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("URL not found")
 		}
@@ -202,6 +381,7 @@ func (s *URLService) DeleteURLWithDetails(id uint) (*models.URLDeleteResponse, e
 	}
 
 	log.Printf("Successfully deleted URL: %s (ID: %d)", url.URL, url.ID)
+	s.webhookService.publish(url.WorkspaceID, models.WebhookEventURLDeleted, deletedURL)
 
 	return &models.URLDeleteResponse{
 		DeletedURL:   deletedURL,
@@ -213,19 +393,16 @@ func (s *URLService) DeleteURLWithDetails(id uint) (*models.URLDeleteResponse, e
 // AnalyzeURL performs analysis on a URL (placeholder for future implementation)
 func (s *URLService) AnalyzeURL(id uint) error {
 	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&url, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("URL not found")
 		}
 		return fmt.Errorf("failed to find URL: %w", err)
 	}
 
-	// Update status to analyzing
-	if err := s.db.Model(&url).Updates(map[string]interface{}{
-		"status":     "analyzing",
-		"updated_at": time.Now(),
-	}).Error; err != nil {
-		return fmt.Errorf("failed to update URL status: %w", err)
+	// Count the analysis against the workspace quota and update status to analyzing
+	if err := s.startAnalysis(&url); err != nil {
+		return err
 	}
 
 	// TODO: Implement actual URL analysis logic here
@@ -238,19 +415,16 @@ func (s *URLService) AnalyzeURL(id uint) error {
 // AnalyzeURLSync performs synchronous analysis on a URL and returns the complete result
 func (s *URLService) AnalyzeURLSync(id uint) (*models.URL, error) {
 	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&url, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("URL not found")
 		}
 		return nil, fmt.Errorf("failed to find URL: %w", err)
 	}
 
-	// Update status to analyzing
-	if err := s.db.Model(&url).Updates(map[string]interface{}{
-		"status":     "analyzing",
-		"updated_at": time.Now(),
-	}).Error; err != nil {
-		return nil, fmt.Errorf("failed to update URL status: %w", err)
+	// Count the analysis against the workspace quota and update status to analyzing
+	if err := s.startAnalysis(&url); err != nil {
+		return nil, err
 	}
 
 	// Perform synchronous analysis
@@ -260,127 +434,68 @@ func (s *URLService) AnalyzeURLSync(id uint) (*models.URL, error) {
 	}
 
 	// Fetch the updated URL with analysis results
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Preload("Tags").First(&url, id).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch updated URL: %w", err)
 	}
 
 	return &url, nil
 }
 
-// performAnalysis performs comprehensive SEO analysis on a URL
-func (s *URLService) performAnalysis(id uint) {
-	// Get the URL record
-	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
-		// Mark as failed if URL not found
-		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
-			"status":        "failed",
-			"error_message": "URL record not found",
-			"updated_at":    time.Now(),
-		})
-		return
-	}
-
-	log.Printf("Starting SEO analysis for URL: %s", url.URL)
-
-	// Perform comprehensive SEO analysis
-	result, err := s.seoAnalyzer.AnalyzeURL(url.URL)
-	if err != nil {
-		// Mark as failed if analysis fails
-		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
-			"status":        "failed",
-			"error_message": fmt.Sprintf("Analysis failed: %v", err),
-			"updated_at":    time.Now(),
-		})
-		return
-	}
+// startAnalysis counts an analysis of url against its workspace's monthly quota
+// and marks the URL as being analyzed
+func (s *URLService) startAnalysis(url *models.URL) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := reserveAnalyses(tx, url.WorkspaceID, 1); err != nil {
+			return err
+		}
+		if err := tx.Model(url).Updates(map[string]interface{}{
+			"status":     "analyzing",
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update URL status: %w", err)
+		}
+		return nil
+	})
+}
 
-	// Convert arrays to JSON strings for database storage
-	jsonStrings, err := s.seoAnalyzer.ConvertToJSONStrings(result)
-	if err != nil {
-		log.Printf("Failed to convert analysis results to JSON: %v", err)
-		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
-			"status":        "failed",
-			"error_message": "Failed to process analysis results",
-			"updated_at":    time.Now(),
+// analyzeIfIdle analyzes a URL of a workspace synchronously unless it is
+// already being analyzed. It reports whether an analysis was started; the
+// analysis counts against the workspace's monthly quota.
+func (s *URLService) analyzeIfIdle(workspaceID, id uint) (bool, error) {
+	started := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.URL{}).Where("id = ? AND status <> ?", id, "analyzing").Updates(map[string]interface{}{
+			"status":     "analyzing",
+			"updated_at": time.Now(),
 		})
-		return
-	}
-
-	// Prepare updates with all analysis results
-	updates := map[string]interface{}{
-		"status":      "completed",
-		"status_code": result.StatusCode,
-		"analyzed_at": time.Now(),
-		"updated_at":  time.Now(),
-
-		// SEO Analysis fields
-		"html_version":     result.HTMLVersion,
-		"meta_title":       result.MetaTitle,
-		"meta_description": result.MetaDescription,
-
-		// Heading tags
-		"h1_tags":  jsonStrings["h1_tags"],
-		"h2_tags":  jsonStrings["h2_tags"],
-		"h3_tags":  jsonStrings["h3_tags"],
-		"h4_tags":  jsonStrings["h4_tags"],
-		"h5_tags":  jsonStrings["h5_tags"],
-		"h6_tags":  jsonStrings["h6_tags"],
-		"h1_count": result.H1Count,
-		"h2_count": result.H2Count,
-		"h3_count": result.H3Count,
-		"h4_count": result.H4Count,
-		"h5_count": result.H5Count,
-		"h6_count": result.H6Count,
-
-		// Link analysis
-		"image_count":        result.ImageCount,
-		"link_count":         result.TotalLinks,
-		"internal_links":     result.InternalLinks,
-		"external_links":     result.ExternalLinks,
-		"broken_links":       len(result.BrokenLinks),
-		"broken_links_list":  jsonStrings["broken_links_list"],
-
-		// Form analysis
-		"has_login_form": result.HasLoginForm,
-		"form_count":     result.FormCount,
-
-		// Performance
-		"load_time": result.LoadTime,
-		"page_size": result.PageSize,
-	}
-
-	// Add error message if there was one during analysis
-	if result.ErrorMessage != "" {
-		updates["error_message"] = result.ErrorMessage
-		// If there was an error but we got some results, mark as completed with warnings
-		if result.StatusCode > 0 {
-			updates["status"] = "completed"
-		} else {
-			updates["status"] = "failed"
+		if result.Error != nil {
+			return fmt.Errorf("failed to update URL status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
 		}
+		started = true
+		return reserveAnalyses(tx, workspaceID, 1)
+	})
+	if err != nil || !started {
+		return false, err
 	}
 
-	// Update the database with analysis results
-	if err := s.db.Model(&models.URL{}).Where("id = ?", id).Updates(updates).Error; err != nil {
-		log.Printf("Failed to update analysis results: %v", err)
-		// If update fails, mark as failed
-		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
-			"status":        "failed",
-			"error_message": "Failed to save analysis results",
-			"updated_at":    time.Now(),
-		})
-		return
-	}
+	return true, s.performAnalysisSync(id)
+}
 
-	log.Printf("SEO analysis completed successfully for URL: %s", url.URL)
+// performAnalysis performs comprehensive SEO analysis on a URL in the background
+func (s *URLService) performAnalysis(id uint) {
+	if err := s.performAnalysisSync(id); err != nil {
+		log.Printf("SEO analysis failed for URL ID %d: %v", id, err)
+	}
 }
 
 // performAnalysisSync performs comprehensive SEO analysis on a URL synchronously
 func (s *URLService) performAnalysisSync(id uint) error {
 	// Get the URL record
 	var url models.URL
-	if err := s.db.First(&url, id).Error; err != nil {
+	if err := s.db.Preload("Tags").First(&url, id).Error; err != nil {
 		// Mark as failed if URL not found
 		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
 			"status":        "failed",
@@ -390,10 +505,16 @@ func (s *URLService) performAnalysisSync(id uint) error {
 		return fmt.Errorf("URL record not found")
 	}
 
-	log.Printf("Starting synchronous SEO analysis for URL: %s", url.URL)
+	log.Printf("Starting SEO analysis for URL: %s", url.URL)
+
+	// Notify webhooks of the outcome and raised alerts once the URL holds its final state
+	var raisedAlerts []models.Alert
+	defer func() {
+		s.publishAnalysisEvents(id, raisedAlerts)
+	}()
 
 	// Perform comprehensive SEO analysis
-	result, err := s.seoAnalyzer.AnalyzeURL(url.URL)
+	result, err := s.seoAnalyzer.AnalyzeURL(url.URL, url.WorkspaceID)
 	if err != nil {
 		// Mark as failed if analysis fails
 		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
@@ -416,6 +537,45 @@ func (s *URLService) performAnalysisSync(id uint) error {
 		return fmt.Errorf("failed to process analysis results: %w", err)
 	}
 
+	// Summarize known library vulnerabilities for the page
+	vulnerableLibraries, vulnerabilityCount, vulnerabilitySeverity := 0, 0, ""
+	for _, library := range result.JSLibraries {
+		if len(library.Vulnerabilities) == 0 {
+			continue
+		}
+		vulnerableLibraries++
+		vulnerabilityCount += len(library.Vulnerabilities)
+		vulnerabilitySeverity = HighestSeverity(vulnerabilitySeverity, library.Severity)
+	}
+
+	// Evaluate the performance budgets that apply to the URL and its tags
+	budgets, err := s.budgetService.GetApplicableBudgets(&url)
+	if err != nil {
+		log.Printf("Skipping performance budgets for URL %s: %v", url.URL, err)
+	}
+	budgetResults := EvaluateBudgets(budgets, result)
+	overBudget := false
+	for _, budgetResult := range budgetResults {
+		if budgetResult.Passed {
+			continue
+		}
+		overBudget = true
+		result.Findings = append(result.Findings, models.Finding{
+			Check:    "budgets",
+			Severity: "high",
+			Message: fmt.Sprintf("Budget %q exceeded: %s is %g (limit %g, over by %g)",
+				budgetResult.BudgetName, budgetResult.Metric, budgetResult.Actual, budgetResult.Max, budgetResult.Overage),
+		})
+	}
+	budgetResultsJSON, _ := json.Marshal(budgetResults)
+
+	// Alert rules compare this analysis with the previous one stored on the URL
+	alertRules, err := s.alertService.GetApplicableRules(&url)
+	if err != nil {
+		log.Printf("Skipping alert rules for URL %s: %v", url.URL, err)
+	}
+	previousSnapshot, currentSnapshot := alertSnapshotFromURL(&url), alertSnapshotFromResult(result)
+
 	// Prepare updates with all analysis results
 	updates := map[string]interface{}{
 		"status":      "completed",
@@ -427,6 +587,7 @@ func (s *URLService) performAnalysisSync(id uint) error {
 		"html_version":     result.HTMLVersion,
 		"meta_title":       result.MetaTitle,
 		"meta_description": result.MetaDescription,
+		"noindex":          result.Noindex,
 
 		// Heading tags
 		"h1_tags":  jsonStrings["h1_tags"],
@@ -453,10 +614,42 @@ func (s *URLService) performAnalysisSync(id uint) error {
 		// Form analysis
 		"has_login_form": result.HasLoginForm,
 		"form_count":     result.FormCount,
+		"forms":               jsonStrings["forms"],
+		"high_severity_forms": result.HighSeverityForms,
+
+		// Mobile-friendliness
+		"mobile_analysis": jsonStrings["mobile_analysis"],
+		"mobile_friendly": result.Mobile.MobileFriendly,
+
+		// Accessibility
+		"accessibility_violation_count": result.AccessibilityViolationCount,
+
+		// Technologies
+		"technologies": jsonStrings["technologies"],
+
+		// JavaScript libraries
+		"scripts":                jsonStrings["scripts"],
+		"js_libraries":           jsonStrings["js_libraries"],
+		"vulnerable_libraries":   vulnerableLibraries,
+		"vulnerability_count":    vulnerabilityCount,
+		"vulnerability_severity": vulnerabilitySeverity,
+
+		// Health score
+		"seo_score":       result.Score.Score,
+		"score_breakdown": jsonStrings["score_breakdown"],
+
+		// Check pipeline
+		"check_results": jsonStrings["check_results"],
 
 		// Performance
-		"load_time": result.LoadTime,
-		"page_size": result.PageSize,
+		"load_time":            result.LoadTime,
+		"page_size":            result.PageSize,
+		"total_requests":       result.TotalRequests,
+		"third_party_requests": result.ThirdPartyRequests,
+
+		// Performance budgets
+		"budget_results": string(budgetResultsJSON),
+		"over_budget":    overBudget,
 	}
 
 	// Add error message if there was one during analysis
@@ -470,8 +663,76 @@ func (s *URLService) performAnalysisSync(id uint) error {
 		}
 	}
 
+	// Record the analysis run with its rule violations, findings, metrics and alerts, and update the URL in one transaction
+	analyzedAt := updates["analyzed_at"].(time.Time)
+	updates["rule_violation_count"] = len(result.RuleViolations)
+	var newAlerts []models.Alert
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		analysis := models.Analysis{
+			URLID:                       id,
+			Status:                      updates["status"].(string),
+			StatusCode:                  result.StatusCode,
+			SEOScore:                    result.Score.Score,
+			LoadTime:                    result.LoadTime,
+			PageSize:                    result.PageSize,
+			BrokenLinks:                 len(result.BrokenLinks),
+			AccessibilityViolationCount: result.AccessibilityViolationCount,
+			VulnerabilityCount:          vulnerabilityCount,
+			RuleViolationCount:          len(result.RuleViolations),
+			FindingCount:                len(result.Findings),
+			OverBudget:                  overBudget,
+			ErrorMessage:                result.ErrorMessage,
+			AnalyzedAt:                  analyzedAt,
+		}
+		if err := tx.Create(&analysis).Error; err != nil {
+			return err
+		}
+
+		if len(result.RuleViolations) > 0 {
+			for i := range result.RuleViolations {
+				result.RuleViolations[i].AnalysisID = analysis.ID
+				result.RuleViolations[i].URLID = id
+				result.RuleViolations[i].AnalyzedAt = analyzedAt
+			}
+			if err := tx.Create(&result.RuleViolations).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(result.Findings) > 0 {
+			for i := range result.Findings {
+				result.Findings[i].AnalysisID = analysis.ID
+				result.Findings[i].URLID = id
+				result.Findings[i].AnalyzedAt = analyzedAt
+			}
+			if err := tx.Create(&result.Findings).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(result.Metrics) > 0 {
+			for i := range result.Metrics {
+				result.Metrics[i].AnalysisID = analysis.ID
+				result.Metrics[i].URLID = id
+				result.Metrics[i].AnalyzedAt = analyzedAt
+			}
+			if err := tx.Create(&result.Metrics).Error; err != nil {
+				return err
+			}
+		}
+
+		raised, err := s.alertService.applyAlerts(tx, alertRules, previousSnapshot, currentSnapshot, id, analysis.ID, analyzedAt)
+		if err != nil {
+			return err
+		}
+		newAlerts = raised
+
+		updates["last_analysis_id"] = analysis.ID
+		return tx.Model(&models.URL{}).Where("id = ?", id).Updates(updates).Error
+	})
+
 	// Update the database with analysis results
-	if err := s.db.Model(&models.URL{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+	if err != nil {
 		log.Printf("Failed to update analysis results: %v", err)
 		// If update fails, mark as failed
 		s.db.Model(&models.URL{}).Where("id = ?", id).Updates(map[string]interface{}{
@@ -482,16 +743,53 @@ func (s *URLService) performAnalysisSync(id uint) error {
 		return fmt.Errorf("failed to save analysis results: %w", err)
 	}
 
-	log.Printf("Synchronous SEO analysis completed successfully for URL: %s", url.URL)
+	raisedAlerts = newAlerts
+	if len(raisedAlerts) > 0 {
+		log.Printf("Raised %d alert(s) for URL: %s", len(raisedAlerts), url.URL)
+	}
+	log.Printf("SEO analysis completed successfully for URL: %s", url.URL)
 	return nil
 }
 
+// publishAnalysisEvents notifies webhooks of an analysis outcome and of the alerts
+// it raised, and mails critical alerts to their subscribers
+func (s *URLService) publishAnalysisEvents(id uint, alerts []models.Alert) {
+	var url models.URL
+	if err := s.db.Preload("Tags").First(&url, id).Error; err != nil {
+		log.Printf("Skipping webhook events for URL ID %d: %v", id, err)
+		return
+	}
+
+	response := url.ToResponse()
+	event := models.WebhookEventAnalysisCompleted
+	if url.Status != "completed" {
+		event = models.WebhookEventAnalysisFailed
+	}
+	s.webhookService.publish(url.WorkspaceID, event, response)
+
+	for _, alert := range alerts {
+		s.webhookService.publish(url.WorkspaceID, models.WebhookEventAlertTriggered, models.AlertTriggeredPayload{
+			Alert: alert,
+			URL:   response,
+		})
+	}
+	if len(alerts) > 0 {
+		go s.notifications.NotifyCriticalAlerts(alerts, response)
+	}
+}
+
 // BulkDeleteURLs deletes multiple URLs by their IDs
 func (s *URLService) BulkDeleteURLs(ids []uint) error {
 	if len(ids) == 0 {
 		return errors.New("no IDs provided")
 	}
 
+	// Capture the URLs for webhook notifications before deletion
+	var urls []models.URL
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Where("id IN ?", ids).Find(&urls).Error; err != nil {
+		return fmt.Errorf("failed to find URLs: %w", err)
+	}
+
 	// Start a transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -504,7 +802,7 @@ func (s *URLService) BulkDeleteURLs(ids []uint) error {
 	}()
 
 	// Delete URLs in bulk
-	result := tx.Where("id IN ?", ids).Delete(&models.URL{})
+	result := tx.Scopes(inWorkspace(s.workspaceID)).Where("id IN ?", ids).Delete(&models.URL{})
 	if result.Error != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to delete URLs: %w", result.Error)
@@ -522,6 +820,9 @@ func (s *URLService) BulkDeleteURLs(ids []uint) error {
 	}
 
 	log.Printf("Successfully deleted %d URLs", result.RowsAffected)
+	for _, url := range urls {
+		s.webhookService.publish(url.WorkspaceID, models.WebhookEventURLDeleted, url.ToResponse())
+	}
 	return nil
 }
 
@@ -533,7 +834,7 @@ func (s *URLService) BulkDeleteURLsWithDetails(ids []uint) (*models.BulkDeleteRe
 
 	// First, get the URLs details before deletion
 	var urls []models.URL
-	if err := s.db.Where("id IN ?", ids).Find(&urls).Error; err != nil {
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Where("id IN ?", ids).Find(&urls).Error; err != nil {
 		return nil, fmt.Errorf("failed to find URLs: %w", err)
 	}
 
@@ -559,7 +860,7 @@ func (s *URLService) BulkDeleteURLsWithDetails(ids []uint) (*models.BulkDeleteRe
 	}()
 
 	// Delete URLs in bulk
-	result := tx.Where("id IN ?", ids).Delete(&models.URL{})
+	result := tx.Scopes(inWorkspace(s.workspaceID)).Where("id IN ?", ids).Delete(&models.URL{})
 	if result.Error != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to delete URLs: %w", result.Error)
@@ -577,6 +878,9 @@ func (s *URLService) BulkDeleteURLsWithDetails(ids []uint) (*models.BulkDeleteRe
 	}
 
 	log.Printf("Successfully deleted %d URLs", result.RowsAffected)
+	for _, deletedURL := range deletedURLs {
+		s.webhookService.publish(deletedURL.WorkspaceID, models.WebhookEventURLDeleted, deletedURL)
+	}
 
 	return &models.BulkDeleteResponse{
 		DeletedURLs:  deletedURLs,
@@ -586,16 +890,340 @@ func (s *URLService) BulkDeleteURLsWithDetails(ids []uint) (*models.BulkDeleteRe
 	}, nil
 }
 
-// BulkAnalyzeURLs triggers analysis for multiple URLs by their IDs
-func (s *URLService) BulkAnalyzeURLs(ids []uint) error {
+// urlOwnedModels lists the models that record data about a single URL and are
+// deleted along with it when it is purged
+var urlOwnedModels = []interface{}{
+	&models.Analysis{},
+	&models.Finding{},
+	&models.Metric{},
+	&models.RuleViolation{},
+	&models.Alert{},
+	&models.UptimeCheck{},
+}
+
+// urlTargetedModels lists the configuration that targets a single URL. It has
+// nothing left to apply to once the URL is purged, and clearing its url_id
+// would widen it to every URL, so it is deleted too.
+var urlTargetedModels = []interface{}{
+	&models.PerformanceBudget{},
+	&models.Schedule{},
+	&models.AlertRule{},
+}
+
+// TrashedURLError is returned when creating a URL whose address is in the
+// workspace's trash; restoring it keeps its history
+type TrashedURLError struct {
+	URL models.URL
+}
+
+func (e *TrashedURLError) Error() string {
+	return fmt.Sprintf("URL is in the trash (ID %d)", e.URL.ID)
+}
+
+// findTrashedURL returns the most recently deleted copy of an address in the
+// workspace's trash, if there is one
+func (s *URLService) findTrashedURL(db *gorm.DB, address string) *models.URL {
+	var url models.URL
+	if err := db.Unscoped().Where("workspace_id = ? AND url = ? AND deleted_at IS NOT NULL", s.workspaceID, address).Order("deleted_at DESC").First(&url).Error; err != nil {
+		return nil
+	}
+	return &url
+}
+
+// GetTrash retrieves the URLs in the workspace's trash with pagination, most recently deleted first
+func (s *URLService) GetTrash(page, limit int) ([]models.URL, int64, error) {
+	var urls []models.URL
+	var total int64
+
+	query := s.db.Unscoped().Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID)).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count URLs in trash: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Preload("Tags").Offset(offset).Limit(limit).Order("deleted_at DESC, id DESC").Find(&urls).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get URLs in trash: %w", err)
+	}
+
+	return urls, total, nil
+}
+
+// getTrashedURL retrieves a URL in the workspace's trash by its ID
+func (s *URLService) getTrashedURL(id uint) (*models.URL, error) {
+	var url models.URL
+	if err := s.db.Unscoped().Scopes(inWorkspace(s.workspaceID)).Where("deleted_at IS NOT NULL").First(&url, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("URL not found in trash")
+		}
+		return nil, fmt.Errorf("failed to find URL: %w", err)
+	}
+	return &url, nil
+}
+
+// restoreURL moves a URL out of the trash unless the workspace has added the
+// same address again since it was deleted
+func (s *URLService) restoreURL(url *models.URL) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock every copy of the address, trashed or not, so concurrent
+		// restores and creates of it wait until this one commits
+		var copies []models.URL
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "deleted_at").
+			Where("workspace_id = ? AND url = ?", url.WorkspaceID, url.URL).Find(&copies).Error; err != nil {
+			return fmt.Errorf("failed to check for existing URL: %w", err)
+		}
+		for _, other := range copies {
+			switch {
+			case other.DeletedAt.Valid:
+				continue
+			case other.ID == url.ID:
+				return errors.New("URL not found in trash")
+			default:
+				return errors.New("URL already exists")
+			}
+		}
+
+		if err := tx.Unscoped().Model(&models.URL{}).Where("id = ?", url.ID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to restore URL: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Restored URL from trash: %s (ID: %d)", url.URL, url.ID)
+	return nil
+}
+
+// RestoreURL moves a URL out of the trash
+func (s *URLService) RestoreURL(id uint) (*models.URL, error) {
+	url, err := s.getTrashedURL(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.restoreURL(url); err != nil {
+		return nil, err
+	}
+	return s.GetURLByID(id)
+}
+
+// BulkRestoreURLs moves several URLs out of the trash. URLs that cannot be
+// restored are reported in the returned errors; the others are restored.
+func (s *URLService) BulkRestoreURLs(ids []uint) ([]models.URL, []error) {
 	if len(ids) == 0 {
-		return errors.New("no IDs provided")
+		return nil, []error{errors.New("no IDs provided")}
+	}
+
+	var restoredIDs []uint
+	var restoreErrors []error
+	for _, id := range ids {
+		url, err := s.getTrashedURL(id)
+		if err == nil {
+			err = s.restoreURL(url)
+		}
+		if err != nil {
+			restoreErrors = append(restoreErrors, fmt.Errorf("URL ID %d: %w", id, err))
+			continue
+		}
+		restoredIDs = append(restoredIDs, id)
+	}
+
+	var restored []models.URL
+	if len(restoredIDs) > 0 {
+		if err := s.db.Preload("Tags").Where("id IN ?", restoredIDs).Find(&restored).Error; err != nil {
+			restoreErrors = append(restoreErrors, fmt.Errorf("failed to fetch restored URLs: %w", err))
+		}
+	}
+	return restored, restoreErrors
+}
+
+// PurgeTrash permanently deletes URLs in the workspace's trash: those with the
+// given IDs, or all of them. It returns the IDs that were purged.
+func (s *URLService) PurgeTrash(ids []uint, all bool) ([]uint, error) {
+	if !all && len(ids) == 0 {
+		return nil, errors.New("no IDs provided")
+	}
+
+	query := s.db.Unscoped().Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID)).Where("deleted_at IS NOT NULL")
+	if !all {
+		query = query.Where("id IN ?", ids)
+	}
+	var trashedIDs []uint
+	if err := query.Pluck("id", &trashedIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find URLs in trash: %w", err)
+	}
+	if len(trashedIDs) == 0 {
+		return nil, errors.New("no URLs found in trash")
+	}
+
+	if err := purgeURLs(s.db, trashedIDs); err != nil {
+		return nil, err
+	}
+	log.Printf("Purged %d URLs from trash", len(trashedIDs))
+	return trashedIDs, nil
+}
+
+// purgeURLs permanently deletes URLs together with their analyses, findings,
+// alerts, uptime probes and tags, and the budgets, schedules and alert rules
+// that target them
+func purgeURLs(db *gorm.DB, ids []uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range urlOwnedModels {
+			if err := tx.Where("url_id IN ?", ids).Delete(model).Error; err != nil {
+				return fmt.Errorf("failed to purge URL data: %w", err)
+			}
+		}
+		for _, model := range urlTargetedModels {
+			if err := tx.Where("url_id IN ?", ids).Delete(model).Error; err != nil {
+				return fmt.Errorf("failed to purge URL configuration: %w", err)
+			}
+		}
+		if err := tx.Exec("DELETE FROM url_tags WHERE url_id IN ?", ids).Error; err != nil {
+			return fmt.Errorf("failed to purge URL tags: %w", err)
+		}
+		if err := tx.Unscoped().Where("id IN ?", ids).Delete(&models.URL{}).Error; err != nil {
+			return fmt.Errorf("failed to purge URLs: %w", err)
+		}
+		return nil
+	})
+}
+
+// resolveFolder checks that a folder belongs to the workspace. It returns nil
+// for a nil or zero ID, which means no folder.
+func (s *URLService) resolveFolder(id *uint) (*uint, error) {
+	if id == nil || *id == 0 {
+		return nil, nil
+	}
+	var folder models.Folder
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&folder, *id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("folder not found")
+		}
+		return nil, fmt.Errorf("failed to find folder: %w", err)
+	}
+	return &folder.ID, nil
+}
+
+// findURLsForBulkChange returns the workspace's URLs with the given IDs
+func (s *URLService) findURLsForBulkChange(ids []uint) ([]uint, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no IDs provided")
+	}
+	var found []uint
+	if err := s.db.Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID)).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return nil, fmt.Errorf("failed to find URLs: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, errors.New("no URLs found with the provided IDs")
+	}
+	return found, nil
+}
+
+// reloadURLs fetches URLs with their tags after a bulk change
+func (s *URLService) reloadURLs(ids []uint) ([]models.URL, error) {
+	var urls []models.URL
+	if err := s.db.Preload("Tags").Where("id IN ?", ids).Order("id ASC").Find(&urls).Error; err != nil {
+		return nil, fmt.Errorf("failed to reload URLs: %w", err)
+	}
+	return urls, nil
+}
+
+// BulkTagURLs adds tags to multiple URLs, creating tags that do not exist yet
+func (s *URLService) BulkTagURLs(ids []uint, tagNames []string) ([]models.URL, error) {
+	urlIDs, err := s.findURLsForBulkChange(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		tags, err := findOrCreateTags(tx, s.workspaceID, tagNames)
+		if err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return fmt.Errorf("%w: no tag names provided", ErrInvalidTag)
+		}
+
+		rows := make([]map[string]interface{}, 0, len(urlIDs)*len(tags))
+		for _, urlID := range urlIDs {
+			for _, tag := range tags {
+				rows = append(rows, map[string]interface{}{"url_id": urlID, "tag_id": tag.ID})
+			}
+		}
+		if err := tx.Table("url_tags").Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to tag URLs: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Tagged %d URLs with %v", len(urlIDs), normalizeTagNames(tagNames))
+	return s.reloadURLs(urlIDs)
+}
+
+// BulkUntagURLs removes tags from multiple URLs. The tags themselves are kept.
+func (s *URLService) BulkUntagURLs(ids []uint, tagNames []string) ([]models.URL, error) {
+	urlIDs, err := s.findURLsForBulkChange(ids)
+	if err != nil {
+		return nil, err
+	}
+	names := normalizeTagNames(tagNames)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no tag names provided", ErrInvalidTag)
+	}
+
+	var tagIDs []uint
+	if err := s.db.Model(&models.Tag{}).Scopes(inWorkspace(s.workspaceID)).Where("name IN ?", names).Pluck("id", &tagIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find tags: %w", err)
+	}
+	if len(tagIDs) > 0 {
+		if err := s.db.Exec("DELETE FROM url_tags WHERE url_id IN ? AND tag_id IN ?", urlIDs, tagIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to untag URLs: %w", err)
+		}
+	}
+
+	log.Printf("Removed %v from %d URLs", names, len(urlIDs))
+	return s.reloadURLs(urlIDs)
+}
+
+// BulkMoveURLs moves multiple URLs into a folder, or out of their folders for a zero ID
+func (s *URLService) BulkMoveURLs(ids []uint, folderID uint) ([]models.URL, error) {
+	urlIDs, err := s.findURLsForBulkChange(ids)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := s.resolveFolder(&folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(&models.URL{}).Where("id IN ?", urlIDs).Updates(map[string]interface{}{
+		"folder_id":  folder,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to move URLs: %w", err)
+	}
+
+	log.Printf("Moved %d URLs to folder %d", len(urlIDs), folderID)
+	return s.reloadURLs(urlIDs)
+}
+
+// BulkAnalyzeURLs triggers analysis for multiple URLs by their IDs, returning
+// the IDs of the workspace's URLs it started
+func (s *URLService) BulkAnalyzeURLs(ids []uint) ([]uint, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no IDs provided")
 	}
 
 	// Start a transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
-		return fmt.Errorf("failed to start transaction: %w", tx.Error)
+		return nil, fmt.Errorf("failed to start transaction: %w", tx.Error)
 	}
 	defer func() {
 		if r := recover(); r != nil {
@@ -603,6 +1231,18 @@ func (s *URLService) BulkAnalyzeURLs(ids []uint) error {
 		}
 	}()
 
+	// Only analyze the URLs of the workspace
+	var visibleIDs []uint
+	if err := tx.Model(&models.URL{}).Scopes(inWorkspace(s.workspaceID)).Where("id IN ?", ids).Pluck("id", &visibleIDs).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to find URLs: %w", err)
+	}
+	ids = visibleIDs
+	if len(ids) == 0 {
+		tx.Rollback()
+		return nil, errors.New("no URLs found with the provided IDs")
+	}
+
 	// Update status to analyzing for all URLs
 	result := tx.Model(&models.URL{}).Where("id IN ?", ids).Updates(map[string]interface{}{
 		"status":     "analyzing",
@@ -610,18 +1250,24 @@ func (s *URLService) BulkAnalyzeURLs(ids []uint) error {
 	})
 	if result.Error != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to update URL status: %w", result.Error)
+		return nil, fmt.Errorf("failed to update URL status: %w", result.Error)
 	}
 
 	// Check if any URLs were actually updated
 	if result.RowsAffected == 0 {
 		tx.Rollback()
-		return errors.New("no URLs found with the provided IDs")
+		return nil, errors.New("no URLs found with the provided IDs")
+	}
+
+	// Count the analyses against the workspace quota; all of them start or none do
+	if err := reserveAnalyses(tx, s.workspaceID, len(ids)); err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	// Start analysis for each URL in separate goroutines
@@ -629,8 +1275,8 @@ func (s *URLService) BulkAnalyzeURLs(ids []uint) error {
 		go s.performAnalysis(id)
 	}
 
-	log.Printf("Successfully started analysis for %d URLs", result.RowsAffected)
-	return nil
+	log.Printf("Successfully started analysis for %d URLs", len(ids))
+	return ids, nil
 }
 
 // BulkImportURLs creates multiple URLs from import data
@@ -654,20 +1300,34 @@ func (s *URLService) BulkImportURLs(urls []models.URLCreateRequest) ([]models.UR
 	}()
 
 	for i, urlReq := range urls {
-		// Check if URL already exists
+		// Check if URL already exists in the workspace
 		var existingURL models.URL
-		if err := tx.Where("url = ?", urlReq.URL).First(&existingURL).Error; err == nil {
+		if err := tx.Where("workspace_id = ? AND url = ?", s.workspaceID, urlReq.URL).First(&existingURL).Error; err == nil {
 			errors = append(errors, fmt.Errorf("row %d: URL already exists: %s", i+1, urlReq.URL))
 			continue
 		}
+		if trashed := s.findTrashedURL(tx, urlReq.URL); trashed != nil {
+			errors = append(errors, fmt.Errorf("row %d: URL is in the trash, restore ID %d instead: %s", i+1, trashed.ID, urlReq.URL))
+			continue
+		}
 
 		// Create new URL record
 		url := models.URL{
-			URL:       urlReq.URL,
-			Title:     urlReq.Title,
-			Status:    "pending",
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			WorkspaceID: s.workspaceID,
+			URL:         urlReq.URL,
+			Title:       urlReq.Title,
+			Status:      "pending",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+
+		if len(urlReq.Tags) > 0 {
+			tags, err := findOrCreateTags(tx, s.workspaceID, urlReq.Tags)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("row %d: %w", i+1, err))
+				continue
+			}
+			url.Tags = tags
 		}
 
 		if err := tx.Create(&url).Error; err != nil {
@@ -678,16 +1338,44 @@ func (s *URLService) BulkImportURLs(urls []models.URLCreateRequest) ([]models.UR
 		createdURLs = append(createdURLs, url)
 	}
 
+	// Count the analyses of the imported URLs against the workspace quota; when
+	// it is exhausted the URLs are still imported but left pending
+	analyze := true
+	if len(createdURLs) > 0 {
+		if err := reserveAnalyses(tx, s.workspaceID, len(createdURLs)); err != nil {
+			analyze = false
+			errors = append(errors, fmt.Errorf("imported URLs were not analyzed: %w", err))
+		}
+	}
+
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, []error{fmt.Errorf("failed to commit transaction: %w", err)}
 	}
 
 	// Start analysis for each created URL in separate goroutines
-	for _, url := range createdURLs {
-		go s.performAnalysis(url.ID)
+	if analyze {
+		for _, url := range createdURLs {
+			go s.performAnalysis(url.ID)
+		}
 	}
 
 	log.Printf("Successfully imported %d URLs with %d errors", len(createdURLs), len(errors))
+
+	imported := models.ImportCompletedPayload{
+		ImportedCount: len(createdURLs),
+		ErrorCount:    len(errors),
+		Errors:        make([]string, 0, len(errors)),
+		URLs:          make([]models.URLResponse, 0, len(createdURLs)),
+	}
+	for _, err := range errors {
+		imported.Errors = append(imported.Errors, err.Error())
+	}
+	for _, url := range createdURLs {
+		imported.URLs = append(imported.URLs, url.ToResponse())
+		s.webhookService.publish(url.WorkspaceID, models.WebhookEventURLCreated, url.ToResponse())
+	}
+	s.webhookService.publish(s.workspaceID, models.WebhookEventImportCompleted, imported)
+
 	return createdURLs, errors
 }
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidUser is returned when a user definition fails validation
+var ErrInvalidUser = errors.New("invalid user")
+
+// exampleAPIToken is the API_TOKEN once shipped in .env.example; it is public,
+// so it must never become a key
+const exampleAPIToken = "your-secret-api-token-here"
+
+// BootstrapSettings describes the first user created on an empty database
+type BootstrapSettings struct {
+	Name     string
+	Email    string
+	APIToken string // imported as the user's first API key when set, so existing clients keep working
+	Password string // login password; also set on an existing user with this email who has none
+}
+
+// UserService handles business logic for users
+type UserService struct {
+	db *gorm.DB
+}
+
+// NewUserService creates a new user service instance
+func NewUserService() *UserService {
+	return &UserService{
+		db: database.GetDB(),
+	}
+}
+
+// GetAllUsers retrieves all users
+func (s *UserService) GetAllUsers() ([]models.User, error) {
+	var users []models.User
+	if err := s.db.Order("name ASC").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	return users, nil
+}
+
+// GetUserByID retrieves a user by ID
+func (s *UserService) GetUserByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+// CreateUser validates and creates a user
+func (s *UserService) CreateUser(req models.UserRequest) (*models.User, error) {
+	user := models.User{
+		CreatedAt: time.Now(),
+	}
+	if err := s.applyRequest(&user, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return &user, nil
+}
+
+// UpdateUser validates and replaces a user. Users cannot deactivate themselves
+// or change their own role, so an admin cannot lock every admin out.
+func (s *UserService) UpdateUser(id uint, req models.UserRequest, currentUserID uint) (*models.User, error) {
+	user, err := s.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	previousRole := user.Role
+	previousPassword := user.PasswordHash
+	if err := s.applyRequest(user, req); err != nil {
+		return nil, err
+	}
+	if user.ID == currentUserID && !user.Active {
+		return nil, fmt.Errorf("%w: you cannot deactivate yourself", ErrInvalidUser)
+	}
+	if user.ID == currentUserID && user.Role != previousRole {
+		return nil, fmt.Errorf("%w: you cannot change your own role", ErrInvalidUser)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		// A new password signs the user out of existing sessions
+		if user.PasswordHash != previousPassword {
+			if err := tx.Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", user.ID).
+				Update("revoked_at", time.Now()).Error; err != nil {
+				return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser deletes a user, their API keys, refresh tokens, saved views and
+// workspace memberships. Users cannot delete themselves.
+func (s *UserService) DeleteUser(id uint, currentUserID uint) error {
+	if id == currentUserID {
+		return fmt.Errorf("%w: you cannot delete yourself", ErrInvalidUser)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&models.APIKey{}).Error; err != nil {
+			return fmt.Errorf("failed to delete API keys: %w", err)
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.RefreshToken{}).Error; err != nil {
+			return fmt.Errorf("failed to delete refresh tokens: %w", err)
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.SavedView{}).Error; err != nil {
+			return fmt.Errorf("failed to delete saved views: %w", err)
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return fmt.Errorf("failed to delete notification preferences: %w", err)
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace memberships: %w", err)
+		}
+		result := tx.Delete(&models.User{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete user: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("user not found")
+		}
+		return nil
+	})
+}
+
+// EnsureBootstrapUser creates the first user, an admin, when there are none, so
+// the API can be reached after upgrading from the single shared token. Without
+// an APIToken a random key is generated and written to the log once.
+func (s *UserService) EnsureBootstrapUser(settings BootstrapSettings) error {
+	if settings.APIToken == exampleAPIToken {
+		return errors.New("API_TOKEN is still the example value; set your own token or leave it empty to generate a key")
+	}
+
+	var count int64
+	if err := s.db.Model(&models.User{}).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count users: %w", err)
+	}
+	if count > 0 {
+		if settings.APIToken != "" {
+			log.Println("API_TOKEN is ignored because users exist; manage keys with /api/v1/api-keys")
+		}
+		if err := s.ensureBootstrapPassword(settings); err != nil {
+			return err
+		}
+		return s.ensureAdmin()
+	}
+
+	user := models.User{
+		Name:      settings.Name,
+		Email:     strings.ToLower(settings.Email),
+		Role:      models.RoleAdmin,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	if user.Name == "" {
+		user.Name = "Administrator"
+	}
+	if settings.Password != "" {
+		hash, err := hashPassword(settings.Password)
+		if err != nil {
+			return fmt.Errorf("ADMIN_PASSWORD: %w", err)
+		}
+		user.PasswordHash = hash
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to create bootstrap user: %w", err)
+		}
+
+		if settings.APIToken != "" {
+			if _, err := createAPIKey(tx, user.ID, "API_TOKEN", settings.APIToken, "", nil); err != nil {
+				return err
+			}
+			log.Printf("Created user %s with API_TOKEN as its API key", user.Email)
+			return nil
+		}
+
+		plain, prefix, err := generateAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate API key: %w", err)
+		}
+		if _, err := createAPIKey(tx, user.ID, "Bootstrap key", plain, prefix, nil); err != nil {
+			return err
+		}
+		log.Printf("Created user %s with API key %s (shown only once)", user.Email, plain)
+		return nil
+	})
+}
+
+// ensureBootstrapPassword sets the bootstrap password on the user with the
+// bootstrap email if they have no password yet, so installations that predate
+// password login can log in
+func (s *UserService) ensureBootstrapPassword(settings BootstrapSettings) error {
+	if settings.Password == "" {
+		return nil
+	}
+	hash, err := hashPassword(settings.Password)
+	if err != nil {
+		return fmt.Errorf("ADMIN_PASSWORD: %w", err)
+	}
+	result := s.db.Model(&models.User{}).
+		Where("email = ? AND (password_hash = '' OR password_hash IS NULL)", strings.ToLower(settings.Email)).
+		Update("password_hash", hash)
+	if result.Error != nil {
+		return fmt.Errorf("failed to set bootstrap password: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Set the password of %s from ADMIN_PASSWORD", settings.Email)
+	}
+	return nil
+}
+
+// ensureAdmin promotes the oldest active user to admin when no active admin
+// exists, e.g. for users created before roles were introduced
+func (s *UserService) ensureAdmin() error {
+	var admins int64
+	if err := s.db.Model(&models.User{}).Where("role = ? AND active = ?", models.RoleAdmin, true).Count(&admins).Error; err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	}
+	if admins > 0 {
+		return nil
+	}
+
+	var user models.User
+	if err := s.db.Where("active = ?", true).Order("id ASC").First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("No active users; activate a user in the database to regain access")
+			return nil
+		}
+		return fmt.Errorf("failed to find a user to promote: %w", err)
+	}
+	if err := s.db.Model(&user).Update("role", models.RoleAdmin).Error; err != nil {
+		return fmt.Errorf("failed to promote user to admin: %w", err)
+	}
+	log.Printf("No admin found; promoted user %s to admin", user.Email)
+	return nil
+}
+
+// applyRequest validates a user request and copies it onto the model
+func (s *UserService) applyRequest(user *models.User, req models.UserRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidUser)
+	}
+	address, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		return fmt.Errorf("%w: invalid email address %q", ErrInvalidUser, req.Email)
+	}
+	email := strings.ToLower(address.Address)
+
+	// Without a role, new users are viewers and existing users keep theirs
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	if role == "" {
+		role = user.Role
+	}
+	if role == "" {
+		role = models.RoleViewer
+	}
+	if models.RoleRank(role) == 0 {
+		return fmt.Errorf("%w: role must be one of %s", ErrInvalidUser, strings.Join(models.Roles, ", "))
+	}
+
+	var existing models.User
+	if err := s.db.Where("email = ? AND id <> ?", email, user.ID).First(&existing).Error; err == nil {
+		return errors.New("user already exists")
+	}
+
+	if req.Password != "" {
+		hash, err := hashPassword(req.Password)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash = hash
+	}
+
+	user.Name = name
+	user.Email = email
+	user.Role = role
+	user.Active = true
+	if req.Active != nil {
+		user.Active = *req.Active
+	}
+	return nil
+}
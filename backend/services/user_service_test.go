@@ -0,0 +1,25 @@
+package services
+
+import (
+	"testing"
+
+	"website-analyzer-backend/models"
+)
+
+func TestEnsureBootstrapUserRejectsExampleToken(t *testing.T) {
+	db := newTestDB(t, &models.User{}, &models.APIKey{})
+	service := &UserService{db: db}
+
+	err := service.EnsureBootstrapUser(BootstrapSettings{Email: "admin@localhost", APIToken: exampleAPIToken})
+	if err == nil {
+		t.Fatal("the example API_TOKEN was accepted")
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).Count(&count).Error; err != nil {
+		t.Fatalf("counting users: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("%d users were created with the example API_TOKEN", count)
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// webhookBatchSize caps how many due deliveries are sent per tick
+	webhookBatchSize = 50
+	// maxWebhookResponseBody caps how much of a webhook response is kept in the delivery log
+	maxWebhookResponseBody = 1024
+)
+
+// WebhookSettings configures webhook delivery
+type WebhookSettings struct {
+	Tick         time.Duration // how often due deliveries are sent
+	Timeout      time.Duration // per-request timeout
+	MaxAttempts  int           // attempts before a delivery is marked failed
+	RetryBackoff time.Duration // delay before the first retry, doubled after every failure
+}
+
+// WebhookDispatcher sends queued webhook deliveries in the background,
+// retrying failed deliveries with exponential backoff
+type WebhookDispatcher struct {
+	db       *gorm.DB
+	client   *http.Client
+	settings WebhookSettings
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWebhookDispatcher creates a webhook dispatcher
+func NewWebhookDispatcher(settings WebhookSettings) *WebhookDispatcher {
+	if settings.Tick <= 0 {
+		settings.Tick = 5 * time.Second
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = 10 * time.Second
+	}
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = 6
+	}
+	if settings.RetryBackoff <= 0 {
+		settings.RetryBackoff = 30 * time.Second
+	}
+	return &WebhookDispatcher{
+		db:       database.GetDB(),
+		client:   newWebhookClient(settings.Timeout),
+		settings: settings,
+		stop:     make(chan struct{}),
+	}
+}
+
+// newWebhookClient creates the HTTP client for webhook deliveries. Webhook URLs
+// are user-supplied, so the client only connects to public addresses, checked
+// after DNS resolution, and does not follow redirects or use a proxy.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook address %s is not a public address", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicIP reports whether ip is a globally routable unicast address, not a
+// loopback, private, link-local, shared (CGNAT) or unspecified one
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	return !sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Start begins sending due deliveries in the background
+func (d *WebhookDispatcher) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.settings.Tick)
+		defer ticker.Stop()
+
+		log.Printf("Webhook dispatcher started (checking every %s)", d.settings.Tick)
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				d.dispatchDue()
+			}
+		}
+	}()
+}
+
+// Stop stops the dispatcher after the delivery in progress, or when ctx is done.
+// Pending deliveries are kept and sent after the next start.
+func (d *WebhookDispatcher) Stop(ctx context.Context) {
+	close(d.stop)
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("Webhook dispatcher stopped")
+	case <-ctx.Done():
+		log.Println("Webhook dispatcher stopped before the current delivery finished")
+	}
+}
+
+// dispatchDue sends every pending delivery whose next attempt is due
+func (d *WebhookDispatcher) dispatchDue() {
+	var deliveries []models.WebhookDelivery
+	if err := d.db.Scopes(allWorkspaces).Preload("Webhook").
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at ASC").Limit(webhookBatchSize).Find(&deliveries).Error; err != nil {
+		log.Printf("Webhook dispatcher failed to load due deliveries: %v", err)
+		return
+	}
+
+	for i := range deliveries {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+		d.deliver(&deliveries[i])
+	}
+}
+
+// deliver makes one delivery attempt and records its outcome
+func (d *WebhookDispatcher) deliver(delivery *models.WebhookDelivery) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"attempts":        delivery.Attempts + 1,
+		"last_attempt_at": now,
+		"updated_at":      now,
+	}
+
+	statusCode, body, err := d.send(delivery)
+	updates["response_status"] = statusCode
+	updates["response_body"] = body
+
+	switch {
+	case delivery.Webhook == nil || !delivery.Webhook.Enabled:
+		updates["status"] = models.WebhookDeliveryFailed
+		updates["next_attempt_at"] = nil
+		updates["error"] = "Webhook is disabled"
+	case err == nil:
+		updates["status"] = models.WebhookDeliveryDelivered
+		updates["next_attempt_at"] = nil
+		updates["delivered_at"] = now
+		updates["error"] = ""
+	case delivery.Attempts+1 >= d.settings.MaxAttempts:
+		updates["status"] = models.WebhookDeliveryFailed
+		updates["next_attempt_at"] = nil
+		updates["error"] = fmt.Sprintf("Giving up after %d attempts: %v", delivery.Attempts+1, err)
+		log.Printf("Webhook delivery %d to %s failed permanently: %v", delivery.ID, delivery.Webhook.URL, err)
+	default:
+		updates["next_attempt_at"] = now.Add(d.settings.RetryBackoff << uint(delivery.Attempts))
+		updates["error"] = err.Error()
+	}
+
+	if err := d.db.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error; err != nil {
+		log.Printf("Failed to record webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// send posts a delivery's payload to its webhook, signed with the webhook secret
+// together with the time of sending. Any non-2xx response, including a
+// redirect, is an error.
+func (d *WebhookDispatcher) send(delivery *models.WebhookDelivery) (int, string, error) {
+	webhook := delivery.Webhook
+	if webhook == nil || !webhook.Enabled {
+		return 0, "", nil
+	}
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Website-Analyzer-Webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	timestamp := time.Now().Unix()
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", SignWebhookPayload(webhook.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	body := strings.ToValidUTF8(string(raw), "")
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, body, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, body, nil
+}
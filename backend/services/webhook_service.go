@@ -0,0 +1,330 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidWebhook is returned when a webhook definition fails validation
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// WebhookService handles business logic for webhooks and their deliveries
+type WebhookService struct {
+	db          *gorm.DB
+	workspaceID uint
+}
+
+// NewWebhookService creates a new webhook service instance
+func NewWebhookService() *WebhookService {
+	return &WebhookService{
+		db: database.GetDB(),
+	}
+}
+
+// ForWorkspace returns a copy of the service whose queries are limited to a workspace
+func (s *WebhookService) ForWorkspace(workspaceID uint) *WebhookService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	return &scoped
+}
+
+// GetAllWebhooks retrieves all webhooks
+func (s *WebhookService) GetAllWebhooks() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).Order("name ASC").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// GetWebhookByID retrieves a webhook by its ID
+func (s *WebhookService) GetWebhookByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := s.db.Scopes(inWorkspace(s.workspaceID)).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// CreateWebhook validates and creates a webhook, generating a secret if none is given
+func (s *WebhookService) CreateWebhook(req models.WebhookRequest) (*models.Webhook, error) {
+	webhook := models.Webhook{
+		WorkspaceID: s.workspaceID,
+		CreatedAt:   time.Now(),
+	}
+	if err := applyWebhookRequest(&webhook, req); err != nil {
+		return nil, err
+	}
+	if webhook.Secret == "" {
+		secret, err := randomHex(32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		webhook.Secret = secret
+	}
+
+	if err := s.db.Create(&webhook).Error; err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// UpdateWebhook validates and replaces a webhook, keeping its secret unless a new one is given
+func (s *WebhookService) UpdateWebhook(id uint, req models.WebhookRequest) (*models.Webhook, error) {
+	webhook, err := s.GetWebhookByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWebhookRequest(webhook, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(webhook).Error; err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (s *WebhookService) DeleteWebhook(id uint) error {
+	if _, err := s.GetWebhookByID(id); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		result := tx.Delete(&models.Webhook{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("webhook not found")
+		}
+		return nil
+	})
+}
+
+// applyWebhookRequest validates a webhook request and copies it onto the model
+func applyWebhookRequest(webhook *models.Webhook, req models.WebhookRequest) error {
+	name := strings.TrimSpace(req.Name)
+	target := strings.TrimSpace(req.URL)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWebhook)
+	}
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an http or https URL", ErrInvalidWebhook)
+	}
+	// Addresses are checked again when connecting, after DNS resolution
+	if ip := net.ParseIP(parsed.Hostname()); (ip != nil && !isPublicIP(ip)) || strings.EqualFold(parsed.Hostname(), "localhost") {
+		return fmt.Errorf("%w: url must point to a public address", ErrInvalidWebhook)
+	}
+
+	known := map[string]bool{models.WebhookEventAll: true}
+	for _, event := range models.WebhookEvents {
+		known[event] = true
+	}
+	seen := make(map[string]bool)
+	events := make([]string, 0, len(req.Events))
+	for _, event := range req.Events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !known[event] {
+			return fmt.Errorf("%w: unknown event %q (supported: %s, or * for all)", ErrInvalidWebhook, event, strings.Join(models.WebhookEvents, ", "))
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	webhook.Name = name
+	webhook.URL = target
+	webhook.Events = strings.Join(events, ",")
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	webhook.Enabled = true
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+	webhook.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Publish queues an event for every enabled webhook of a workspace subscribed
+// to it. Pass a transaction as db to queue deliveries atomically with the change
+// they describe. Deliveries are sent by the WebhookDispatcher.
+func (s *WebhookService) Publish(db *gorm.DB, workspaceID uint, event string, data interface{}) error {
+	var webhooks []models.Webhook
+	if err := db.Where("workspace_id = ? AND enabled = ?", workspaceID, true).Find(&webhooks).Error; err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	var deliveries []models.WebhookDelivery
+	var payload []byte
+	var eventID string
+	now := time.Now()
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		if payload == nil {
+			var err error
+			if eventID, err = randomHex(16); err != nil {
+				return fmt.Errorf("failed to generate event ID: %w", err)
+			}
+			if payload, err = json.Marshal(models.WebhookPayload{
+				EventID:   eventID,
+				Event:     event,
+				CreatedAt: now,
+				Data:      data,
+			}); err != nil {
+				return fmt.Errorf("failed to encode %s payload: %w", event, err)
+			}
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := db.Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to queue %s deliveries: %w", event, err)
+	}
+	return nil
+}
+
+// publish queues an event outside of a transaction, logging failures so
+// they never affect the operation that raised the event
+func (s *WebhookService) publish(workspaceID uint, event string, data interface{}) {
+	if err := s.Publish(s.db, workspaceID, event, data); err != nil {
+		log.Printf("Failed to publish webhook event %s: %v", event, err)
+	}
+}
+
+// WebhookDeliveryFilters represents filters for webhook delivery queries
+type WebhookDeliveryFilters struct {
+	Status string
+	Event  string
+}
+
+// GetDeliveries retrieves a webhook's deliveries with pagination and filtering, newest first
+func (s *WebhookService) GetDeliveries(webhookID uint, page, limit int, filters WebhookDeliveryFilters) ([]models.WebhookDelivery, int64, error) {
+	if _, err := s.GetWebhookByID(webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := s.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if filters.Status != "" {
+		query = query.Where("status = ?", strings.ToLower(filters.Status))
+	}
+	if filters.Event != "" {
+		query = query.Where("event = ?", strings.ToLower(filters.Event))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Order("id DESC").Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, total, nil
+}
+
+// GetDelivery retrieves a delivery of a webhook by its ID
+func (s *WebhookService) GetDelivery(webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	if _, err := s.GetWebhookByID(webhookID); err != nil {
+		return nil, err
+	}
+
+	var delivery models.WebhookDelivery
+	if err := s.db.Where("webhook_id = ?", webhookID).First(&delivery, deliveryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// Redeliver queues a new delivery with the same payload as an earlier one
+func (s *WebhookService) Redeliver(webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	original, err := s.GetDelivery(webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery := models.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.ID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.db.Create(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature header value for a payload
+// sent at timestamp (Unix seconds, the X-Webhook-Timestamp header): "sha256="
+// followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// webhook secret. Signing the timestamp lets receivers reject replayed requests.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex returns n random bytes encoded as hex
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"website-analyzer-backend/models"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"url.created"}`)
+
+	// Expected values are the HMAC-SHA256 of "<timestamp>.<body>", computed independently
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		payload   []byte
+		want      string
+	}{
+		{"payload", "s3cret", 1700000000, body, "sha256=f0b96fead8ea5498ecf535210e6c2c26dfa67b16591024641bfbeff9d5d398b3"},
+		{"later timestamp", "s3cret", 1700000001, body, "sha256=f22ae4139b4c44c212c2a37684bd71c0a0828de796227c70082b49ad8a037230"},
+		{"other secret", "other", 1700000000, body, "sha256=e8a456467819f54cb74e547f24dc5f1d4df53c10bcffa63d1f4b273c6a6a5789"},
+		{"empty body", "s3cret", 1700000000, nil, "sha256=21948100f1d7a89f3338f6b1106fc4f7a702fbe1493b833a3382f80193bde3fe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignWebhookPayload(tt.secret, tt.timestamp, tt.payload); got != tt.want {
+				t.Errorf("SignWebhookPayload = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyWebhookRequest(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name    string
+		req     models.WebhookRequest
+		wantErr bool
+	}{
+		{"public URL", models.WebhookRequest{Name: "CI", URL: "https://ci.example.com/hook", Events: []string{"*"}}, false},
+		{"disabled", models.WebhookRequest{Name: "CI", URL: "http://203.0.113.7/hook", Enabled: &disabled}, false},
+		{"missing name", models.WebhookRequest{URL: "https://ci.example.com/hook"}, true},
+		{"other scheme", models.WebhookRequest{Name: "CI", URL: "ftp://ci.example.com/hook"}, true},
+		{"no host", models.WebhookRequest{Name: "CI", URL: "https:///hook"}, true},
+		{"localhost", models.WebhookRequest{Name: "CI", URL: "http://localhost:8080/hook"}, true},
+		{"loopback", models.WebhookRequest{Name: "CI", URL: "http://127.0.0.1/hook"}, true},
+		{"metadata service", models.WebhookRequest{Name: "CI", URL: "http://169.254.169.254/latest/meta-data"}, true},
+		{"private network", models.WebhookRequest{Name: "CI", URL: "https://10.1.2.3/hook"}, true},
+		{"IPv6 loopback", models.WebhookRequest{Name: "CI", URL: "http://[::1]/hook"}, true},
+		{"unknown event", models.WebhookRequest{Name: "CI", URL: "https://ci.example.com/hook", Events: []string{"url.renamed"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var webhook models.Webhook
+			err := applyWebhookRequest(&webhook, tt.req)
+			if tt.wantErr && !errors.Is(err, ErrInvalidWebhook) {
+				t.Errorf("applyWebhookRequest = %v, want ErrInvalidWebhook", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("applyWebhookRequest: %v", err)
+			}
+		})
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
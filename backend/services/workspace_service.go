@@ -0,0 +1,508 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"website-analyzer-backend/database"
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// defaultWorkspaceName names the workspace created for data that predates workspaces
+const defaultWorkspaceName = "Default"
+
+// ErrInvalidWorkspace is returned when a workspace or membership request fails validation
+var ErrInvalidWorkspace = errors.New("invalid workspace")
+
+// ErrWorkspaceAccess is returned when a user is not a member of a workspace
+var ErrWorkspaceAccess = errors.New("workspace access denied")
+
+// ErrWorkspaceNotFound is returned when a workspace does not exist
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+
+// workspaceOwnedModels lists the models that belong to a workspace
+var workspaceOwnedModels = []interface{}{
+	&models.URL{},
+	&models.Schedule{},
+	&models.AuditRule{},
+	&models.AlertRule{},
+	&models.Webhook{},
+	&models.PerformanceBudget{},
+	&models.NotificationPreference{},
+}
+
+// inWorkspace scopes a query on a workspace-owned table to one workspace. A zero
+// ID, such as that of a service never given a workspace, matches nothing;
+// background jobs that work across workspaces use allWorkspaces instead.
+func inWorkspace(workspaceID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if workspaceID == 0 {
+			return db.Where("1 = 0")
+		}
+		return db.Where("workspace_id = ?", workspaceID)
+	}
+}
+
+// urlInWorkspace scopes a query on a table with a url_id column, such as alerts
+// or rule violations, to the URLs of one workspace. A zero ID matches nothing.
+func urlInWorkspace(workspaceID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if workspaceID == 0 {
+			return db.Where("1 = 0")
+		}
+		urls := db.Session(&gorm.Session{NewDB: true}).Model(&models.URL{}).Select("id").Where("workspace_id = ?", workspaceID)
+		return db.Where("url_id IN (?)", urls)
+	}
+}
+
+// allWorkspaces marks a query that deliberately spans every workspace. It is
+// for background jobs, such as the scheduler, the trash purger and the webhook
+// dispatcher, that act on behalf of no single workspace.
+func allWorkspaces(db *gorm.DB) *gorm.DB {
+	return db
+}
+
+// WorkspaceService handles business logic for workspaces and their members
+type WorkspaceService struct {
+	db *gorm.DB
+}
+
+// NewWorkspaceService creates a new workspace service instance
+func NewWorkspaceService() *WorkspaceService {
+	return &WorkspaceService{
+		db: database.GetDB(),
+	}
+}
+
+// GetWorkspaces retrieves the workspaces a user can access along with their
+// role in each. Instance admins can access every workspace.
+func (s *WorkspaceService) GetWorkspaces(user *models.User) ([]models.WorkspaceResponse, error) {
+	var memberships []models.WorkspaceMember
+	if err := s.db.Where("user_id = ?", user.ID).Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get workspace memberships: %w", err)
+	}
+	roles := make(map[uint]string, len(memberships))
+	ids := make([]uint, 0, len(memberships))
+	for _, membership := range memberships {
+		roles[membership.WorkspaceID] = membership.Role
+		ids = append(ids, membership.WorkspaceID)
+	}
+
+	var workspaces []models.Workspace
+	query := s.db.Order("name ASC")
+	if !user.HasRole(models.RoleAdmin) {
+		if len(ids) == 0 {
+			return []models.WorkspaceResponse{}, nil
+		}
+		query = query.Where("id IN ?", ids)
+	}
+	if err := query.Find(&workspaces).Error; err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+
+	responses := make([]models.WorkspaceResponse, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		responses = append(responses, models.WorkspaceResponse{
+			Workspace: workspace,
+			Role:      effectiveWorkspaceRole(user, roles[workspace.ID]),
+		})
+	}
+	return responses, nil
+}
+
+// GetWorkspaceByID retrieves a workspace by its ID
+func (s *WorkspaceService) GetWorkspaceByID(id uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	if err := s.db.First(&workspace, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return &workspace, nil
+}
+
+// CreateWorkspace creates a workspace and makes its creator a workspace admin
+func (s *WorkspaceService) CreateWorkspace(req models.WorkspaceRequest, creatorID uint) (*models.Workspace, error) {
+	workspace := models.Workspace{
+		CreatedAt: time.Now(),
+	}
+	if err := s.applyRequest(&workspace, req); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&workspace).Error; err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+		member := models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      creatorID,
+			Role:        models.RoleAdmin,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return fmt.Errorf("failed to add workspace member: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// UpdateWorkspace renames a workspace or changes its analysis quota
+func (s *WorkspaceService) UpdateWorkspace(id uint, req models.WorkspaceRequest) (*models.Workspace, error) {
+	workspace, err := s.GetWorkspaceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(workspace, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(workspace).Error; err != nil {
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+	return workspace, nil
+}
+
+// DeleteWorkspace deletes an empty workspace and its memberships. Workspaces
+// that still own URLs, rules, schedules or integrations cannot be deleted.
+func (s *WorkspaceService) DeleteWorkspace(id uint) error {
+	if _, err := s.GetWorkspaceByID(id); err != nil {
+		return err
+	}
+
+	var workspaces int64
+	if err := s.db.Model(&models.Workspace{}).Count(&workspaces).Error; err != nil {
+		return fmt.Errorf("failed to count workspaces: %w", err)
+	}
+	if workspaces <= 1 {
+		return fmt.Errorf("%w: the last workspace cannot be deleted", ErrInvalidWorkspace)
+	}
+
+	for _, model := range workspaceOwnedModels {
+		var count int64
+		if err := s.db.Model(model).Scopes(inWorkspace(id)).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check workspace contents: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: workspace is not empty; delete its URLs, rules, schedules and integrations first", ErrInvalidWorkspace)
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var trashedIDs []uint
+		if err := tx.Unscoped().Model(&models.URL{}).Where("workspace_id = ? AND deleted_at IS NOT NULL", id).Pluck("id", &trashedIDs).Error; err != nil {
+			return fmt.Errorf("failed to find URLs in trash: %w", err)
+		}
+		if len(trashedIDs) > 0 {
+			if err := purgeURLs(tx, trashedIDs); err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.Tag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace tags: %w", err)
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.Folder{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace folders: %w", err)
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.SavedView{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace saved views: %w", err)
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace members: %w", err)
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.AnalysisUsage{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workspace usage: %w", err)
+		}
+		result := tx.Delete(&models.Workspace{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete workspace: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrWorkspaceNotFound
+		}
+		return nil
+	})
+}
+
+// GetMembers retrieves the members of a workspace with their users
+func (s *WorkspaceService) GetMembers(workspaceID uint) ([]models.WorkspaceMember, error) {
+	if _, err := s.GetWorkspaceByID(workspaceID); err != nil {
+		return nil, err
+	}
+
+	var members []models.WorkspaceMember
+	if err := s.db.Preload("User").Where("workspace_id = ?", workspaceID).Order("id ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to get workspace members: %w", err)
+	}
+	return members, nil
+}
+
+// SetMember adds a user to a workspace or changes their role in it
+func (s *WorkspaceService) SetMember(workspaceID, userID uint, role string) (*models.WorkspaceMember, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if models.RoleRank(role) == 0 {
+		return nil, fmt.Errorf("%w: role must be one of %s", ErrInvalidWorkspace, strings.Join(models.Roles, ", "))
+	}
+	if _, err := s.GetWorkspaceByID(workspaceID); err != nil {
+		return nil, err
+	}
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var member models.WorkspaceMember
+	err := s.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error
+	switch {
+	case err == nil:
+		if member.Role == models.RoleAdmin && role != models.RoleAdmin {
+			if err := s.ensureOtherAdmin(workspaceID, userID); err != nil {
+				return nil, err
+			}
+		}
+		member.Role = role
+		member.UpdatedAt = time.Now()
+		if err := s.db.Omit("User", "Workspace").Save(&member).Error; err != nil {
+			return nil, fmt.Errorf("failed to update workspace member: %w", err)
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		member = models.WorkspaceMember{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+			Role:        role,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+		if err := s.db.Create(&member).Error; err != nil {
+			return nil, fmt.Errorf("failed to add workspace member: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+
+	member.User = &user
+	return &member, nil
+}
+
+// RemoveMember removes a user from a workspace
+func (s *WorkspaceService) RemoveMember(workspaceID, userID uint) error {
+	var member models.WorkspaceMember
+	if err := s.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("workspace member not found")
+		}
+		return fmt.Errorf("failed to get workspace member: %w", err)
+	}
+	if member.Role == models.RoleAdmin {
+		if err := s.ensureOtherAdmin(workspaceID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return fmt.Errorf("failed to delete notification preferences: %w", err)
+		}
+		if err := tx.Delete(&member).Error; err != nil {
+			return fmt.Errorf("failed to remove workspace member: %w", err)
+		}
+		return nil
+	})
+}
+
+// ResolveWorkspace returns the workspace a request acts on and the user's role
+// in it. Without a requested ID it falls back to the user's first workspace.
+func (s *WorkspaceService) ResolveWorkspace(user *models.User, requestedID uint) (*models.Workspace, string, error) {
+	if requestedID != 0 {
+		workspace, err := s.GetWorkspaceByID(requestedID)
+		if err != nil {
+			return nil, "", err
+		}
+		role, err := s.MemberRole(workspace.ID, user.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		if role = effectiveWorkspaceRole(user, role); role == "" {
+			return nil, "", fmt.Errorf("%w: you are not a member of workspace %d", ErrWorkspaceAccess, workspace.ID)
+		}
+		return workspace, role, nil
+	}
+
+	var member models.WorkspaceMember
+	err := s.db.Preload("Workspace").Where("user_id = ?", user.ID).Order("workspace_id ASC").First(&member).Error
+	if err == nil && member.Workspace != nil {
+		return member.Workspace, effectiveWorkspaceRole(user, member.Role), nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", fmt.Errorf("failed to get workspace membership: %w", err)
+	}
+
+	if user.HasRole(models.RoleAdmin) {
+		var workspace models.Workspace
+		if err := s.db.Order("id ASC").First(&workspace).Error; err == nil {
+			return &workspace, models.RoleAdmin, nil
+		}
+	}
+	return nil, "", fmt.Errorf("%w: you are not a member of any workspace", ErrWorkspaceAccess)
+}
+
+// EnsureDefaultWorkspace creates the default workspace when there are none and
+// moves data that predates workspaces into it. On the first start with
+// workspaces, before anyone is a member of one, every user is added to it with
+// their user role; users created later only join the workspaces they are
+// added to. It returns the oldest workspace.
+func (s *WorkspaceService) EnsureDefaultWorkspace() (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := s.db.Order("id ASC").First(&workspace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		workspace = models.Workspace{
+			Name:      defaultWorkspaceName,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := s.db.Create(&workspace).Error; err != nil {
+			return nil, fmt.Errorf("failed to create default workspace: %w", err)
+		}
+		log.Printf("Created workspace %q", workspace.Name)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range workspaceOwnedModels {
+			if err := tx.Model(model).Where("workspace_id = ?", 0).Update("workspace_id", workspace.ID).Error; err != nil {
+				return fmt.Errorf("failed to assign existing data to workspace: %w", err)
+			}
+		}
+
+		var members int64
+		if err := tx.Model(&models.WorkspaceMember{}).Count(&members).Error; err != nil {
+			return fmt.Errorf("failed to count workspace members: %w", err)
+		}
+		if members > 0 {
+			return nil
+		}
+
+		var users []models.User
+		if err := tx.Find(&users).Error; err != nil {
+			return fmt.Errorf("failed to get users: %w", err)
+		}
+		for _, user := range users {
+			member := models.WorkspaceMember{
+				WorkspaceID: workspace.ID,
+				UserID:      user.ID,
+				Role:        user.Role,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			}
+			if err := tx.Create(&member).Error; err != nil {
+				return fmt.Errorf("failed to add user %s to workspace: %w", user.Email, err)
+			}
+		}
+		if len(users) > 0 {
+			log.Printf("Added %d user(s) to workspace %q", len(users), workspace.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// addWorkspaceMember adds a new user to a workspace with their user role
+func addWorkspaceMember(tx *gorm.DB, workspaceID uint, user *models.User) error {
+	var workspace models.Workspace
+	if err := tx.First(&workspace, workspaceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("workspace %d does not exist", workspaceID)
+		}
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	member := models.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Role:        user.Role,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := tx.Create(&member).Error; err != nil {
+		return fmt.Errorf("failed to add user to workspace: %w", err)
+	}
+	return nil
+}
+
+// MemberRole returns a user's role in a workspace, or "" if they are not a member
+func (s *WorkspaceService) MemberRole(workspaceID, userID uint) (string, error) {
+	var member models.WorkspaceMember
+	err := s.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace membership: %w", err)
+	}
+	return member.Role, nil
+}
+
+// ensureOtherAdmin rejects changes that would leave a workspace without an admin member
+func (s *WorkspaceService) ensureOtherAdmin(workspaceID, userID uint) error {
+	var admins int64
+	if err := s.db.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ? AND user_id <> ?", workspaceID, models.RoleAdmin, userID).
+		Count(&admins).Error; err != nil {
+		return fmt.Errorf("failed to count workspace admins: %w", err)
+	}
+	if admins == 0 {
+		return fmt.Errorf("%w: a workspace must keep at least one admin", ErrInvalidWorkspace)
+	}
+	return nil
+}
+
+// applyRequest validates a workspace request and copies it onto the model
+func (s *WorkspaceService) applyRequest(workspace *models.Workspace, req models.WorkspaceRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWorkspace)
+	}
+
+	var existing models.Workspace
+	if err := s.db.Where("name = ? AND id <> ?", name, workspace.ID).First(&existing).Error; err == nil {
+		return errors.New("workspace already exists")
+	}
+
+	if req.MonthlyAnalysisQuota != nil {
+		if *req.MonthlyAnalysisQuota < 0 {
+			return fmt.Errorf("%w: monthly_analysis_quota must not be negative", ErrInvalidWorkspace)
+		}
+		quota := *req.MonthlyAnalysisQuota
+		workspace.MonthlyAnalysisQuota = &quota
+	}
+
+	workspace.Name = name
+	workspace.UpdatedAt = time.Now()
+	return nil
+}
+
+// effectiveWorkspaceRole returns a user's role in a workspace given their
+// membership role; instance admins are admins of every workspace
+func effectiveWorkspaceRole(user *models.User, memberRole string) string {
+	if user.HasRole(models.RoleAdmin) {
+		return models.RoleAdmin
+	}
+	return memberRole
+}
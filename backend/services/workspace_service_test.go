@@ -0,0 +1,34 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"website-analyzer-backend/models"
+
+	"gorm.io/gorm"
+)
+
+func TestWorkspaceScopesFailClosed(t *testing.T) {
+	db := newTestDB(t).Session(&gorm.Session{DryRun: true})
+
+	tests := []struct {
+		name  string
+		scope func(*gorm.DB) *gorm.DB
+		model interface{}
+		want  string
+	}{
+		{"workspace", inWorkspace(7), &[]models.URL{}, "workspace_id = ?"},
+		{"no workspace", inWorkspace(0), &[]models.URL{}, "1 = 0"},
+		{"URLs of a workspace", urlInWorkspace(7), &[]models.Alert{}, "url_id IN (SELECT"},
+		{"URLs of no workspace", urlInWorkspace(0), &[]models.Alert{}, "1 = 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := db.Scopes(tt.scope).Find(tt.model).Statement.SQL.String()
+			if !strings.Contains(sql, tt.want) {
+				t.Errorf("got %q, want it to contain %q", sql, tt.want)
+			}
+		})
+	}
+}
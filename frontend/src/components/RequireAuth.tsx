@@ -0,0 +1,26 @@
+import React, { type ReactNode } from 'react';
+import { Navigate, useLocation } from 'react-router-dom';
+import { useAuth } from '../hooks/useAuth';
+import { LoadingSpinner } from './LoadingSpinner';
+
+/**
+ * Renders its children for signed-in users and sends everyone else to the login page
+ */
+export const RequireAuth: React.FC<{ children: ReactNode }> = ({ children }) => {
+  const { isAuthenticated, isLoading } = useAuth();
+  const location = useLocation();
+
+  if (isLoading) {
+    return (
+      <div className='flex h-screen items-center justify-center'>
+        <LoadingSpinner />
+      </div>
+    );
+  }
+
+  if (!isAuthenticated) {
+    return <Navigate to='/login' replace state={{ from: location.pathname }} />;
+  }
+
+  return <>{children}</>;
+};
@@ -1,11 +1,13 @@
-import React, { useState, useEffect, createContext, useContext, ReactNode } from 'react';
+import React, { useState, useEffect, createContext, useContext, type ReactNode } from 'react';
+import { apiClient, type ApiResponse, type TokenResponse } from '../services/apiClient';
 
 // User type definition
 export interface User {
-  id: string;
+  id: number;
   email: string;
   name: string;
-  role: 'admin' | 'user';
+  role: 'viewer' | 'analyst' | 'admin';
+  active: boolean;
 }
 
 // Auth context type
@@ -14,8 +16,7 @@ interface AuthContextType {
   isLoading: boolean;
   isAuthenticated: boolean;
   login: (email: string, password: string) => Promise<void>;
-  logout: () => void;
-  register: (email: string, password: string, name: string) => Promise<void>;
+  logout: () => Promise<void>;
 }
 
 // Create auth context
@@ -26,81 +27,50 @@ export const AuthProvider: React.FC<{ children: ReactNode }> = ({ children }) =>
   const [user, setUser] = useState<User | null>(null);
   const [isLoading, setIsLoading] = useState(true);
 
-  // Check for existing session on mount
+  // Restore the session on mount; the API client refreshes an expired access token
   useEffect(() => {
+    apiClient.onUnauthorized(() => setUser(null));
+
     const checkAuth = async () => {
       try {
-        const token = localStorage.getItem('auth_token');
-        if (token) {
-          // In a real app, you would validate the token with your API
-          // For now, we'll just simulate a user
-          const mockUser: User = {
-            id: '1',
-            email: 'user@example.com',
-            name: 'John Doe',
-            role: 'user',
-          };
-          setUser(mockUser);
+        if (apiClient.getRefreshToken()) {
+          const response = await apiClient.get<ApiResponse<{ user: User }>>('/me');
+          setUser(response.data.user);
         }
       } catch (error) {
         console.error('Auth check failed:', error);
-        localStorage.removeItem('auth_token');
+        apiClient.clearAuthTokens();
       } finally {
         setIsLoading(false);
       }
     };
 
     checkAuth();
+    return () => apiClient.onUnauthorized(null);
   }, []);
 
   const login = async (email: string, password: string): Promise<void> => {
     setIsLoading(true);
     try {
-      // In a real app, you would call your API here
-      // For now, we'll simulate a successful login
-      await new Promise(resolve => setTimeout(resolve, 1000)); // Simulate API call
-
-      const mockUser: User = {
-        id: '1',
-        email,
-        name: 'John Doe',
-        role: 'user',
-      };
-
-      setUser(mockUser);
-      localStorage.setItem('auth_token', 'mock_token_123');
-    } catch (error) {
-      throw new Error('Login failed');
-    } finally {
-      setIsLoading(false);
-    }
-  };
-
-  const register = async (email: string, password: string, name: string): Promise<void> => {
-    setIsLoading(true);
-    try {
-      // In a real app, you would call your API here
-      await new Promise(resolve => setTimeout(resolve, 1000)); // Simulate API call
-
-      const mockUser: User = {
-        id: '1',
+      const response = await apiClient.post<ApiResponse<TokenResponse>>('/auth/login', {
         email,
-        name,
-        role: 'user',
-      };
-
-      setUser(mockUser);
-      localStorage.setItem('auth_token', 'mock_token_123');
-    } catch (error) {
-      throw new Error('Registration failed');
+        password,
+      });
+      apiClient.setAuthTokens(response.data.access_token, response.data.refresh_token);
+      setUser(response.data.user as User);
     } finally {
       setIsLoading(false);
     }
   };
 
-  const logout = (): void => {
+  const logout = async (): Promise<void> => {
+    const refreshToken = apiClient.getRefreshToken();
+    apiClient.clearAuthTokens();
     setUser(null);
-    localStorage.removeItem('auth_token');
+    if (refreshToken) {
+      // Revoke the refresh token; the session is gone locally either way
+      await apiClient.post('/auth/logout', { refresh_token: refreshToken }).catch(() => undefined);
+    }
   };
 
   const value: AuthContextType = {
@@ -109,7 +79,6 @@ export const AuthProvider: React.FC<{ children: ReactNode }> = ({ children }) =>
     isAuthenticated: !!user,
     login,
     logout,
-    register,
   };
 
   return <AuthContext.Provider value={value}>{children}</AuthContext.Provider>;
@@ -0,0 +1,96 @@
+import React, { useState } from 'react';
+import { Navigate, useLocation, useNavigate } from 'react-router-dom';
+import { Button } from '../components/Button';
+import { useAuth } from '../hooks/useAuth';
+import { ApiError } from '../services/apiClient';
+
+const Login: React.FC = () => {
+  const { login, isAuthenticated, isLoading } = useAuth();
+  const navigate = useNavigate();
+  const location = useLocation();
+  const [email, setEmail] = useState('');
+  const [password, setPassword] = useState('');
+  const [error, setError] = useState<string | null>(null);
+
+  // Return to the page that required authentication
+  const from = (location.state as { from?: string } | null)?.from || '/dashboard';
+
+  if (isAuthenticated) {
+    return <Navigate to={from} replace />;
+  }
+
+  const handleSubmit = async (event: React.FormEvent) => {
+    event.preventDefault();
+    setError(null);
+    try {
+      await login(email, password);
+      navigate(from, { replace: true });
+    } catch (err) {
+      setError(err instanceof ApiError && err.status === 401 ? 'Invalid email or password' : 'Login failed');
+    }
+  };
+
+  return (
+    <div className='min-h-screen bg-gray-50 flex flex-col justify-center py-12 sm:px-6 lg:px-8'>
+      <div className='sm:mx-auto sm:w-full sm:max-w-md'>
+        <h1 className='text-center text-2xl font-bold text-gray-900'>Website Analyzer</h1>
+        <p className='mt-2 text-center text-sm text-gray-500'>Sign in to your account</p>
+      </div>
+
+      <div className='mt-8 sm:mx-auto sm:w-full sm:max-w-md'>
+        <form
+          onSubmit={handleSubmit}
+          className='space-y-6 bg-white py-8 px-4 shadow sm:rounded-lg sm:px-10'
+        >
+          <div>
+            <label htmlFor='email' className='block text-sm font-medium text-gray-700'>
+              Email
+            </label>
+            <div className='mt-1'>
+              <input
+                type='email'
+                id='email'
+                autoComplete='email'
+                required
+                value={email}
+                onChange={event => setEmail(event.target.value)}
+                className='block w-full py-2 px-2 rounded-md border border-gray-200 shadow-sm focus:border-blue-500 focus:ring-blue-500 sm:text-sm'
+                data-testid='email-input'
+              />
+            </div>
+          </div>
+
+          <div>
+            <label htmlFor='password' className='block text-sm font-medium text-gray-700'>
+              Password
+            </label>
+            <div className='mt-1'>
+              <input
+                type='password'
+                id='password'
+                autoComplete='current-password'
+                required
+                value={password}
+                onChange={event => setPassword(event.target.value)}
+                className='block w-full py-2 px-2 rounded-md border border-gray-200 shadow-sm focus:border-blue-500 focus:ring-blue-500 sm:text-sm'
+                data-testid='password-input'
+              />
+            </div>
+          </div>
+
+          {error && (
+            <p className='text-sm text-red-600' role='alert' data-testid='login-error'>
+              {error}
+            </p>
+          )}
+
+          <Button type='submit' className='w-full' loading={isLoading} data-testid='login-button'>
+            Sign in
+          </Button>
+        </form>
+      </div>
+    </div>
+  );
+};
+
+export default Login;
@@ -2,13 +2,23 @@ import { createBrowserRouter, Navigate } from 'react-router-dom';
 import SidebarLayout from './layouts/SidebarLayout';
 import Dashboard from './pages/Dashboard';
 import NotFound from './pages/NotFound';
+import Login from './pages/Login';
+import { RequireAuth } from './components/RequireAuth';
 import URLManagement from './features/urlManagement/pages/URLManagement';
 import URLDetails from './features/dashboard/pages/URLDetails';
 
 export const router = createBrowserRouter([
+  {
+    path: '/login',
+    element: <Login />,
+  },
   {
     path: '/',
-    element: <SidebarLayout />,
+    element: (
+      <RequireAuth>
+        <SidebarLayout />
+      </RequireAuth>
+    ),
     children: [
       {
         index: true,
@@ -18,6 +18,20 @@ export interface ApiResponse<T = any> {
   success: boolean;
 }
 
+// Tokens issued by POST /auth/login and POST /auth/refresh
+export interface TokenResponse {
+  access_token: string;
+  token_type: string;
+  expires_in: number;
+  refresh_token: string;
+  refresh_expires_in: number;
+  user: any;
+}
+
+// localStorage keys of the current session's tokens
+const ACCESS_TOKEN_KEY = 'access_token';
+const REFRESH_TOKEN_KEY = 'refresh_token';
+
 // API Client configuration
 interface ApiClientConfig {
   baseURL: string;
@@ -31,6 +45,8 @@ class ApiClient {
   private defaultHeaders: Record<string, string>;
   private requestInterceptors: Array<(config: RequestInit) => RequestInit> = [];
   private responseInterceptors: Array<(response: any) => any> = [];
+  private refreshPromise: Promise<boolean> | null = null;
+  private unauthorizedHandler: (() => void) | null = null;
 
   constructor(config: ApiClientConfig) {
     this.baseURL = config.baseURL;
@@ -83,7 +99,7 @@ class ApiClient {
     this.responseInterceptors.push(interceptor);
   }
 
-  private async request<T>(endpoint: string, options: RequestInit = {}): Promise<T> {
+  private async request<T>(endpoint: string, options: RequestInit = {}, retried = false): Promise<T> {
     const url = `${this.baseURL}${endpoint}`;
 
     // Don't set Content-Type for FormData - let browser handle it
@@ -128,11 +144,14 @@ class ApiClient {
       if (!response.ok) {
         const errorData = await response.json().catch(() => ({}));
 
-        // Handle authentication errors
-        if (response.status === 401) {
-          // Clear stored token on unauthorized
-          localStorage.removeItem('api_token');
-          this.removeHeader('Authorization');
+        // Handle authentication errors: the access token may have expired, so
+        // refresh it once and retry before giving up
+        if (response.status === 401 && !endpoint.startsWith('/auth/')) {
+          if (!retried && (await this.refreshAccessToken())) {
+            return this.request<T>(endpoint, options, true);
+          }
+          this.clearAuthTokens();
+          this.unauthorizedHandler?.();
         }
 
         throw new ApiError(
@@ -232,19 +251,61 @@ class ApiClient {
   }
 
   /**
-   * Set authentication token
+   * Store the access and refresh tokens issued by a login or refresh
    */
-  setAuthToken(token: string): void {
-    this.setHeader('Authorization', `Bearer ${token}`);
-    localStorage.setItem('api_token', token);
+  setAuthTokens(accessToken: string, refreshToken: string): void {
+    this.setHeader('Authorization', `Bearer ${accessToken}`);
+    localStorage.setItem(ACCESS_TOKEN_KEY, accessToken);
+    localStorage.setItem(REFRESH_TOKEN_KEY, refreshToken);
   }
 
   /**
-   * Clear authentication token
+   * Clear the stored access and refresh tokens
    */
-  clearAuthToken(): void {
+  clearAuthTokens(): void {
     this.removeHeader('Authorization');
-    localStorage.removeItem('api_token');
+    localStorage.removeItem(ACCESS_TOKEN_KEY);
+    localStorage.removeItem(REFRESH_TOKEN_KEY);
+  }
+
+  /**
+   * Get the refresh token of the current session, if any
+   */
+  getRefreshToken(): string | null {
+    return localStorage.getItem(REFRESH_TOKEN_KEY);
+  }
+
+  /**
+   * Register a callback for when the session cannot be refreshed
+   */
+  onUnauthorized(handler: (() => void) | null): void {
+    this.unauthorizedHandler = handler;
+  }
+
+  /**
+   * Exchange the refresh token for new tokens. Concurrent callers share one
+   * request, since each refresh token can only be used once.
+   */
+  private refreshAccessToken(): Promise<boolean> {
+    const refreshToken = this.getRefreshToken();
+    if (!refreshToken) {
+      return Promise.resolve(false);
+    }
+
+    if (!this.refreshPromise) {
+      this.refreshPromise = this.post<ApiResponse<TokenResponse>>('/auth/refresh', {
+        refresh_token: refreshToken,
+      })
+        .then(response => {
+          this.setAuthTokens(response.data.access_token, response.data.refresh_token);
+          return true;
+        })
+        .catch(() => false)
+        .finally(() => {
+          this.refreshPromise = null;
+        });
+    }
+    return this.refreshPromise;
   }
 }
 
@@ -253,10 +314,10 @@ export const apiClient = new ApiClient({
   baseURL: import.meta.env.VITE_API_BASE_URL || 'http://localhost:8080/api/v1',
 });
 
-// Set up authentication token if available
-const token = localStorage.getItem('api_token') || import.meta.env.VITE_API_TOKEN;
-if (token) {
-  apiClient.setHeader('Authorization', `Bearer ${token}`);
+// Restore the access token of a previous session
+const accessToken = localStorage.getItem(ACCESS_TOKEN_KEY);
+if (accessToken) {
+  apiClient.setHeader('Authorization', `Bearer ${accessToken}`);
 }
 
 export default apiClient;